@@ -56,6 +56,10 @@ func New(cfg *config.Amp) (*amp, error) {
 		return nil, fmt.Errorf("The storage element is missing from the amp configuration.")
 	}
 
+	if err := cfg.TagPolicy().Validate(); err != nil {
+		return nil, err
+	}
+
 	a := &amp{
 		Amp: cfg,
 	}
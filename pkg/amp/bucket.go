@@ -29,6 +29,7 @@ package amp
 import (
 	"fmt"
 
+	"github.com/cisco/arc/pkg/aaa"
 	"github.com/cisco/arc/pkg/config"
 	"github.com/cisco/arc/pkg/help"
 	"github.com/cisco/arc/pkg/log"
@@ -164,7 +165,31 @@ func (b *bucket) Audit(flags ...string) error {
 	if len(flags) == 0 || flags[0] == "" {
 		return fmt.Errorf("No flag set to find the audit object")
 	}
-	return b.providerBucket.Audit(flags...)
+	if err := b.providerBucket.Audit(flags...); err != nil {
+		return err
+	}
+	return b.auditTags(flags[0])
+}
+
+// auditTags reports the problems with the tags of the bucket when amp has a
+// tag policy.
+func (b *bucket) auditTags(flag string) error {
+	policy := b.Storage().Amp().TagPolicy()
+	if policy == nil || b.Destroyed() {
+		return nil
+	}
+	a := aaa.AuditBuffer[flag]
+	if a == nil {
+		return fmt.Errorf("Audit object doesn't exist")
+	}
+	tags, err := b.providerBucket.Tags()
+	if err != nil {
+		return err
+	}
+	for _, p := range policy.Check("bucket", tags) {
+		a.Audit(aaa.Mismatched, "bucket %s | %s", b.Name(), p)
+	}
+	return nil
 }
 
 func (b *bucket) Provision(flags ...string) error {
@@ -220,6 +245,26 @@ func (b *bucket) createSecurityTags() error {
 	for k, v := range b.SecurityTags() {
 		tags[k] = v
 	}
+
+	// Setting the tags replaces all of them, so the tags required by the tag
+	// policy are added to the security tags.
+	if policy := b.Storage().Amp().TagPolicy(); policy != nil {
+		deployed, err := b.providerBucket.Tags()
+		if err != nil {
+			return err
+		}
+		for k, v := range tags {
+			deployed[k] = v
+		}
+		fixed, problems := policy.Fix("bucket", deployed, nil, tags)
+		for k, v := range fixed {
+			deployed[k] = v
+		}
+		for _, p := range problems {
+			msg.Warn("Unable to fix bucket %s, %s", b.Name(), p)
+		}
+		tags = deployed
+	}
 	return b.SetTags(tags)
 }
 
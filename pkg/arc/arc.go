@@ -133,7 +133,11 @@ func (a *arc) Run() (int, error) {
 			break
 		}
 		log.Info("Loading arc: %q", a.Name())
-		if resp := a.Route(req.Clone(route.Load)); resp != route.OK {
+		load := a.loadRequest(req)
+		if err := a.loadCaches(load, req.Command().ReadOnly()); err != nil {
+			return 1, err
+		}
+		if resp := a.Route(load); resp != route.OK {
 			return 1, fmt.Errorf("Failed to load datacenter %s", a.Name())
 		}
 		log.Info("Loading complete")
@@ -149,6 +153,80 @@ func (a *arc) Run() (int, error) {
 	return 0, nil
 }
 
+// loadRequest creates the load request used prior to routing req. A read only
+// request, such as info or audit, for a pod or an instance only needs to load
+// that pod or instance, all other requests load everything.
+func (a *arc) loadRequest(req *route.Request) *route.Request {
+	if !req.Command().ReadOnly() || a.datacenter == nil || a.datacenter.compute == nil {
+		return req.Clone(route.Load)
+	}
+	switch req.Top() {
+	case "pod", "instance":
+		return req.CloneWithPath(route.Load)
+	}
+	return req.Clone(route.Load)
+}
+
+// loadCaches loads the provider caches concurrently ahead of the load request.
+// When the load request has a path, only the instances along that path, and
+// their dns records, are cached.
+func (a *arc) loadCaches(load *route.Request, readOnly bool) error {
+	var compute resource.ProviderCompute
+	if a.datacenter != nil && a.datacenter.compute != nil {
+		compute = a.datacenter.compute.ProviderCompute()
+	}
+	var dns resource.ProviderDns
+	if a.dns != nil {
+		dns = a.dns.ProviderDns()
+	}
+
+	names := []string{}
+	records := []string{}
+	if load.Top() != "" {
+		if compute == nil {
+			return nil
+		}
+		pod, instances := a.datacenter.compute.scopedInstances(load.Path().Clone())
+		if len(instances) == 0 {
+			return nil
+		}
+		for _, i := range instances {
+			names = append(names, i.Name())
+			records = append(records, i.PrivateHostname())
+			if i.PublicHostname() != "" {
+				records = append(records, i.PublicHostname())
+			}
+		}
+		if a.dns != nil {
+			records = append(records, a.dns.podRecordNames(pod.Name())...)
+		}
+	}
+
+	errs := make(chan error, 2)
+	go func() {
+		if compute == nil {
+			errs <- nil
+			return
+		}
+		errs <- compute.LoadCache(names, readOnly)
+	}()
+	go func() {
+		if dns == nil {
+			errs <- nil
+			return
+		}
+		errs <- dns.LoadCache(records, readOnly)
+	}()
+
+	var err error
+	for i := 0; i < 2; i++ {
+		if e := <-errs; e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
 // DataCenter satisfies the resource.Arc interface and provides access
 // to arc's datacenter service object.
 func (a *arc) DataCenter() resource.DataCenter {
@@ -195,7 +273,7 @@ func (a *arc) Route(req *route.Request) route.Response {
 	switch req.Top() {
 	case "":
 		break
-	case "network", "subnet", "secgroup", "compute", "keypair", "cluster", "pod", "instance", "volume", "eip":
+	case "network", "subnet", "secgroup", "compute", "keypair", "cluster", "pod", "instance", "volume", "eip", "image", "tags":
 		if a.datacenter == nil {
 			msg.Error("Datacenter not defined in the config file")
 			return route.FAIL
@@ -264,6 +342,8 @@ func Help() {
 		{Name: "cluster 'name'", Desc: "manage named cluster"},
 		{Name: "pod 'name'", Desc: "manage named pod"},
 		{Name: "instance 'name'", Desc: "manage named instance"},
+		{Name: "image 'pod'", Desc: "manage images baked for named pod"},
+		{Name: "tags", Desc: "audit and enforce the tag policy"},
 		{Name: "db", Desc: "manage database service"},
 		{Name: "db 'name'", Desc: "manage named database service"},
 		{Name: "container", Desc: "manage container service"},
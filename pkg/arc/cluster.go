@@ -68,6 +68,12 @@ func newCluster(compute resource.Compute, prov provider.DataCenter, cfg *config.
 		return nil, fmt.Errorf("The pods element is missing from the compute configuration")
 	}
 
+	// Use the provider target the cluster is pinned to.
+	prov, err := prov.Target(cfg.Target())
+	if err != nil {
+		return nil, err
+	}
+
 	factory := clusterFactories[cfg.Name()]
 	if factory != nil {
 		return factory(compute, prov, cfg)
@@ -193,6 +199,10 @@ func (c *Cluster) Route(req *route.Request) route.Response {
 		return c.restart(req)
 	case route.Replace:
 		return c.replace(req)
+	case route.Resize:
+		return c.resize(req)
+	case route.Snapshot, route.Restore:
+		return c.routeToChildren(req)
 	default:
 		msg.Error("Unknown cluster command %q.", req.Command().String())
 	}
@@ -222,7 +232,11 @@ func (c *Cluster) help() {
 		{Name: route.Stop.String(), Desc: fmt.Sprintf("stop %s cluster", c.Name())},
 		{Name: route.Restart.String(), Desc: fmt.Sprintf("restart %s cluster", c.Name())},
 		{Name: route.Replace.String(), Desc: fmt.Sprintf("replace %s cluster", c.Name())},
+		{Name: route.Resize.String(), Desc: fmt.Sprintf("resize %s cluster instances to the configured type", c.Name())},
 		{Name: route.Audit.String(), Desc: fmt.Sprintf("audit %s cluster", c.Name())},
+		{Name: route.Snapshot.String(), Desc: fmt.Sprintf("snapshot %s cluster volumes, optionally name=[name]", c.Name())},
+		{Name: route.Snapshot.String() + " prune", Desc: fmt.Sprintf("prune %s cluster snapshots per the retention policy", c.Name())},
+		{Name: route.Restore.String(), Desc: fmt.Sprintf("restore %s cluster volumes, optionally snapshot=[name]", c.Name())},
 		{Name: route.Destroy.String(), Desc: fmt.Sprintf("destroy %s cluster", c.Name())},
 		{Name: route.Config.String(), Desc: fmt.Sprintf("provide the %s cluster configuration", c.Name())},
 		{Name: route.Info.String(), Desc: fmt.Sprintf("provide information about allocated %s cluster", c.Name())},
@@ -484,6 +498,40 @@ func (c *Cluster) PostReplace(req *route.Request) route.Response {
 	return route.OK
 }
 
+// Resize
+
+func (c *Cluster) resize(req *route.Request) route.Response {
+	msg.Info("Cluster Resize: %s", c.Name())
+	if c.Destroyed() {
+		msg.Detail("Cluster does not exist, skipping...")
+		return route.OK
+	}
+	if resp := c.Derived().PreResize(req); resp != route.OK {
+		return resp
+	}
+	if resp := c.Derived().Resize(req); resp != route.OK {
+		return resp
+	}
+	if resp := c.Derived().PostResize(req); resp != route.OK {
+		return resp
+	}
+	msg.Detail("Cluster Resized: %s", c.Name())
+	aaa.Accounting("Cluster resized: %s", c.Name())
+	return route.OK
+}
+
+func (c *Cluster) PreResize(req *route.Request) route.Response {
+	return route.OK
+}
+
+func (c *Cluster) Resize(req *route.Request) route.Response {
+	return c.routeToChildren(req)
+}
+
+func (c *Cluster) PostResize(req *route.Request) route.Response {
+	return route.OK
+}
+
 // Audit
 
 func (c *Cluster) Audit(flags ...string) error {
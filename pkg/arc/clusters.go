@@ -62,6 +62,16 @@ func newClusters(compute *compute, prov provider.DataCenter, cfg *config.Cluster
 		if err != nil {
 			return nil, err
 		}
+		// Instance names are expanded from naming templates, so they may collide.
+		pods, ok := cluster.Pods().(*pods)
+		if !ok {
+			return nil, fmt.Errorf("Unable to obtain the pods of cluster %q", cluster.Name())
+		}
+		for _, name := range pods.instanceNames() {
+			if c.FindInstance(name) != nil {
+				return nil, fmt.Errorf("Instance name %q of cluster %q is used by another cluster, check the naming templates", name, cluster.Name())
+			}
+		}
 		c.clusters[cluster.Name()] = cluster
 		c.Append(cluster)
 	}
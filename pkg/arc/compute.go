@@ -111,6 +111,16 @@ func (c *compute) AuditInstances(flags ...string) error {
 	return c.providerCompute.AuditInstances(flags...)
 }
 
+// AuditTags identifies any resources whose tags violate the tag policy.
+func (c *compute) AuditTags(policy *config.TagPolicy, flags ...string) error {
+	return c.providerCompute.AuditTags(policy, flags...)
+}
+
+// FixTags sets the tags of the resources whose tags violate the tag policy.
+func (c *compute) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	return c.providerCompute.FixTags(policy, defaults)
+}
+
 // auditHelper is a function that handles getting the audits completed
 func (c *compute) auditHelper(req *route.Request, auditType auditResource) route.Response {
 	// Skip if the test flag is set.
@@ -186,7 +196,7 @@ func (c *compute) FindPod(name string) resource.Pod {
 }
 
 // Find instance by name. This implies instances are named uniquely.
-// The name takes the form "<pod name>-<instance number>".
+// The name is expanded from the naming template, "<pod name>-<instance number>" by default.
 func (c *compute) FindInstance(name string) resource.Instance {
 	return c.clusters.FindInstance(name)
 }
@@ -196,6 +206,34 @@ func (c *compute) FindInstanceByIP(ip string) resource.Instance {
 	return c.clusters.FindInstanceByIP(ip)
 }
 
+// scopedInstances returns the pod named in the path and the instances of
+// the pod or instance named in the path.
+func (c *compute) scopedInstances(path *route.Path) (resource.Pod, []resource.Instance) {
+	instances := []resource.Instance{}
+	switch path.Top() {
+	case "pod":
+		pod := c.FindPod(path.Pop().Top())
+		if pod == nil {
+			return nil, instances
+		}
+		if path.Pop().Top() == "instance" {
+			if i := pod.FindInstance(path.Pop().Top()); i != nil {
+				instances = append(instances, i)
+			}
+			return pod, instances
+		}
+		for _, i := range pod.Instances().GetInstances() {
+			instances = append(instances, i)
+		}
+		return pod, instances
+	case "instance":
+		if i := c.FindInstance(path.Pop().Top()); i != nil {
+			return i.Pod(), append(instances, i)
+		}
+	}
+	return nil, instances
+}
+
 // ProviderCompute provides access to the provider specific compute.
 func (c *compute) ProviderCompute() resource.ProviderCompute {
 	return c.providerCompute
@@ -221,6 +259,26 @@ func (c *compute) Route(req *route.Request) route.Response {
 			return c.clusters.Route(req)
 		}
 		return c.auditHelper(req, inst)
+	case "image":
+		req.Pop()
+		if req.Top() == "" {
+			imageHelp("")
+			return route.FAIL
+		}
+		pod := c.FindPod(req.Top())
+		if pod == nil {
+			msg.Error("Unknown pod %q.", req.Top())
+			return route.FAIL
+		}
+		switch req.Command() {
+		case route.Bake:
+			return pod.Route(req.Pop())
+		case route.Help:
+			imageHelp(pod.Name())
+			return route.OK
+		}
+		msg.Error("No command %q found for images", req.Command())
+		return route.FAIL
 	case "volume":
 		if req.Command() != route.Audit {
 			msg.Error("No command %q found for volumes", req.Command())
@@ -51,6 +51,15 @@ func newDatabaseService(cfg *config.DatabaseService, arc *arc) (*databaseService
 	}
 	log.Debug("Initializing Database Service")
 
+	// Use the datacenter provider target the database service is pinned to.
+	if cfg.Provider == nil && cfg.Target() != "" {
+		p, err := arc.Arc.Target(cfg.Target())
+		if err != nil {
+			return nil, err
+		}
+		cfg.Provider = p
+	}
+
 	// Use the arc provider, if it exists, when the datacenter provider isn't available.
 	if cfg.Provider == nil && arc.Arc.Provider != nil {
 		cfg.Provider = arc.Arc.Provider
@@ -67,6 +67,10 @@ func newDataCenter(cfg *config.DataCenter, arc *arc) (*dataCenter, error) {
 		return nil, fmt.Errorf("The network element is missing from the datacenter configuration")
 	}
 
+	if err := cfg.TagPolicy().Validate(); err != nil {
+		return nil, err
+	}
+
 	// Use the arc provider, if it exists, when the datacenter provider isn't available.
 	if cfg.Provider == nil && arc.Arc.Provider != nil {
 		cfg.Provider = arc.Arc.Provider
@@ -168,7 +172,8 @@ func (d *dataCenter) Dns() resource.Dns {
 
 // Route satisfies the embedded resource.Resource interface in resource.DataCenter.
 // DataCenter does not directly terminate a request so only handles load and info
-// requests from it's parent.  All other commands are routed to arc's children.
+// requests from it's parent, along with the tag policy's share of audit and
+// provision tags.  All other commands are routed to arc's children.
 func (d *dataCenter) Route(req *route.Request) route.Response {
 	log.Route(req, "DataCenter")
 
@@ -194,12 +199,14 @@ func (d *dataCenter) Route(req *route.Request) route.Response {
 			return route.OK
 		}
 		return d.Compute().Route(req.Pop())
-	case "keypair", "cluster", "pod", "instance", "volume", "eip":
+	case "keypair", "cluster", "pod", "instance", "volume", "eip", "image":
 		if d.Compute() == nil {
 			msg.Error("Compute not defined in the config file")
 			return route.OK
 		}
 		return d.Compute().Route(req)
+	case "tags":
+		return d.tags(req.Pop())
 	default:
 		panic("Internal Error: Unknown path " + req.Top())
 	}
@@ -218,7 +225,17 @@ func (d *dataCenter) Route(req *route.Request) route.Response {
 		d.info(req)
 		return route.OK
 	case route.Audit:
-		return d.RouteInOrder(req)
+		if resp := d.RouteInOrder(req); resp != route.OK {
+			return resp
+		}
+		if policy := d.TagPolicy(); policy != nil {
+			return d.auditTags(policy)
+		}
+		return route.OK
+	case route.Provision:
+		if req.Flag("tags") {
+			return d.tags(req)
+		}
 	}
 	msg.Error("Internal Error: arc/datacenter.go. Unknown command %s", req.Command())
 	return route.FAIL
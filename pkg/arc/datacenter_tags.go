@@ -0,0 +1,131 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package arc
+
+import (
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// tags audits or fixes the tags of the datacenter's resources against the
+// tag policy.
+func (d *dataCenter) tags(req *route.Request) route.Response {
+	if req.Command() == route.Help {
+		tagsHelp()
+		return route.OK
+	}
+	if req.TestFlag() {
+		msg.Detail("Test. Skipping...")
+		return route.OK
+	}
+	policy := d.TagPolicy()
+	if policy == nil {
+		msg.Error("Tag policy not defined in the config file")
+		return route.FAIL
+	}
+
+	switch req.Command() {
+	case route.Audit:
+		if resp := d.auditTags(policy); resp != route.OK {
+			return resp
+		}
+		if d.Dns() != nil {
+			if err := d.Dns().AuditTags(policy, "Tags"); err != nil {
+				msg.Error(err.Error())
+				return route.FAIL
+			}
+		}
+		return route.OK
+	case route.Provision:
+		return d.fixTags(policy)
+	}
+	tagsHelp()
+	return route.FAIL
+}
+
+// auditTags reports the resources of the network and compute whose tags
+// violate the tag policy. The dns audits its own tags when audited.
+func (d *dataCenter) auditTags(policy *config.TagPolicy) route.Response {
+	if err := aaa.NewAuditWithOptions("Tags", false, false, true); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if d.Network() != nil {
+		if err := d.Network().AuditTags(policy, "Tags"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+	}
+	if d.Compute() != nil {
+		if err := d.Compute().AuditTags(policy, "Tags"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+	}
+	return route.OK
+}
+
+// fixTags sets the missing or invalid tags of the resources of the network,
+// compute and dns. Tags that can't be inherited are taken from the
+// datacenter's security tags.
+func (d *dataCenter) fixTags(policy *config.TagPolicy) route.Response {
+	defaults := map[string]string{}
+	for k, v := range d.SecurityTags() {
+		defaults[k] = v
+	}
+	if d.Network() != nil {
+		if err := d.Network().FixTags(policy, defaults); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+	}
+	if d.Compute() != nil {
+		if err := d.Compute().FixTags(policy, defaults); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+	}
+	if d.Dns() != nil {
+		if err := d.Dns().FixTags(policy, defaults); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+	}
+	return route.OK
+}
+
+func tagsHelp() {
+	commands := []help.Command{
+		{Name: route.Audit.String(), Desc: "audit the resource tags against the tag policy"},
+		{Name: route.Provision.String(), Desc: "set missing or invalid tags from the tag policy"},
+		{Name: route.Help.String(), Desc: "show this help"},
+	}
+	help.Print("tags", commands)
+}
@@ -51,7 +51,13 @@ type dns struct {
 	provider     provider.Dns
 	providerDns  resource.ProviderDns
 	aRecords     *dnsRecords
+	aaaaRecords  *dnsRecords
 	cnameRecords *dnsRecords
+	txtRecords   *dnsRecords
+	mxRecords    *dnsRecords
+	srvRecords   *dnsRecords
+	caaRecords   *dnsRecords
+	aliasRecords *dnsRecords
 	datacenter   *dataCenter
 }
 
@@ -62,14 +68,22 @@ func newDns(cfg *config.Dns, arc *arc) (*dns, error) {
 	}
 	log.Debug("Initializing Dns")
 
-	if cfg.Provider == nil && arc.Arc.Provider == nil {
+	if cfg.Provider == nil && arc.Arc.Provider == nil && cfg.Target() == "" {
 		return nil, fmt.Errorf("The provider element is missing from the dns configuration")
 	}
 	if cfg.CNameRecords == nil {
 		return nil, fmt.Errorf("The records element is missing from the dns configuration")
 	}
 
-	// Use the arc provider, if it exists, when the dns provider isn't available.
+	// Use the datacenter provider target the dns is pinned to, otherwise use
+	// the arc provider, if it exists, when the dns provider isn't available.
+	if cfg.Provider == nil && cfg.Target() != "" {
+		p, err := arc.Arc.Target(cfg.Target())
+		if err != nil {
+			return nil, err
+		}
+		cfg.Provider = p
+	}
 	if cfg.Provider == nil && arc.Arc.Provider != nil {
 		cfg.Provider = arc.Arc.Provider
 	}
@@ -110,12 +124,66 @@ func newDns(cfg *config.Dns, arc *arc) (*dns, error) {
 	}
 	d.Append(d.aRecords)
 
+	if cfg.AAAARecords == nil {
+		cfg.AAAARecords = &config.DnsRecords{}
+	}
+	d.aaaaRecords, err = newDnsAAAARecords(d, cfg.AAAARecords)
+	if err != nil {
+		return nil, err
+	}
+	d.Append(d.aaaaRecords)
+
 	d.cnameRecords, err = newDnsCNameRecords(d, cfg.CNameRecords)
 	if err != nil {
 		return nil, err
 	}
 	d.Append(d.cnameRecords)
 
+	if cfg.TXTRecords == nil {
+		cfg.TXTRecords = &config.DnsRecords{}
+	}
+	d.txtRecords, err = newDnsTXTRecords(d, cfg.TXTRecords)
+	if err != nil {
+		return nil, err
+	}
+	d.Append(d.txtRecords)
+
+	if cfg.MXRecords == nil {
+		cfg.MXRecords = &config.DnsRecords{}
+	}
+	d.mxRecords, err = newDnsMXRecords(d, cfg.MXRecords)
+	if err != nil {
+		return nil, err
+	}
+	d.Append(d.mxRecords)
+
+	if cfg.SRVRecords == nil {
+		cfg.SRVRecords = &config.DnsRecords{}
+	}
+	d.srvRecords, err = newDnsSRVRecords(d, cfg.SRVRecords)
+	if err != nil {
+		return nil, err
+	}
+	d.Append(d.srvRecords)
+
+	if cfg.CAARecords == nil {
+		cfg.CAARecords = &config.DnsRecords{}
+	}
+	d.caaRecords, err = newDnsCAARecords(d, cfg.CAARecords)
+	if err != nil {
+		return nil, err
+	}
+	d.Append(d.caaRecords)
+
+	if cfg.AliasRecords == nil {
+		cfg.AliasRecords = &config.DnsRecords{}
+	}
+	d.aliasRecords, err = newDnsAliasRecords(d, cfg.AliasRecords)
+	if err != nil {
+		return nil, err
+	}
+	d.Append(d.aliasRecords)
+
 	return d, nil
 }
 
@@ -146,16 +214,73 @@ func (d *dns) ARecords() resource.DnsRecords {
 	return d.aRecords
 }
 
+// AAAARecords satisfies the resource.DataCenter interface and provides access
+// to dns' aaaa records.
+func (d *dns) AAAARecords() resource.DnsRecords {
+	return d.aaaaRecords
+}
+
 // CNameRecords satisfies the resource.DataCenter interface and provides access
 // to dns' cname records.
 func (d *dns) CNameRecords() resource.DnsRecords {
 	return d.cnameRecords
 }
 
+// TXTRecords satisfies the resource.DataCenter interface and provides access
+// to dns' txt records.
+func (d *dns) TXTRecords() resource.DnsRecords {
+	return d.txtRecords
+}
+
+// MXRecords satisfies the resource.DataCenter interface and provides access
+// to dns' mx records.
+func (d *dns) MXRecords() resource.DnsRecords {
+	return d.mxRecords
+}
+
+// SRVRecords satisfies the resource.DataCenter interface and provides access
+// to dns' srv records.
+func (d *dns) SRVRecords() resource.DnsRecords {
+	return d.srvRecords
+}
+
+// CAARecords satisfies the resource.DataCenter interface and provides access
+// to dns' caa records.
+func (d *dns) CAARecords() resource.DnsRecords {
+	return d.caaRecords
+}
+
+// AliasRecords satisfies the resource.DataCenter interface and provides access
+// to dns' alias records.
+func (d *dns) AliasRecords() resource.DnsRecords {
+	return d.aliasRecords
+}
+
+// podRecordNames returns the names of the dns records associated with the pod.
+func (d *dns) podRecordNames(pod string) []string {
+	names := []string{}
+	for _, records := range []*dnsRecords{d.aRecords, d.aaaaRecords, d.cnameRecords, d.txtRecords, d.mxRecords, d.srvRecords, d.caaRecords, d.aliasRecords} {
+		for _, r := range records.FindByPod(pod) {
+			names = append(names, r.Name())
+		}
+	}
+	return names
+}
+
 func (d *dns) AuditDnsRecords(flags ...string) error {
 	return d.ProviderDns().AuditDnsRecords(flags...)
 }
 
+// AuditTags satisfies the resource.DynamicDns interface.
+func (d *dns) AuditTags(policy *config.TagPolicy, flags ...string) error {
+	return d.ProviderDns().AuditTags(policy, flags...)
+}
+
+// FixTags satisfies the resource.DynamicDns interface.
+func (d *dns) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	return d.ProviderDns().FixTags(policy, defaults)
+}
+
 // associate the DataCenter resource with this Dns resource.
 func (d *dns) associate(dc *dataCenter) {
 	d.datacenter = dc
@@ -181,8 +306,20 @@ func (d *dns) Route(req *route.Request) route.Response {
 		break
 	case "a":
 		return d.aRecords.Route(req.Pop())
+	case "aaaa":
+		return d.aaaaRecords.Route(req.Pop())
 	case "cname":
 		return d.cnameRecords.Route(req.Pop())
+	case "txt":
+		return d.txtRecords.Route(req.Pop())
+	case "mx":
+		return d.mxRecords.Route(req.Pop())
+	case "srv":
+		return d.srvRecords.Route(req.Pop())
+	case "caa":
+		return d.caaRecords.Route(req.Pop())
+	case "alias":
+		return d.aliasRecords.Route(req.Pop())
 	default:
 		d.help()
 		return route.FAIL
@@ -231,14 +368,16 @@ func (d *dns) Audit(flags ...string) error {
 	if err := d.AuditDnsRecords(flags...); err != nil {
 		return err
 	}
-	for _, v := range d.aRecords.dnsRecords {
-		if err := v.Audit(flags...); err != nil {
+	if d.datacenter != nil && d.datacenter.TagPolicy() != nil {
+		if err := d.AuditTags(d.datacenter.TagPolicy(), flags...); err != nil {
 			return err
 		}
 	}
-	for _, v := range d.cnameRecords.dnsRecords {
-		if err := v.Audit(flags...); err != nil {
-			return err
+	for _, records := range []*dnsRecords{d.aRecords, d.aaaaRecords, d.cnameRecords, d.txtRecords, d.mxRecords, d.srvRecords, d.caaRecords, d.aliasRecords} {
+		for _, v := range records.dnsRecords {
+			if err := v.Audit(flags...); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -250,8 +389,20 @@ func (d *dns) help() {
 		{Name: route.Destroy.String(), Desc: "destroy all dns records"},
 		{Name: "a", Desc: "manage dns a records"},
 		{Name: "a 'name'", Desc: "manage named dns a record"},
+		{Name: "aaaa", Desc: "manage dns aaaa records"},
+		{Name: "aaaa 'name'", Desc: "manage named dns aaaa record"},
 		{Name: "cname", Desc: "manage dns cname records"},
 		{Name: "cname 'name'", Desc: "manage named dns cname record"},
+		{Name: "txt", Desc: "manage dns txt records"},
+		{Name: "txt 'name'", Desc: "manage named dns txt record"},
+		{Name: "mx", Desc: "manage dns mx records"},
+		{Name: "mx 'name'", Desc: "manage named dns mx record"},
+		{Name: "srv", Desc: "manage dns srv records"},
+		{Name: "srv 'name'", Desc: "manage named dns srv record"},
+		{Name: "caa", Desc: "manage dns caa records"},
+		{Name: "caa 'name'", Desc: "manage named dns caa record"},
+		{Name: "alias", Desc: "manage dns alias records"},
+		{Name: "alias 'name'", Desc: "manage named dns alias record"},
 		{Name: route.Config.String(), Desc: "show the dns configuration"},
 		{Name: route.Info.String(), Desc: "show information about allocated dns resource"},
 		{Name: route.Help.String(), Desc: "show this help"},
@@ -90,6 +90,31 @@ func newDynamicDnsARecord(i resource.Instance, dns *dns, hostname, ipType string
 	return r, nil
 }
 
+// newDnsAAAARecord is a constructor for an "AAAA" type resource.DnsRecord.
+func newDnsAAAARecord(dns *dns, cfg *config.DnsRecord) (*dnsRecord, error) {
+	log.Debug("Initializing DNS AAAA Record %q", cfg.Name())
+	return newDnsRecord(dns, cfg, "AAAA")
+}
+
+// newDynamicDnsAAAARecord is a constructor for an "AAAA" type resource.DnsRecord for dynamic records created
+// by instances on dual stack subnets.
+func newDynamicDnsAAAARecord(i resource.Instance, dns *dns, hostname string, ignoreAudit bool) (*dnsRecord, error) {
+	r, err := newDnsAAAARecord(dns, &config.DnsRecord{
+		Name_: hostname,
+		Ttl_:  300,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.instance = i
+	r.ipType = "ipv6"
+	r.auditIgnore = ignoreAudit
+
+	dns.aaaaRecords.dnsRecords[hostname] = r
+	dns.aaaaRecords.Append(r)
+	return r, nil
+}
+
 // newDnsCNameRecord is a constructor for an "CNAME" type resource.DnsRecord.
 func newDnsCNameRecord(dns *dns, cfg *config.DnsRecord) (*dnsRecord, error) {
 	log.Debug("Initializing DNS CNAME Record %q", cfg.Name())
@@ -113,7 +138,7 @@ func (r *dnsRecord) Id() string {
 	return r.providerDnsRecord.Id()
 }
 
-// The record type, "A" or "CNAME"
+// The record type, "A", "AAAA", "CNAME", "TXT", "MX", "SRV", "CAA" or "ALIAS"
 func (r *dnsRecord) Type() string {
 	return r.recordType
 }
@@ -140,7 +165,8 @@ func (r *dnsRecord) Route(req *route.Request) route.Response {
 
 	switch req.Command() {
 	case route.Load:
-		if r.Type() == "CNAME" {
+		switch r.Type() {
+		case "CNAME", "SRV", "ALIAS":
 			if err := r.preload(); err != nil {
 				msg.Error(err.Error())
 				return route.FAIL
@@ -179,16 +205,25 @@ func (r *dnsRecord) Route(req *route.Request) route.Response {
 }
 
 func (r *dnsRecord) preload() error {
-	// The dns record config must either have a pod set or the values set.
+	t := strings.ToLower(r.Type())
+
+	// The dns record config must either have a pod set or the values set. Alias records
+	// may point at a bucket or an explicit target instead.
 	if len(r.Values()) < 1 && r.Pod() == "" {
-		return fmt.Errorf("Cannot create dns cname record for %s, no values nor pod present.", r.Name())
+		if r.Type() == "ALIAS" {
+			return nil
+		}
+		return fmt.Errorf("Cannot create dns %s record for %s, no values nor pod present.", t, r.Name())
 	}
 	// If values aren't set and pod is set, remember the associated pod. We will populate the values with the
 	// fqdn of a created instance in the pod during create.
 	if len(r.Values()) < 1 && r.Pod() != "" {
 		p := r.Dns().DataCenter().Compute().Clusters().FindPod(r.Pod())
 		if p == nil {
-			return fmt.Errorf("Cannot find pod %s configured for dns cname record %s", r.Pod(), r.Name())
+			return fmt.Errorf("Cannot find pod %s configured for dns %s record %s", r.Pod(), t, r.Name())
+		}
+		if (r.Access() == "load_balancer" || r.Type() == "ALIAS") && p.ProviderLoadBalancer() == nil {
+			return fmt.Errorf("Dns %s record %s points at the load balancer of pod %s, which doesn't have one", t, r.Name(), r.Pod())
 		}
 		log.Debug("Dns %s Record Load: Using pod %s, servertype: %s", r.Type(), p.Name(), p.ServerType())
 		r.pod = p
@@ -199,12 +234,18 @@ func (r *dnsRecord) preload() error {
 
 func (r *dnsRecord) preCreate() route.Response {
 	switch r.Type() {
-	case "A":
+	case "A", "AAAA":
 		return r.preCreateA()
 	case "CNAME":
 		return r.preCreateCName()
+	case "TXT", "MX", "CAA":
+		return route.CONTINUE
+	case "SRV":
+		return r.preCreateSRV()
+	case "ALIAS":
+		return r.preCreateAlias()
 	}
-	msg.Error("Unknown dns record type %p", r.Type())
+	msg.Error("Unknown dns record type %s", r.Type())
 	return route.FAIL
 }
 
@@ -226,9 +267,11 @@ func (r *dnsRecord) preCreateA() route.Response {
 		ip = r.instance.PrivateIPAddress()
 	case "public", "public_elastic":
 		ip = r.instance.PublicIPAddress()
+	case "ipv6":
+		ip = r.instance.Ipv6Address()
 	}
 	if ip == "" {
-		msg.Error("Missing ip address for dns A record %s", r.Name())
+		msg.Error("Missing ip address for dns %s record %s", r.Type(), r.Name())
 		return route.FAIL
 	}
 	r.SetValues([]string{ip})
@@ -240,6 +283,9 @@ func (r *dnsRecord) preCreateCName() route.Response {
 	if r.pod == nil {
 		return route.CONTINUE
 	}
+	if r.Access() == "load_balancer" {
+		return r.preCreateLoadBalancer()
+	}
 	// We need to populate the values based on the name of an instance
 	// in the pod. Look for the first created instance in the pod.
 	for _, j := range r.pod.Instances().(*instances).Get() {
@@ -260,13 +306,20 @@ func (r *dnsRecord) preCreateCName() route.Response {
 
 func (r *dnsRecord) preProvision(req *route.Request) route.Response {
 	switch r.Type() {
-	case "A":
-		// create and provision are the same for A records
+	case "A", "AAAA":
+		// create and provision are the same for A and AAAA records
 		return r.preCreateA()
 	case "CNAME":
 		return r.preProvisionCName(req)
+	case "TXT", "MX", "CAA":
+		return route.CONTINUE
+	case "SRV":
+		// Provisioning picks up the instances added to or removed from the pod.
+		return r.preCreateSRV()
+	case "ALIAS":
+		return r.preCreateAlias()
 	}
-	msg.Error("Unknown dns record type %p", r.Type())
+	msg.Error("Unknown dns record type %s", r.Type())
 	return route.FAIL
 }
 
@@ -275,6 +328,10 @@ func (r *dnsRecord) preProvisionCName(req *route.Request) route.Response {
 	if r.pod == nil {
 		return route.CONTINUE
 	}
+	// The load balancer doesn't fail over between instances.
+	if r.Access() == "load_balancer" {
+		return r.preCreateLoadBalancer()
+	}
 
 	// Attempting to set the cname to a given instance.
 	for _, f := range req.Flags().Get() {
@@ -307,6 +364,65 @@ func (r *dnsRecord) preProvisionCName(req *route.Request) route.Response {
 	return route.CONTINUE
 }
 
+// preCreateLoadBalancer populates the values with the dns name of the pod's load balancer.
+func (r *dnsRecord) preCreateLoadBalancer() route.Response {
+	lb := r.pod.ProviderLoadBalancer()
+	if lb == nil || lb.DNSName() == "" {
+		return route.OK
+	}
+	log.Debug("Dns %s Record Create: Using load balancer %s for pod %s", r.Type(), lb.DNSName(), r.pod.Name())
+	r.SetValues([]string{lb.DNSName()})
+	return route.CONTINUE
+}
+
+// preCreateSRV populates the values of a srv record based on a pod with a target for each
+// of the pod's created instances.
+func (r *dnsRecord) preCreateSRV() route.Response {
+	// Proceed if the srv record isn't based on a pod.
+	if r.pod == nil {
+		return route.CONTINUE
+	}
+	values := []string{}
+	for _, j := range r.pod.Instances().(*instances).Get() {
+		i := j.(resource.Instance)
+		if !i.Created() {
+			continue
+		}
+		target := i.PrivateFQDN()
+		if r.Access() == "public" || r.Access() == "public_elastic" {
+			target = i.PublicFQDN()
+		}
+		if target == "" {
+			continue
+		}
+		values = append(values, fmt.Sprintf("%d %d %d %s", r.Priority(), r.Weight(), r.Port(), target))
+	}
+	if len(values) < 1 {
+		return route.OK
+	}
+	log.Debug("Dns %s Record Create: Using values %q for pod %s, servertype: %s", r.Type(), values, r.pod.Name(), r.pod.ServerType())
+	r.SetValues(values)
+	return route.CONTINUE
+}
+
+// preCreateAlias points an alias record based on a pod at the pod's load balancer.
+func (r *dnsRecord) preCreateAlias() route.Response {
+	// Bucket and explicit targets are resolved by the provider.
+	if r.pod == nil {
+		return route.CONTINUE
+	}
+	lb := r.pod.ProviderLoadBalancer()
+	if lb == nil || lb.DNSName() == "" {
+		return route.OK
+	}
+	log.Debug("Dns %s Record Create: Using load balancer %s for pod %s", r.Type(), lb.DNSName(), r.pod.Name())
+	r.SetAliasTarget(&config.AliasTarget{
+		HostedZoneId_: lb.HostedZoneId(),
+		DnsName_:      lb.DNSName(),
+	})
+	return route.CONTINUE
+}
+
 func (r *dnsRecord) Load() error {
 	return r.providerDnsRecord.Load()
 }
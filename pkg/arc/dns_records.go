@@ -48,11 +48,14 @@ type dnsRecords struct {
 }
 
 // newDnsRecords is a constructor for a dnsRecords object. It returns a non-nil error
-// upon failure. You want to use either newDnsARecords or newDnsCNameRecords instead.
+// upon failure. You want to use newDnsARecords, newDnsAAAARecords, newDnsCNameRecords or one of the
+// other type specific constructors instead.
 func newDnsRecords(dns *dns, cfg *config.DnsRecords, t string) (*dnsRecords, error) {
 	log.Debug("Initializing DNS %s Records", t)
 
-	if t != "A" && t != "CNAME" {
+	switch t {
+	case "A", "AAAA", "CNAME", "TXT", "MX", "SRV", "CAA", "ALIAS":
+	default:
 		return nil, fmt.Errorf("Unknown dns record type %s", t)
 	}
 
@@ -69,13 +72,21 @@ func newDnsRecords(dns *dns, cfg *config.DnsRecords, t string) (*dnsRecords, err
 		if d.Find(conf.Name()) != nil {
 			return nil, fmt.Errorf("DNS %s Record name %q must be unique but is used multiple times", t, conf.Name())
 		}
+		if err := conf.Validate(t); err != nil {
+			return nil, err
+		}
 		var record *dnsRecord
 		var err error
 		switch t {
 		case "A":
 			record, err = newDnsARecord(dns, conf)
+		case "AAAA":
+			record, err = newDnsAAAARecord(dns, conf)
 		case "CNAME":
 			record, err = newDnsCNameRecord(dns, conf)
+		case "TXT", "MX", "SRV", "CAA", "ALIAS":
+			log.Debug("Initializing DNS %s Record %q", t, conf.Name())
+			record, err = newDnsRecord(dns, conf, t)
 		default:
 			return nil, fmt.Errorf("Unknown dns record type %s for %s", t, conf.Name())
 		}
@@ -101,11 +112,42 @@ func newDnsARecords(dns *dns, cfg *config.DnsRecords) (*dnsRecords, error) {
 	return newDnsRecords(dns, cfg, "A")
 }
 
+// newDnsAAAARecords is a constructor for a dnsRecords object given a list of AAAA records via config.DnsRecords.
+func newDnsAAAARecords(dns *dns, cfg *config.DnsRecords) (*dnsRecords, error) {
+	return newDnsRecords(dns, cfg, "AAAA")
+}
+
 // newDnsCNameRecords is a constructor for a dnsRecords object given a list of CNAME records via config.DnsRecords.
 func newDnsCNameRecords(dns *dns, cfg *config.DnsRecords) (*dnsRecords, error) {
 	return newDnsRecords(dns, cfg, "CNAME")
 }
 
+// newDnsTXTRecords is a constructor for a dnsRecords object given a list of TXT records via config.DnsRecords.
+func newDnsTXTRecords(dns *dns, cfg *config.DnsRecords) (*dnsRecords, error) {
+	return newDnsRecords(dns, cfg, "TXT")
+}
+
+// newDnsMXRecords is a constructor for a dnsRecords object given a list of MX records via config.DnsRecords.
+func newDnsMXRecords(dns *dns, cfg *config.DnsRecords) (*dnsRecords, error) {
+	return newDnsRecords(dns, cfg, "MX")
+}
+
+// newDnsSRVRecords is a constructor for a dnsRecords object given a list of SRV records via config.DnsRecords.
+func newDnsSRVRecords(dns *dns, cfg *config.DnsRecords) (*dnsRecords, error) {
+	return newDnsRecords(dns, cfg, "SRV")
+}
+
+// newDnsCAARecords is a constructor for a dnsRecords object given a list of CAA records via config.DnsRecords.
+func newDnsCAARecords(dns *dns, cfg *config.DnsRecords) (*dnsRecords, error) {
+	return newDnsRecords(dns, cfg, "CAA")
+}
+
+// newDnsAliasRecords is a constructor for a dnsRecords object given a list of alias records via config.DnsRecords.
+// Alias records are provider specific records that point at a load balancer or a bucket.
+func newDnsAliasRecords(dns *dns, cfg *config.DnsRecords) (*dnsRecords, error) {
+	return newDnsRecords(dns, cfg, "ALIAS")
+}
+
 // Find satisfies the resource.DnsRecords interface and provides a way
 // to search for a specific dns record. This assumes dns record names are unique.
 func (d *dnsRecords) Find(name string) resource.DnsRecord {
@@ -69,6 +69,7 @@ type Instance struct {
 	providerInstance resource.ProviderInstance
 	privateARecord   *dnsRecord
 	publicARecord    *dnsRecord
+	aaaaRecord       *dnsRecord
 	derived_         resource.Instance
 }
 
@@ -297,6 +298,34 @@ func (i *Instance) State() string {
 	return i.providerInstance.State()
 }
 
+func (i *Instance) Lifecycle() string {
+	if i.providerInstance == nil {
+		return ""
+	}
+	return i.providerInstance.Lifecycle()
+}
+
+func (i *Instance) AvailabilityZone() string {
+	if i.providerInstance == nil {
+		return ""
+	}
+	return i.providerInstance.AvailabilityZone()
+}
+
+func (i *Instance) DeployedType() string {
+	if i.providerInstance == nil {
+		return ""
+	}
+	return i.providerInstance.DeployedType()
+}
+
+func (i *Instance) SetInstanceType(instanceType string) error {
+	if i.providerInstance == nil {
+		return fmt.Errorf("Instance %s has no provider instance", i.Name())
+	}
+	return i.providerInstance.SetInstanceType(instanceType)
+}
+
 func (i *Instance) Started() bool {
 	if i.providerInstance == nil {
 		return false
@@ -311,6 +340,27 @@ func (i *Instance) Stopped() bool {
 	return i.providerInstance.Stopped()
 }
 
+func (i *Instance) Pending() bool {
+	if i.providerInstance == nil {
+		return false
+	}
+	return i.providerInstance.Pending()
+}
+
+func (i *Instance) Stopping() bool {
+	if i.providerInstance == nil {
+		return false
+	}
+	return i.providerInstance.Stopping()
+}
+
+func (i *Instance) ShuttingDown() bool {
+	if i.providerInstance == nil {
+		return false
+	}
+	return i.providerInstance.ShuttingDown()
+}
+
 func (i *Instance) PrivateIPAddress() string {
 	if i.providerInstance == nil {
 		return ""
@@ -328,6 +378,13 @@ func (i *Instance) PublicIPAddress() string {
 	return i.providerInstance.PublicIPAddress()
 }
 
+func (i *Instance) Ipv6Address() string {
+	if i.providerInstance == nil {
+		return ""
+	}
+	return i.providerInstance.Ipv6Address()
+}
+
 func (i *Instance) SetTags(t map[string]string) error {
 	if i.providerInstance == nil {
 		return fmt.Errorf("providerInstance not created")
@@ -335,6 +392,34 @@ func (i *Instance) SetTags(t map[string]string) error {
 	return i.providerInstance.SetTags(t)
 }
 
+func (i *Instance) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	if i.providerInstance == nil {
+		return fmt.Errorf("providerInstance not created")
+	}
+	return i.providerInstance.FixTags(policy, defaults)
+}
+
+func (i *Instance) Baked() bool {
+	if i.providerInstance == nil {
+		return false
+	}
+	return i.providerInstance.Baked()
+}
+
+func (i *Instance) Adopted() bool {
+	if i.providerInstance == nil {
+		return false
+	}
+	return i.providerInstance.Adopted()
+}
+
+func (i *Instance) CreateImage(name string, tags map[string]string) (string, error) {
+	if i.providerInstance == nil {
+		return "", fmt.Errorf("providerInstance not created")
+	}
+	return i.providerInstance.CreateImage(name, tags)
+}
+
 func (i *Instance) RootUser() string {
 	switch {
 	case strings.HasPrefix(i.Image(), "centos"):
@@ -400,8 +485,16 @@ func (i *Instance) Route(req *route.Request) route.Response {
 		return route.OK
 	case route.Create:
 		msg.Info("Instance Creation: %s", i.Name())
-		if i.Created() {
-			msg.Detail("Instance exists, skipping...")
+		if i.Created() && !i.Adopted() {
+			msg.Detail("Instance exists, %s, skipping...", i.State())
+			return route.OK
+		}
+		if i.ShuttingDown() {
+			msg.Detail("Instance is shutting down, create it once it has terminated, skipping...")
+			return route.OK
+		}
+		if g := i.Pod().ProviderAutoScalingGroup(); g != nil && i.AutoScaling() != nil && !i.Adopted() && !g.Available() {
+			msg.Detail("No autoscaling group instance available, skipping...")
 			return route.OK
 		}
 		if resp := i.create(req); resp != route.OK {
@@ -432,6 +525,15 @@ func (i *Instance) Route(req *route.Request) route.Response {
 	case route.Replace:
 		// See instance_replace.go
 		return i.replace(req)
+	case route.Resize:
+		// See instance_resize.go
+		return i.resize(req)
+	case route.Snapshot:
+		// See instance_snapshot.go
+		return i.snapshot(req)
+	case route.Restore:
+		// See instance_snapshot.go
+		return i.restore(req)
 	case route.Audit:
 		// See instance_audit.go
 		err := aaa.NewAudit("Instance")
@@ -530,13 +632,19 @@ func instanceHelp(n string) {
 	}
 	commands := []help.Command{
 		{Name: route.Create.String(), Desc: fmt.Sprintf("create%s instance", name)},
+		{Name: route.Create.String() + " snapshot=[name]", Desc: fmt.Sprintf("create%s instance with volumes from the named snapshot", name)},
 		{Name: route.Provision.String(), Desc: fmt.Sprintf("provision%s instance", name)},
 		{Name: route.Provision.String() + " users", Desc: fmt.Sprintf("update%s instance users", name)},
 		{Name: route.Start.String(), Desc: fmt.Sprintf("start%s instance", name)},
 		{Name: route.Stop.String(), Desc: fmt.Sprintf("stop%s instance", name)},
 		{Name: route.Restart.String(), Desc: fmt.Sprintf("restart%s instance", name)},
 		{Name: route.Replace.String(), Desc: fmt.Sprintf("replace%s instance", name)},
+		{Name: route.Resize.String(), Desc: fmt.Sprintf("resize%s instance to its configured type", name)},
 		{Name: route.Audit.String(), Desc: fmt.Sprintf("audit%s instance", name)},
+		{Name: route.Snapshot.String(), Desc: fmt.Sprintf("snapshot%s instance volumes, optionally name=[name]", name)},
+		{Name: route.Snapshot.String() + " list", Desc: fmt.Sprintf("list%s instance snapshots", name)},
+		{Name: route.Snapshot.String() + " prune", Desc: fmt.Sprintf("prune%s instance snapshots per the retention policy", name)},
+		{Name: route.Restore.String(), Desc: fmt.Sprintf("restore%s instance volumes, optionally snapshot=[name]", name)},
 		{Name: route.Destroy.String(), Desc: fmt.Sprintf("destroy%s instance", name)},
 		{Name: route.Config.String(), Desc: fmt.Sprintf("provide the%s instance configuration", name)},
 		{Name: route.Info.String(), Desc: fmt.Sprintf("provide information about allocated%s instance", name)},
@@ -561,6 +669,7 @@ func (i *Instance) info() {
 	msg.Detail("%-20s\t%s", "id", i.Id())
 	msg.Detail("%-20s\t%s", "image id", i.ImageId())
 	msg.Detail("%-20s\t%s", "state", i.State())
+	msg.Detail("%-20s\t%s", "lifecycle", i.Lifecycle())
 	msg.Detail("%-20s\t%s", "private ip address", i.PrivateIPAddress())
 	msg.Detail("%-20s\t%s", "private dns a record", i.privateARecord.Id())
 	if i.PublicIPAddress() != "" {
@@ -569,6 +678,12 @@ func (i *Instance) info() {
 	if i.publicARecord != nil {
 		msg.Detail("%-20s\t%s", "public dns a record", i.publicARecord.Id())
 	}
+	if i.Ipv6Address() != "" {
+		msg.Detail("%-20s\t%s", "ipv6 address", i.Ipv6Address())
+	}
+	if i.aaaaRecord != nil {
+		msg.Detail("%-20s\t%s", "dns aaaa record", i.aaaaRecord.Id())
+	}
 	if i.roleIdentifier.Name() != "" {
 		msg.Detail("%-20s\t%s", "role", i.roleIdentifier.Name())
 	}
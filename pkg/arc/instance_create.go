@@ -61,12 +61,26 @@ func (i *Instance) Create(req *route.Request) route.Response {
 		msg.Error("Instance %q cannot be associated with subnet %q. The subnet group needs to be created.", i.Name(), i.subnet.Name())
 		return route.FAIL
 	}
+	if label := req.Value("snapshot"); label != "" {
+		// See instance_snapshot.go
+		if resp := i.useSnapshots(req, label); resp != route.OK {
+			return resp
+		}
+	}
+	if i.CloudInit() {
+		if resp := i.setUserData(req); resp != route.OK {
+			return resp
+		}
+	}
 	if resp := i.providerInstance.Route(req); resp != route.OK {
 		return resp
 	}
-	if err := i.roleIdentifier.Attach(); err != nil {
-		msg.Error(err.Error())
-		return route.FAIL
+	// Instances launched by the autoscaling group get their role from the launch template.
+	if i.AutoScaling() == nil {
+		if err := i.roleIdentifier.Attach(); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
 	}
 	if resp := i.createElasticIP(req); resp != route.OK {
 		return resp
@@ -121,11 +135,6 @@ func (i *Instance) attachVolumes(req *route.Request) route.Response {
 }
 
 func (i *Instance) PostCreate(req *route.Request) route.Response {
-	enabled := "enabled"
-	if req.Flag("bootstrap") {
-		enabled = "disabled"
-	}
-
 	if err := i.createTags(req); err != nil {
 		msg.Error(err.Error())
 		return route.FAIL
@@ -137,6 +146,9 @@ func (i *Instance) PostCreate(req *route.Request) route.Response {
 	if i.createDnsARecords(req) != route.OK {
 		return route.FAIL
 	}
+	if i.CloudInit() {
+		return i.confirmBootstrap(req)
+	}
 	if resp := i.setupArc(req, "setup arc", true); resp != route.OK {
 		return resp
 	}
@@ -146,6 +158,35 @@ func (i *Instance) PostCreate(req *route.Request) route.Response {
 	if resp := i.setupArc(req, "fix arc permissions", false); resp != route.OK {
 		return resp
 	}
+	if !command.Run(i.setupCommands(req), i) {
+		return route.FAIL
+	}
+	return route.OK
+}
+
+// setupCommands returns the commands that set up the hostname, dhcp, repos
+// and volumes of the instance.
+func (i *Instance) setupCommands(req *route.Request) []command.Command {
+	commands := append([]command.Command{i.hostnameCommand()}, i.systemCommands(req)...)
+	return append(commands, i.preservedVolumeCommands(req)...)
+}
+
+func (i *Instance) hostnameCommand() command.Command {
+	return command.Command{
+		Type: command.Remote,
+		Desc: "setup hostname",
+		Src:  "/usr/lib/arc/create/setup_hostname",
+		Args: []string{i.PrivateFQDN()},
+	}
+}
+
+// systemCommands returns the commands that set up the dhcp, repos and the
+// volumes the instance is launched with.
+func (i *Instance) systemCommands(req *route.Request) []command.Command {
+	enabled := "enabled"
+	if req.Flag("bootstrap") {
+		enabled = "disabled"
+	}
 
 	dcName := i.Pod().Cluster().Compute().Name()
 	domain := dcName + ".local"
@@ -156,12 +197,6 @@ func (i *Instance) PostCreate(req *route.Request) route.Response {
 	}
 
 	commands := []command.Command{
-		{
-			Type: command.Remote,
-			Desc: "setup hostname",
-			Src:  "/usr/lib/arc/create/setup_hostname",
-			Args: []string{i.PrivateFQDN()},
-		},
 		{
 			Type: command.Remote,
 			Desc: "setup dhcp",
@@ -177,30 +212,121 @@ func (i *Instance) PostCreate(req *route.Request) route.Response {
 	}
 	for _, r := range i.volumes.Get() {
 		v := r.(resource.Volume)
-		if v.Boot() {
+		if v.Boot() || (req.Flag("preserve_volume") && v.Preserve()) {
 			continue
 		}
-		var args []string
-		if req.Flag("preserve_volume") && v.Preserve() {
-			args = []string{v.Device(), v.MountPoint(), v.FsType(), strconv.Itoa(v.Inodes()), "skip_format"}
-		} else {
-			args = []string{v.Device(), v.MountPoint(), v.FsType(), strconv.Itoa(v.Inodes())}
+		commands = append(commands, volumeCommand(v, req.Value("snapshot") != ""))
+	}
+	return commands
+}
+
+// preservedVolumeCommands returns the commands that set up the preserved
+// volumes. These are attached once the instance is created, so under
+// cloud-init they are set up after the bootstrap rather than by the user-data.
+func (i *Instance) preservedVolumeCommands(req *route.Request) []command.Command {
+	commands := []command.Command{}
+	if !req.Flag("preserve_volume") {
+		return commands
+	}
+	for _, r := range i.volumes.Get() {
+		v := r.(resource.Volume)
+		if v.Boot() || !v.Preserve() {
+			continue
 		}
-		commands = append(commands, command.Command{
-			Type: command.Remote,
-			Desc: "setup volume for " + v.Device(),
-			Src:  "/usr/lib/arc/create/setup_volume",
-			Args: args,
-		})
+		commands = append(commands, volumeCommand(v, true))
+	}
+	return commands
+}
+
+func volumeCommand(v resource.Volume, skipFormat bool) command.Command {
+	args := []string{v.Device(), v.MountPoint(), v.FsType(), strconv.Itoa(v.Inodes())}
+	if skipFormat {
+		args = append(args, "skip_format")
 	}
-	if !command.Run(commands, i) {
+	return command.Command{
+		Type: command.Remote,
+		Desc: "setup volume for " + v.Device(),
+		Src:  "/usr/lib/arc/create/setup_volume",
+		Args: args,
+	}
+}
+
+// bootstrapStatus is the file on the instance where the cloud-init bootstrap
+// records its progress.
+const bootstrapStatus = "/var/lib/arc/bootstrap"
+
+// setUserData renders the first boot setup of the instance as a script and
+// passes it to the provider instance as user-data, so that cloud-init runs
+// it when the instance is launched.
+func (i *Instance) setUserData(req *route.Request) route.Response {
+	script, err := i.userData(req, true)
+	if err != nil {
+		msg.Error(err.Error())
 		return route.FAIL
 	}
+	i.providerInstance.SetUserData(script)
 	return route.OK
 }
 
-func (i *Instance) setupArc(req *route.Request, desc string, asRoot bool) route.Response {
+// launchUserData renders the first boot setup shared by the instances the
+// pod's autoscaling group launches. The hostname is left out since it is set
+// once an instance is claimed, see confirmBootstrap.
+func (i *Instance) launchUserData(req *route.Request) ([]byte, error) {
+	return i.userData(req, false)
+}
+
+func (i *Instance) userData(req *route.Request, hostname bool) ([]byte, error) {
+	commands := i.setupArcCommands("setup arc")
+	users, err := i.userCommands()
+	if err != nil {
+		return nil, err
+	}
+	commands = append(commands, users...)
+	if hostname {
+		commands = append(commands, i.hostnameCommand())
+	}
+	commands = append(commands, i.systemCommands(req)...)
+	return command.Script(commands, i.RootUser(), bootstrapStatus)
+}
+
+// confirmBootstrap waits for the cloud-init bootstrap of the instance to
+// complete, sets up the volumes attached since it was launched and then hands
+// the arc directory over to the ssh user.
+func (i *Instance) confirmBootstrap(req *route.Request) route.Response {
 	commands := []command.Command{
+		{
+			Type: command.Remote,
+			Desc: "wait for bootstrap",
+			Src:  "/usr/lib/arc/create/wait_bootstrap",
+			Dest: "/tmp/wait_bootstrap",
+			Args: []string{bootstrapStatus},
+		},
+	}
+	// Instances launched by the autoscaling group share the user-data of the
+	// launch template, so the hostname is set once the instance is claimed.
+	if i.AutoScaling() != nil {
+		commands = append(commands, i.hostnameCommand())
+	}
+	commands = append(commands, i.preservedVolumeCommands(req)...)
+	if !command.RunAsRoot(commands, i) {
+		return route.FAIL
+	}
+	return i.setupArc(req, "fix arc permissions", false)
+}
+
+func (i *Instance) setupArc(req *route.Request, desc string, asRoot bool) route.Response {
+	f := command.Run
+	if asRoot {
+		f = command.RunAsRoot
+	}
+	if !f(i.setupArcCommands(desc), i) {
+		return route.FAIL
+	}
+	return route.OK
+}
+
+func (i *Instance) setupArcCommands(desc string) []command.Command {
+	return []command.Command{
 		{
 			Type: command.Remote,
 			Desc: desc,
@@ -213,14 +339,6 @@ func (i *Instance) setupArc(req *route.Request, desc string, asRoot bool) route.
 			Src:  "/usr/lib/arc/arc.sh",
 		},
 	}
-	f := command.Run
-	if asRoot {
-		f = command.RunAsRoot
-	}
-	if !f(commands, i) {
-		return route.FAIL
-	}
-	return route.OK
 }
 
 func (i *Instance) createTags(req *route.Request) error {
@@ -296,3 +414,21 @@ func (i *Instance) createSecurityTags() error {
 	}
 	return i.SetTags(tags)
 }
+
+// fixPolicyTags sets the tags of the instance, its volumes and elastic ip that
+// violate the datacenter's tag policy, if one is defined.
+func (i *Instance) fixPolicyTags() error {
+	dc := i.Pod().Cluster().Compute().DataCenter()
+	policy := dc.TagPolicy()
+	if policy == nil {
+		return nil
+	}
+	defaults := map[string]string{}
+	for k, v := range dc.SecurityTags() {
+		defaults[k] = v
+	}
+	for k, v := range i.Pod().Cluster().SecurityTags() {
+		defaults[k] = v
+	}
+	return i.FixTags(policy, defaults)
+}
@@ -40,6 +40,13 @@ func (i *Instance) destroy(req *route.Request) route.Response {
 		msg.Detail("Instance does not exist, skipping...")
 		return route.OK
 	}
+	if i.ShuttingDown() {
+		msg.Detail("Instance is already shutting down, skipping...")
+		return route.OK
+	}
+	if !i.settle(req) {
+		return route.FAIL
+	}
 	id := i.Id()
 	if resp := i.Derived().PreDestroy(req); resp != route.OK {
 		return resp
@@ -56,11 +63,18 @@ func (i *Instance) destroy(req *route.Request) route.Response {
 }
 
 func (i *Instance) PreDestroy(req *route.Request) route.Response {
-	if resp := i.setupArc(req, "fix arc permissions", false); resp != route.OK {
+	// Drain the instance's load balancer connections first. See pod_load_balancer.go
+	if resp := i.deregisterLoadBalancer(req); resp != route.OK {
 		return resp
 	}
-	if resp := i.StopPaging(req); resp != route.OK {
-		return resp
+	// A stopped instance cannot be reached, so skip the remote cleanup.
+	if i.Started() {
+		if resp := i.setupArc(req, "fix arc permissions", false); resp != route.OK {
+			return resp
+		}
+		if resp := i.StopPaging(req); resp != route.OK {
+			return resp
+		}
 	}
 	if i.destroyDnsARecords(req) != route.OK {
 		return route.FAIL
@@ -110,7 +124,7 @@ func (i *Instance) detachVolumes(req *route.Request, destroy bool) route.Respons
 		if v.Detached() {
 			continue
 		}
-		if !command.RunRemote(command.Command{
+		if i.Started() && !command.RunRemote(command.Command{
 			Instance: i,
 			Desc:     "unmount " + v.MountPoint(),
 			Src:      "/usr/lib/arc/destroy/umount",
@@ -51,6 +51,13 @@ func (i *Instance) newDnsARecords() error {
 			return err
 		}
 	}
+	// Allocate the dns aaaa record for the ipv6 address of instances on dual stack subnets.
+	if i.subnet.Ipv6Subnet() != "" && i.aaaaRecord == nil {
+		i.aaaaRecord, err = newDynamicDnsAAAARecord(i, i.dns, i.PrivateHostname(), i.Pod().Cluster().AuditIgnore())
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -67,6 +74,9 @@ func (i *Instance) loadDnsARecords(req *route.Request) route.Response {
 	if i.publicARecord != nil && i.publicARecord.Route(req) != route.OK {
 		return route.FAIL
 	}
+	if i.aaaaRecord != nil && i.aaaaRecord.Route(req) != route.OK {
+		return route.FAIL
+	}
 
 	// Fix dns a records if necessary
 	if i.updateDnsARecord(req, i.privateARecord, i.PrivateIPAddress(), "private") != route.OK {
@@ -75,6 +85,9 @@ func (i *Instance) loadDnsARecords(req *route.Request) route.Response {
 	if i.updateDnsARecord(req, i.publicARecord, i.PublicIPAddress(), "public") != route.OK {
 		return route.FAIL
 	}
+	if i.updateDnsARecord(req, i.aaaaRecord, i.Ipv6Address(), "ipv6") != route.OK {
+		return route.FAIL
+	}
 	return route.OK
 }
 
@@ -109,6 +122,9 @@ func (i *Instance) createDnsARecords(req *route.Request) route.Response {
 	if i.publicARecord != nil && i.publicARecord.Route(r) != route.OK {
 		return route.FAIL
 	}
+	if i.aaaaRecord != nil && i.aaaaRecord.Route(r) != route.OK {
+		return route.FAIL
+	}
 	return route.OK
 }
 
@@ -117,6 +133,9 @@ func (i *Instance) destroyDnsARecords(req *route.Request) route.Response {
 		return route.OK
 	}
 	r := req.Clone(route.Destroy)
+	if i.aaaaRecord != nil && i.aaaaRecord.Route(r) != route.OK {
+		return route.FAIL
+	}
 	if i.publicARecord != nil && i.publicARecord.Route(r) != route.OK {
 		return route.FAIL
 	}
@@ -50,6 +50,22 @@ func (i *Instance) provision(req *route.Request) route.Response {
 		msg.Detail("Instance does not exist, skipping...")
 		return route.OK
 	}
+	if i.ShuttingDown() {
+		msg.Detail("Instance is shutting down, skipping...")
+		return route.OK
+	}
+	// The autoscaling group launched this instance on its own. Claim it, which
+	// names it, creates its dns records and fully provisions it.
+	if i.Adopted() {
+		return i.Route(req.Clone(route.Create))
+	}
+	if !i.settle(req) {
+		return route.FAIL
+	}
+	if !i.Started() {
+		msg.Detail("Instance is %s, start it before provisioning, skipping...", i.State())
+		return route.OK
+	}
 
 	if resp := i.provisionSingleResource(req); resp != route.CONTINUE {
 		return resp
@@ -64,6 +80,10 @@ func (i *Instance) provision(req *route.Request) route.Response {
 	if resp := i.Derived().PostProvision(req); resp != route.OK {
 		return resp
 	}
+	// See pod_load_balancer.go
+	if resp := i.registerLoadBalancer(req); resp != route.OK {
+		return resp
+	}
 	msg.Detail("Provisioned: %s", i.Id())
 	aaa.Accounting("Instance provisioned: %s, %s", i.Name(), i.Id())
 	return route.OK
@@ -101,6 +121,10 @@ func (i *Instance) provisionSingleResource(req *route.Request) route.Response {
 			msg.Error(err.Error())
 			return route.FAIL
 		}
+		if err := i.fixPolicyTags(); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
 		return route.OK
 	}
 	return r
@@ -158,14 +182,20 @@ func (i *Instance) Provision(req *route.Request) route.Response {
 		msg.Error(err.Error())
 		return route.FAIL
 	}
-	if resp := i.provisionInstallServertype(req); resp != route.OK {
-		return resp
-	}
-	if resp := i.provisionInstallPackages(req); resp != route.OK {
-		return resp
-	}
-	if resp := i.provisionInstallPuppet(req); resp != route.OK {
-		return resp
+	// Instances launched from a baked image already have the servertype,
+	// packages and puppet installed.
+	if i.Baked() {
+		msg.Detail("Instance launched from baked image %s, skipping servertype, packages and puppet install", i.BakedImage())
+	} else {
+		if resp := i.provisionInstallServertype(req); resp != route.OK {
+			return resp
+		}
+		if resp := i.provisionInstallPackages(req); resp != route.OK {
+			return resp
+		}
+		if resp := i.provisionInstallPuppet(req); resp != route.OK {
+			return resp
+		}
 	}
 	if !req.Flag("bootstrap") {
 		if err := secrets.InstallCerts(i); err != nil {
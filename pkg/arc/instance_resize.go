@@ -0,0 +1,118 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package arc
+
+import (
+	"strings"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// resize changes the instance type of a deployed instance in place, keeping
+// its volumes, network interfaces and elastic ip.
+func (i *Instance) resize(req *route.Request) route.Response {
+	msg.Info("Instance Resize: %s", i.Name())
+	if i.Destroyed() {
+		msg.Detail("Instance does not exist, skipping...")
+		return route.OK
+	}
+	if i.ShuttingDown() {
+		msg.Detail("Instance is shutting down, skipping...")
+		return route.OK
+	}
+	if i.DeployedType() == i.InstanceType() {
+		msg.Detail("Instance is %s, skipping...", i.InstanceType())
+		return route.OK
+	}
+	if i.AutoScaling() != nil {
+		msg.Detail("Instance is managed by an autoscaling group, use replace instead. Skipping...")
+		return route.OK
+	}
+	if strings.HasPrefix(i.Lifecycle(), "spot") {
+		msg.Detail("Spot instances cannot be stopped, use replace instead. Skipping...")
+		return route.OK
+	}
+	if !i.settle(req) {
+		return route.FAIL
+	}
+	if resp := i.Derived().PreResize(req); resp != route.OK {
+		return resp
+	}
+	if resp := i.Derived().Resize(req); resp != route.OK {
+		return resp
+	}
+	if resp := i.Derived().PostResize(req); resp != route.OK {
+		return resp
+	}
+	msg.Detail("Resized: %s, %s", i.Id(), i.DeployedType())
+	aaa.Accounting("Instance resized: %s, %s, %s", i.Name(), i.Id(), i.DeployedType())
+	return route.OK
+}
+
+func (i *Instance) PreResize(req *route.Request) route.Response {
+	return route.OK
+}
+
+func (i *Instance) Resize(req *route.Request) route.Response {
+	started := i.Started()
+
+	// Stopping the instance stops paging, starting it back up restores it.
+	if started {
+		if resp := i.stop(req.Clone(route.Stop)); resp != route.OK {
+			return resp
+		}
+	}
+
+	from := i.DeployedType()
+	msg.Detail("Changing instance type from %s to %s", from, i.InstanceType())
+	if err := i.providerInstance.SetInstanceType(i.InstanceType()); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if !started {
+		return i.load(req.Clone(route.Load))
+	}
+	if resp := i.start(req.Clone(route.Start)); resp == route.OK {
+		return route.OK
+	}
+
+	// The new instance type may not have capacity, so put the instance
+	// back the way it was rather than leave it stopped.
+	msg.Warn("Instance failed to start as %s, reverting to %s", i.InstanceType(), from)
+	if err := i.providerInstance.SetInstanceType(from); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	i.start(req.Clone(route.Start))
+	return route.FAIL
+}
+
+func (i *Instance) PostResize(req *route.Request) route.Response {
+	return route.OK
+}
@@ -39,6 +39,13 @@ func (i *Instance) start(req *route.Request) route.Response {
 		msg.Detail("Instance does not exist. Skipping...")
 		return route.OK
 	}
+	if i.ShuttingDown() {
+		msg.Detail("Instance is shutting down. Skipping...")
+		return route.OK
+	}
+	if !i.settle(req) {
+		return route.FAIL
+	}
 	if !i.Stopped() {
 		msg.Detail("Instance has been started. Skipping...")
 		return route.OK
@@ -58,6 +65,20 @@ func (i *Instance) start(req *route.Request) route.Response {
 	return route.OK
 }
 
+// settle waits for a pending instance to start or for a stopping instance
+// to stop, so that commands always act on a running or stopped instance.
+func (i *Instance) settle(req *route.Request) bool {
+	switch {
+	case i.Pending():
+		msg.Detail("Instance is pending, waiting for it to start...")
+		return i.reloadStarted(req.Clone(route.Load))
+	case i.Stopping():
+		msg.Detail("Instance is stopping, waiting for it to stop...")
+		return i.reloadStopped(req.Clone(route.Load))
+	}
+	return true
+}
+
 func (i *Instance) PreStart(req *route.Request) route.Response {
 	return route.OK
 }
@@ -88,6 +109,13 @@ func (i *Instance) stop(req *route.Request) route.Response {
 		msg.Detail("Instance does not exist, skipping...")
 		return route.OK
 	}
+	if i.ShuttingDown() {
+		msg.Detail("Instance is shutting down, skipping...")
+		return route.OK
+	}
+	if !i.settle(req) {
+		return route.FAIL
+	}
 	if !i.Started() {
 		msg.Detail("Instance has been stopped. Skipping...")
 		return route.OK
@@ -172,6 +200,10 @@ func (i *Instance) restart(req *route.Request) route.Response {
 		msg.Detail("Instance does not exist, skipping...")
 		return route.OK
 	}
+	if i.ShuttingDown() {
+		msg.Detail("Instance is shutting down, skipping...")
+		return route.OK
+	}
 	if resp := i.Derived().PreRestart(req); resp != route.OK {
 		return resp
 	}
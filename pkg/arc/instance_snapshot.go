@@ -0,0 +1,253 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package arc
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// Snapshots are taken of the instance's data volumes, the boot volume is
+// recreated from the image. Snapshots taken without a name are pruned
+// according to the pod's retention policy, named snapshots are kept until
+// they are removed by hand.
+
+func (i *Instance) snapshot(req *route.Request) route.Response {
+	msg.Info("Instance Snapshot: %s", i.Name())
+	if i.Destroyed() {
+		msg.Detail("Instance does not exist, skipping...")
+		return route.OK
+	}
+	switch {
+	case req.Flag("list"):
+		return i.listSnapshots(req)
+	case req.Flag("prune"):
+		return i.pruneSnapshots(req)
+	}
+
+	label, retention := req.Value("name"), "manual"
+	if label == "" {
+		label, retention = snapshotLabel(req), "auto"
+	}
+	for _, v := range i.dataVolumes() {
+		tags := i.snapshotTags(req, v)
+		tags["Name"] = i.Name() + " " + v.MountPoint()
+		tags["Snapshot"] = label
+		tags["Retention"] = retention
+		tags["Created By"] = req.UserId()
+		id, err := v.Snapshot(tags)
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		msg.Detail("Snapshot %s: %s, %s", label, v.MountPoint(), id)
+	}
+	aaa.Accounting("Instance snapshot created: %s, %s", i.Name(), label)
+	return route.OK
+}
+
+func (i *Instance) listSnapshots(req *route.Request) route.Response {
+	for _, v := range i.dataVolumes() {
+		snapshots, err := v.Snapshots(i.snapshotTags(req, v))
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		msg.Detail("Volume: %s", v.MountPoint())
+		msg.IndentInc()
+		for _, s := range newestFirst(snapshots) {
+			msg.Detail("%-20s\t%s, %s, %s, %s", s.Tags["Snapshot"], s.Id, s.State, s.Tags["Retention"], s.Time.UTC().Format(time.RFC3339))
+		}
+		msg.IndentDec()
+	}
+	return route.OK
+}
+
+func (i *Instance) pruneSnapshots(req *route.Request) route.Response {
+	retention := i.Retention()
+	if retention == nil {
+		msg.Detail("No snapshot retention configured, skipping...")
+		return route.OK
+	}
+	for _, v := range i.dataVolumes() {
+		tags := i.snapshotTags(req, v)
+		tags["Retention"] = "auto"
+		snapshots, err := v.Snapshots(tags)
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		times := []time.Time{}
+		for _, s := range snapshots {
+			times = append(times, s.Time)
+		}
+		for n, keep := range retention.Keep(times) {
+			if keep {
+				continue
+			}
+			s := snapshots[n]
+			msg.Detail("Deleting snapshot %s: %s, %s", s.Tags["Snapshot"], v.MountPoint(), s.Id)
+			if err := v.DeleteSnapshot(s.Id); err != nil {
+				msg.Error(err.Error())
+				return route.FAIL
+			}
+		}
+	}
+	return route.OK
+}
+
+func (i *Instance) restore(req *route.Request) route.Response {
+	msg.Info("Instance Restore: %s", i.Name())
+	if i.Destroyed() {
+		msg.Detail("Instance does not exist, skipping...")
+		return route.OK
+	}
+	snapshots, err := i.findSnapshots(req, req.Value("snapshot"), i.dataVolumes())
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+
+	// The volumes can only be swapped while the instance is stopped.
+	started := i.Started()
+	if started {
+		if resp := i.stop(req.Clone(route.Stop)); resp != route.OK {
+			return resp
+		}
+	}
+	for _, v := range i.dataVolumes() {
+		old, err := v.Restore(snapshots[v.Device()].Id)
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		msg.Warn("Volume %s has been replaced, destroy the previous volume %s once the restore is verified", v.MountPoint(), old)
+	}
+	if started {
+		if resp := i.start(req.Clone(route.Start)); resp != route.OK {
+			return resp
+		}
+	}
+	aaa.Accounting("Instance restored: %s, %s", i.Name(), i.Id())
+	return route.OK
+}
+
+// useSnapshots sets the data volumes of the instance to be created from the
+// snapshots with the given name.
+func (i *Instance) useSnapshots(req *route.Request, label string) route.Response {
+	volumes := []resource.Volume{}
+	for _, v := range i.dataVolumes() {
+		if !(req.Flag("preserve_volume") && v.Preserve()) {
+			volumes = append(volumes, v)
+		}
+	}
+	snapshots, err := i.findSnapshots(req, label, volumes)
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	for _, v := range volumes {
+		s := snapshots[v.Device()]
+		msg.Detail("Creating volume %s from snapshot %s, %s", v.MountPoint(), s.Tags["Snapshot"], s.Id)
+		v.UseSnapshot(s.Id)
+	}
+	return route.OK
+}
+
+// findSnapshots finds the named snapshot of each of the volumes, indexed by
+// device. Without a name the newest completed snapshot is used.
+func (i *Instance) findSnapshots(req *route.Request, label string, volumes []resource.Volume) (map[string]*resource.Snapshot, error) {
+	found := map[string]*resource.Snapshot{}
+	for _, v := range volumes {
+		tags := i.snapshotTags(req, v)
+		if label != "" {
+			tags["Snapshot"] = label
+		}
+		snapshots, err := v.Snapshots(tags)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range newestFirst(snapshots) {
+			if s.Completed() {
+				found[v.Device()] = s
+				break
+			}
+		}
+		if found[v.Device()] == nil {
+			if label == "" {
+				return nil, fmt.Errorf("No completed snapshot found for %s, %s", i.Name(), v.MountPoint())
+			}
+			return nil, fmt.Errorf("No completed snapshot %q found for %s, %s", label, i.Name(), v.MountPoint())
+		}
+	}
+	return found, nil
+}
+
+// snapshotTags returns the tags identifying the snapshots of the given volume.
+func (i *Instance) snapshotTags(req *route.Request, v resource.Volume) map[string]string {
+	return map[string]string{
+		"DataCenter": req.DataCenter(),
+		"Pod":        i.Pod().Name(),
+		"Instance":   i.Name(),
+		"Device":     v.Device(),
+	}
+}
+
+func (i *Instance) dataVolumes() []resource.Volume {
+	volumes := []resource.Volume{}
+	for _, r := range i.volumes.Get() {
+		v := r.(resource.Volume)
+		if !v.Boot() {
+			volumes = append(volumes, v)
+		}
+	}
+	return volumes
+}
+
+// snapshotLabel names the snapshots of a request after the time of the request,
+// so the snapshots of all the instances in a pod or cluster share a name.
+func snapshotLabel(req *route.Request) string {
+	t, err := time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", req.Time())
+	if err != nil {
+		t = time.Now().UTC()
+	}
+	return t.Format("20060102-150405")
+}
+
+func newestFirst(snapshots []*resource.Snapshot) []*resource.Snapshot {
+	sorted := append([]*resource.Snapshot{}, snapshots...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].Time.After(sorted[b].Time)
+	})
+	return sorted
+}
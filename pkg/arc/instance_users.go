@@ -38,20 +38,14 @@ import (
 )
 
 func (i *Instance) configureUsers(req *route.Request, asRoot bool) route.Response {
-	if users.Users == nil || users.Groups == nil || users.Teams == nil {
-		return route.OK
-	}
-	var err error
-	commands := []command.Command{}
-
-	commands = i.copyUserScripts(commands)
-	commands = i.setupGroups(commands)
-	commands, err = i.setupUsers(commands)
-
+	commands, err := i.userCommands()
 	if err != nil {
 		msg.Error(err.Error())
 		return route.FAIL
 	}
+	if commands == nil {
+		return route.OK
+	}
 
 	f := command.RunQuiet
 	if asRoot {
@@ -63,6 +57,16 @@ func (i *Instance) configureUsers(req *route.Request, asRoot bool) route.Respons
 	return route.OK
 }
 
+func (i *Instance) userCommands() ([]command.Command, error) {
+	if users.Users == nil || users.Groups == nil || users.Teams == nil {
+		return nil, nil
+	}
+	commands := []command.Command{}
+	commands = i.copyUserScripts(commands)
+	commands = i.setupGroups(commands)
+	return i.setupUsers(commands)
+}
+
 func (i *Instance) copyUserScripts(commands []command.Command) []command.Command {
 	commands = append(commands,
 		command.Command{
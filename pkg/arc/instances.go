@@ -42,6 +42,7 @@ type instances struct {
 	*resource.Resources
 	*config.Instances
 	pod       *Pod
+	prov      provider.DataCenter
 	instances map[string]resource.Instance
 }
 
@@ -52,15 +53,31 @@ func newInstances(pod *Pod, prov provider.DataCenter, cfg *config.Instances) (*i
 	i := &instances{
 		Resources: resource.NewResources(),
 		pod:       pod,
+		prov:      prov,
 		instances: map[string]resource.Instance{},
 	}
+	for _, conf := range *cfg {
+		if _, err := i.add(conf); err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+// add allocates the instance for the given configuration and appends it to the
+// collection. The availability zone of the instance is chosen by its position
+// in the pod, per the pod's placement policy.
+func (i *instances) add(conf *config.Instance) (resource.Instance, error) {
+	pod := i.pod
+
+	// Ensure the instance is uniquely named.
+	if i.Find(conf.Name()) != nil {
+		return nil, fmt.Errorf("Instance name %q must be unique but is used multiple times", conf.Name())
+	}
 
 	// The reference to the network resource.
 	net := pod.Cluster().Compute().DataCenter().Network()
 
-	// The availability zones available to these instances.
-	availabilityZones := net.AvailabilityZones()
-
 	// The subnet group associated with these instances.
 	subnetGroup := net.SubnetGroups().Find(pod.SubnetGroup())
 	if subnetGroup == nil {
@@ -70,33 +87,29 @@ func newInstances(pod *Pod, prov provider.DataCenter, cfg *config.Instances) (*i
 	// The keypair to be used with these instances.
 	keypair := pod.Cluster().Compute().KeyPair()
 
-	n := 0
-	for _, conf := range *cfg {
-		// Ensure the instance is uniquely named.
-		if i.Find(conf.Name()) != nil {
-			return nil, fmt.Errorf("Instance name %q must be unique but is used multiple times", conf.Name())
-		}
-
-		// The availability zone for this instance. Chosing via round robin. Always starting at 0.
-		az := availabilityZones[n%len(availabilityZones)]
-
-		// Get the subnet associated with the AZ.
-		subnetName := pod.SubnetGroup() + "-" + az
-		subnet := subnetGroup.Find(subnetName)
-		if subnet == nil {
-			return nil, fmt.Errorf("Cannot find subnet %s configured for instance %s", subnetName, conf.Name())
-		}
+	// The availability zone for this instance, per the pod's placement policy.
+	// The zones are those of the provider target the pod's cluster is pinned to.
+	n := i.Length()
+	zones, err := pod.Placement().Zones(i.prov.AvailabilityZones(net.AvailabilityZones()), n+1)
+	if err != nil {
+		return nil, fmt.Errorf("Pod %q: %s", pod.Name(), err)
+	}
+	az := zones[n]
 
-		instance, err := newInstance(pod, subnet, keypair, prov, conf)
-		if err != nil {
-			return nil, err
-		}
-		i.instances[instance.Name()] = instance
-		i.Append(instance)
+	// Get the subnet associated with the AZ.
+	subnetName := pod.SubnetGroup() + "-" + az
+	subnet := subnetGroup.Find(subnetName)
+	if subnet == nil {
+		return nil, fmt.Errorf("Cannot find subnet %s configured for instance %s", subnetName, conf.Name())
+	}
 
-		n++
+	instance, err := newInstance(pod, subnet, keypair, i.prov, conf)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	i.instances[instance.Name()] = instance
+	i.Append(instance)
+	return instance, nil
 }
 
 // GetInstances returns a map of all instances indexed by instance name.
@@ -105,7 +118,7 @@ func (i *instances) GetInstances() map[string]resource.Instance {
 }
 
 // Find instance by name. This implies instances are named uniquely.
-// The name takes the form "<pod name>-<instance number>".
+// The name is expanded from the naming template, "<pod name>-<instance number>" by default.
 func (i *instances) Find(name string) resource.Instance {
 	return i.instances[name]
 }
@@ -140,7 +153,7 @@ func (i *instances) Route(req *route.Request) route.Response {
 	}
 
 	switch req.Command() {
-	case route.Load, route.Create, route.Provision, route.Start, route.Stop, route.Restart, route.Replace:
+	case route.Load, route.Create, route.Provision, route.Start, route.Stop, route.Restart, route.Replace, route.Resize, route.Snapshot, route.Restore:
 		return i.RouteInOrder(req)
 	case route.Destroy:
 		return i.RouteReverseOrder(req)
@@ -174,6 +187,7 @@ func (i *instances) help() {
 		{Name: route.Stop.String(), Desc: "stop all instances"},
 		{Name: route.Restart.String(), Desc: "restart all instances"},
 		{Name: route.Replace.String(), Desc: "replace all instances"},
+		{Name: route.Resize.String(), Desc: "resize all instances to their configured type"},
 		{Name: route.Audit.String(), Desc: "audit all instances"},
 		{Name: route.Destroy.String(), Desc: "destroy all instances"},
 		{Name: "'name'", Desc: "manage named instance"},
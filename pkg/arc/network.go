@@ -34,6 +34,7 @@ import (
 	"github.com/cisco/arc/pkg/help"
 	"github.com/cisco/arc/pkg/log"
 	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/net"
 	"github.com/cisco/arc/pkg/provider"
 	"github.com/cisco/arc/pkg/resource"
 	"github.com/cisco/arc/pkg/route"
@@ -47,6 +48,9 @@ type network struct {
 	subnetGroups        *subnetGroups
 	providerNetworkPost resource.ProviderNetworkPost
 	securityGroups      *securityGroups
+	providerEndpoints   resource.ProviderEndpoints
+	networkPlan         *networkPlan
+	networkPlanErr      error
 }
 
 // newNetwork is the constructor for a network object. It returns a non-nil error upon failure.
@@ -63,6 +67,52 @@ func newNetwork(dc *dataCenter, prov provider.DataCenter, cfg *config.Network) (
 	if cfg.SecurityGroups == nil {
 		return nil, fmt.Errorf("The security_groups element is missing from the network configuration")
 	}
+	if err := cfg.Peerings().Validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Endpoints().Validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.NetworkAcls().Validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidateNatGateways(); err != nil {
+		return nil, err
+	}
+	if cfg.FlowLog() != nil {
+		if err := cfg.FlowLog().Validate(); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range *cfg.SubnetGroups {
+		if s.FlowLog() != nil {
+			if err := s.FlowLog().Validate(); err != nil {
+				return nil, fmt.Errorf("Subnet group %q: %s", s.Name(), err)
+			}
+		}
+		if len(s.Routes()) > 0 {
+			if !s.ManageRoutes() {
+				return nil, fmt.Errorf("Subnet group %q has static routes but doesn't manage its routes", s.Name())
+			}
+			if err := s.Routes().Validate(); err != nil {
+				return nil, fmt.Errorf("Subnet group %q: %s", s.Name(), err)
+			}
+			for _, r := range s.Routes() {
+				if r.Peering() != "" && cfg.Peerings().Find(r.Peering()) == nil {
+					return nil, fmt.Errorf("Subnet group %q routes to the unknown peering %q", s.Name(), r.Peering())
+				}
+			}
+		}
+		if s.NetworkAcl() != "" && cfg.NetworkAcls().Find(s.NetworkAcl()) == nil {
+			return nil, fmt.Errorf("Subnet group %q uses the unknown network acl %q", s.Name(), s.NetworkAcl())
+		}
+		if s.Ipv6Subnet() != "" && !cfg.Ipv6() {
+			return nil, fmt.Errorf("Subnet group %q has an ipv6 subnet but ipv6 isn't enabled for the network", s.Name())
+		}
+		if err := setTargetNetwork(prov, cfg, s); err != nil {
+			return nil, err
+		}
+	}
 
 	n := &network{
 		Resources: resource.NewResources(),
@@ -70,6 +120,21 @@ func newNetwork(dc *dataCenter, prov provider.DataCenter, cfg *config.Network) (
 		dc:        dc,
 	}
 
+	// Plan the subnet groups. Subnet groups without a cidr block are given their
+	// planned cidr block, which should be saved with the network plan command.
+	n.networkPlan, n.networkPlanErr = newNetworkPlan(cfg)
+	for _, s := range *cfg.SubnetGroups {
+		if s.CidrBlock() != "" {
+			continue
+		}
+		if n.networkPlanErr != nil {
+			return nil, n.networkPlanErr
+		}
+		cidr := n.networkPlan.Planned(s.Name())
+		s.SetCidrBlock(cidr)
+		log.Warn("Subnet group %q has no cidr, using the planned cidr %s. Save it with \"network plan update_config\".", s.Name(), cidr)
+	}
+
 	var err error
 
 	// Delegate the provider specific network behavior to the resource.ProviderNetwork object.
@@ -97,9 +162,42 @@ func newNetwork(dc *dataCenter, prov provider.DataCenter, cfg *config.Network) (
 	}
 	n.Append(n.securityGroups)
 
+	n.providerEndpoints, err = prov.NewEndpoints(n, cfg)
+	if err != nil {
+		return nil, err
+	}
+	n.Append(n.providerEndpoints)
+
 	return n, nil
 }
 
+// setTargetNetwork sets the availability zones of the provider target the
+// subnet group is pinned to, and the cidr block of the network it lives in when
+// the target has a network of its own, which mustn't overlap the datacenter's network.
+func setTargetNetwork(prov provider.DataCenter, cfg *config.Network, s *config.SubnetGroup) error {
+	t, err := prov.Target(s.Target())
+	if err != nil {
+		return fmt.Errorf("Subnet group %q: %s", s.Name(), err)
+	}
+	s.SetAvailabilityZones(t.AvailabilityZones(cfg.AvailabilityZones()))
+	cidr := t.CidrBlock(cfg.CidrBlock())
+	if cidr == cfg.CidrBlock() {
+		return nil
+	}
+	if cidr == "" {
+		return fmt.Errorf("Subnet group %q: The network of target %q needs a cidr block", s.Name(), s.Target())
+	}
+	overlap, err := net.Overlaps(cidr, cfg.CidrBlock())
+	if err != nil {
+		return fmt.Errorf("Subnet group %q: The network of target %q: %s", s.Name(), s.Target(), err)
+	}
+	if overlap {
+		return fmt.Errorf("Subnet group %q: The network of target %q, %s, overlaps the network's cidr block %s", s.Name(), s.Target(), cidr, cfg.CidrBlock())
+	}
+	s.SetNetworkCidrBlock(cidr)
+	return nil
+}
+
 // Id provides the id of the provider specific network resource.
 // This satisfies the resource.DynamicNetwork interface.
 func (n *network) Id() string {
@@ -126,6 +224,24 @@ func (n *network) AuditSecgroups(flags ...string) error {
 	return n.providerNetwork.AuditSecgroups(flags...)
 }
 
+func (n *network) ImportSecgroup(group string) (*config.SecurityGroup, error) {
+	return n.providerNetwork.ImportSecgroup(group)
+}
+
+func (n *network) AdoptSecgroup(req *route.Request, group, name string) error {
+	return n.providerNetwork.AdoptSecgroup(req, group, name)
+}
+
+// AuditTags identifies any resources whose tags violate the tag policy.
+func (n *network) AuditTags(policy *config.TagPolicy, flags ...string) error {
+	return n.providerNetwork.AuditTags(policy, flags...)
+}
+
+// FixTags sets the tags of the resources whose tags violate the tag policy.
+func (n *network) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	return n.providerNetwork.FixTags(policy, defaults)
+}
+
 // ProviderNetwork satisfies the resource.Network interface and provides access
 // to the provider's network.
 func (n *network) ProviderNetwork() resource.ProviderNetwork {
@@ -185,6 +301,9 @@ func (n *network) Route(req *route.Request) route.Response {
 	if n.providerNetworkPost.CanRoute(req) {
 		return n.providerNetworkPost.Route(req)
 	}
+	if n.providerEndpoints.CanRoute(req) {
+		return n.providerEndpoints.Route(req)
+	}
 	if req.Top() != "" {
 		n.help()
 		return route.FAIL
@@ -198,6 +317,8 @@ func (n *network) Route(req *route.Request) route.Response {
 	switch req.Command() {
 	case route.Load, route.Create, route.Audit:
 		return n.RouteInOrder(req)
+	case route.Provision:
+		return n.providerNetworkPost.Route(req)
 	case route.Destroy:
 		return n.RouteReverseOrder(req)
 	case route.Help:
@@ -209,6 +330,10 @@ func (n *network) Route(req *route.Request) route.Response {
 	case route.Info:
 		n.info(req)
 		return route.OK
+	case route.Plan:
+		return n.plan(req)
+	case route.Flows:
+		return n.flows(req)
 	default:
 		msg.Error("Unknown network command %q.", req.Command().String())
 	}
@@ -217,11 +342,17 @@ func (n *network) Route(req *route.Request) route.Response {
 
 func (n *network) help() {
 	providerCommands := help.Append(n.providerNetwork.HelpCommands(), n.providerNetworkPost.HelpCommands())
+	providerCommands = help.Append(providerCommands, n.providerEndpoints.HelpCommands())
 	commands := []help.Command{
 		{Name: route.Create.String(), Desc: "create all network resources"},
 		{Name: route.Destroy.String(), Desc: "destroy all network resources"},
+		{Name: route.Provision.String(), Desc: "update the nat gateway topology and static routes"},
 		{Name: route.Config.String(), Desc: "show the network configuration"},
 		{Name: route.Info.String(), Desc: "show information about allocated network resource"},
+		{Name: route.Plan.String(), Desc: "show the cidr plan of the subnet groups and the free space"},
+		{Name: route.Plan.String() + " json", Desc: "emit the planned subnet_groups json"},
+		{Name: route.Plan.String() + " update_config", Desc: "save the planned subnet group cidrs to the config"},
+		{Name: route.Flows.String() + " file|dir... [top=n]", Desc: "summarize the rejected flows of downloaded flow logs"},
 		{Name: route.Help.String(), Desc: "show this help"},
 	}
 	commands = help.Append(providerCommands, commands)
@@ -0,0 +1,185 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package arc
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/net"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// flows summarizes the rejected flows of flow log files downloaded from the
+// flow log bucket, e.g. "network flows ~/flowlogs top=10". Directories are
+// read recursively and gzipped files are uncompressed. Each rejected flow is
+// attributed to the pod, and the pod's security groups, of the instance that
+// owns one of the flow's addresses, the destination first.
+func (n *network) flows(req *route.Request) route.Response {
+	top := 20
+	if v := req.Value("top"); v != "" {
+		var err error
+		if top, err = strconv.Atoi(v); err != nil {
+			msg.Error("Malformed top=%q", v)
+			return route.FAIL
+		}
+	}
+	files := []string{}
+	for _, f := range req.Flags().Get() {
+		if !strings.Contains(f, "=") {
+			files = append(files, f)
+		}
+	}
+	if len(files) == 0 {
+		msg.Error("No flow log files given")
+		return route.FAIL
+	}
+
+	records := []*net.FlowRecord{}
+	for _, f := range files {
+		err := filepath.Walk(f, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			r, err := readFlowLog(path)
+			if err != nil {
+				return fmt.Errorf("%s: %s", path, err)
+			}
+			records = append(records, r...)
+			return nil
+		})
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+	}
+
+	byPod := map[string]int{}
+	bySecgroup := map[string]int{}
+	byFlow := map[string]int{}
+	rejected := 0
+	for _, r := range records {
+		if r.Action != "REJECT" {
+			continue
+		}
+		rejected++
+		pod, secgroups := n.flowOwner(r.DstAddr)
+		if pod == "" {
+			pod, secgroups = n.flowOwner(r.SrcAddr)
+		}
+		if pod == "" {
+			pod = "unknown"
+		}
+		byPod[pod]++
+		for _, s := range secgroups {
+			bySecgroup[s]++
+		}
+		byFlow[fmt.Sprintf("%s -> %s %s/%d", r.SrcAddr, r.DstAddr, r.ProtocolName(), r.DstPort)]++
+	}
+
+	msg.Info("Rejected Flows")
+	msg.Detail("%-20s\t%d of %d", "rejected", rejected, len(records))
+	msg.IndentInc()
+	printFlowCounts("By Pod", byPod, 0)
+	printFlowCounts("By Security Group", bySecgroup, 0)
+	printFlowCounts("Top Flows", byFlow, top)
+	msg.IndentDec()
+	return route.OK
+}
+
+// flowOwner returns the pod and security groups of the instance with the given
+// address, or the subnet group containing the address when it isn't an instance's.
+func (n *network) flowOwner(addr string) (string, []string) {
+	if n.dc.compute != nil {
+		if i := n.dc.compute.FindInstanceByIP(addr); i != nil {
+			return i.Pod().Name(), i.Pod().SecurityGroups()
+		}
+	}
+	for _, s := range *n.Network.SubnetGroups {
+		cidrs, err := n.Network.SubnetGroupCidrs(s.Name())
+		if err != nil {
+			continue
+		}
+		for _, cidr := range cidrs {
+			if ok, err := net.Contains(cidr, addr+"/32"); err == nil && ok {
+				return "subnet_group:" + s.Name(), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func readFlowLog(path string) ([]*net.FlowRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return net.ParseFlowLog(r)
+}
+
+// printFlowCounts prints the counts, largest first, limited to the top
+// counts when top is greater than zero.
+func printFlowCounts(title string, counts map[string]int, top int) {
+	if len(counts) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if top > 0 && len(keys) > top {
+		keys = keys[:top]
+	}
+	msg.Info(title)
+	for _, k := range keys {
+		msg.Detail("%-8d\t%s", counts[k], k)
+	}
+}
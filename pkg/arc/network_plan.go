@@ -0,0 +1,261 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package arc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/net"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// networkPlan is the allocation of the network's cidr block to its subnet groups.
+// Subnet groups with a cidr block keep it, those without one are given the first
+// free cidr block that fits a subnet of the subnet group's size in each
+// availability zone. Subnet groups pinned to a provider target with a network
+// of its own are planned within that network's cidr block.
+type networkPlan struct {
+	cidrBlock string
+	groups    []*plannedGroup
+	overlaps  []string
+	free      []string
+}
+
+type plannedGroup struct {
+	*config.SubnetGroup
+	cidrs   []string
+	planned bool
+}
+
+// newNetworkPlan plans the subnet groups of the network configuration. It returns
+// a non-nil error if the configured cidr blocks overlap or don't fit the network,
+// or if there is no room left for the subnet groups to be planned.
+func newNetworkPlan(cfg *config.Network) (*networkPlan, error) {
+	planner, err := net.NewPlanner(cfg.CidrBlock())
+	if err != nil {
+		return nil, fmt.Errorf("Network cidr: %s", err)
+	}
+	p := &networkPlan{cidrBlock: cfg.CidrBlock()}
+
+	// The planners of the networks, in the order they are first used.
+	cidrs := []string{cfg.CidrBlock()}
+	planners := map[string]*net.Planner{cfg.CidrBlock(): planner}
+	plannerOf := func(s *config.SubnetGroup) (*net.Planner, error) {
+		cidr := s.NetworkCidrBlock()
+		if cidr == "" {
+			cidr = cfg.CidrBlock()
+		}
+		if planners[cidr] == nil {
+			pl, err := net.NewPlanner(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("Subnet group %q, network cidr: %s", s.Name(), err)
+			}
+			cidrs = append(cidrs, cidr)
+			planners[cidr] = pl
+		}
+		return planners[cidr], nil
+	}
+
+	unplanned := []*plannedGroup{}
+	for _, s := range *cfg.SubnetGroups {
+		planner, err := plannerOf(s)
+		if err != nil {
+			return nil, err
+		}
+		g := &plannedGroup{SubnetGroup: s}
+		p.groups = append(p.groups, g)
+		if s.CidrBlock() == "" {
+			if s.Size() == 0 {
+				return nil, fmt.Errorf("Subnet group %q needs either a cidr or a size", s.Name())
+			}
+			unplanned = append(unplanned, g)
+			continue
+		}
+		g.cidrs, err = net.CidrBlocks(s.CidrBlock(), len(cfg.SubnetGroupZones(s)))
+		if err != nil {
+			return nil, fmt.Errorf("Subnet group %q: %s", s.Name(), err)
+		}
+		for _, cidr := range g.cidrs {
+			if err := planner.Reserve(s.Name(), cidr); err != nil {
+				return nil, fmt.Errorf("Subnet group %s", err)
+			}
+		}
+	}
+
+	// Plan the largest subnets first so the smaller ones fill the gaps left behind.
+	sort.SliceStable(unplanned, func(i, j int) bool { return unplanned[i].Size() < unplanned[j].Size() })
+	for _, g := range unplanned {
+		planner, err := plannerOf(g.SubnetGroup)
+		if err != nil {
+			return nil, err
+		}
+		g.cidrs, err = planner.Allocate(g.Name(), g.Size(), len(cfg.SubnetGroupZones(g.SubnetGroup)))
+		if err != nil {
+			return nil, fmt.Errorf("Subnet group %s", err)
+		}
+		g.planned = true
+	}
+
+	for _, g := range p.groups {
+		p.findOverlaps(g, cfg)
+	}
+	for _, cidr := range cidrs {
+		p.free = append(p.free, planners[cidr].Free()...)
+	}
+	return p, nil
+}
+
+// findOverlaps records the cidr aliases, cidr groups and peer networks that
+// overlap the subnet group. These aren't errors since a cidr alias or group
+// may purposely refer to addresses within the network.
+func (p *networkPlan) findOverlaps(g *plannedGroup, cfg *config.Network) {
+	check := func(kind, name, other string) {
+		for _, cidr := range g.cidrs {
+			if overlap, err := net.Overlaps(cidr, other); err == nil && overlap {
+				p.overlaps = append(p.overlaps, fmt.Sprintf("Subnet group %s, %s overlaps %s %s, %s", g.Name(), cidr, kind, name, other))
+				return
+			}
+		}
+	}
+	aliases := cfg.CidrAliases()
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		check("cidr alias", name, aliases[name])
+	}
+	groups := cfg.CidrGroups()
+	names = make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, cidr := range groups[name] {
+			check("cidr group", name, cidr)
+		}
+	}
+	for _, peering := range cfg.Peerings() {
+		for _, cidr := range peering.Cidrs() {
+			check("peering", peering.Name(), cidr)
+		}
+	}
+}
+
+// Planned returns the planned cidr block of the named subnet group, or an empty
+// string if the subnet group has a configured cidr block.
+func (p *networkPlan) Planned(name string) string {
+	for _, g := range p.groups {
+		if g.Name() == name && g.planned {
+			return g.cidrs[0]
+		}
+	}
+	return ""
+}
+
+func (p *networkPlan) print() {
+	msg.Info("Network Plan")
+	msg.Detail("%-20s\t%s", "cidr", p.cidrBlock)
+	msg.IndentInc()
+	for _, g := range p.groups {
+		state := "configured"
+		if g.planned {
+			state = "planned"
+		}
+		msg.Info("SubnetGroup %s", g.Name())
+		msg.Detail("%-20s\t%s, %s", "cidr", g.cidrs[0], state)
+		for _, cidr := range g.cidrs {
+			msg.Detail("%-20s\t%s", "subnet", cidr)
+		}
+	}
+	msg.IndentDec()
+	for _, overlap := range p.overlaps {
+		msg.Warn("%s", overlap)
+	}
+	msg.Info("Free")
+	for _, cidr := range p.free {
+		msg.Detail("%-20s\t%s", "cidr", cidr)
+	}
+}
+
+// json provides the planned subnet_groups element of the network configuration.
+func (p *networkPlan) json() (string, error) {
+	groups := config.SubnetGroups{}
+	for _, g := range p.groups {
+		s := *g.SubnetGroup
+		s.SetCidrBlock(g.cidrs[0])
+		groups = append(groups, &s)
+	}
+	data, err := json.MarshalIndent(struct {
+		SubnetGroups config.SubnetGroups `json:"subnet_groups"`
+	}{groups}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// plan handles the network plan command. The json flag emits the planned
+// subnet_groups, the update_config flag saves the planned cidr blocks to the
+// datacenter's configuration.
+func (n *network) plan(req *route.Request) route.Response {
+	if n.networkPlanErr != nil {
+		msg.Error(n.networkPlanErr.Error())
+		return route.FAIL
+	}
+	if req.Flag("json") {
+		data, err := n.networkPlan.json()
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		msg.Raw("%s\n", data)
+		return route.OK
+	}
+	n.networkPlan.print()
+	if !req.Flag("update_config") {
+		return route.OK
+	}
+	for _, g := range n.networkPlan.groups {
+		if !g.planned {
+			continue
+		}
+		path := []string{"datacenter", "network", "subnet_groups", "subnet=" + g.Name(), "cidr"}
+		if err := config.UpdateArc(req.DataCenter(), path, g.cidrs[0]); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		msg.Detail("Updated the %s config, subnet group %s: %s", req.DataCenter(), g.Name(), g.cidrs[0])
+	}
+	return route.OK
+}
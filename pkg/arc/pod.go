@@ -56,9 +56,15 @@ type Pod struct {
 	*resource.Resources
 	*config.Pod
 	cluster      resource.Cluster
+	provider     provider.DataCenter
 	instances    *instances
+	autoScaling  resource.ProviderAutoScalingGroup
+	placement    resource.ProviderPlacementGroup
+	loadBalancer resource.ProviderLoadBalancer
 	cnameRecords []resource.DnsRecord
 	primaryCName resource.DnsRecord
+	srvRecords   []resource.DnsRecord
+	aliasRecords []resource.DnsRecord
 	derived_     resource.Pod
 }
 
@@ -66,6 +72,40 @@ type Pod struct {
 func newPod(cluster resource.Cluster, prov provider.DataCenter, cfg *config.Pod) (resource.Pod, error) {
 	log.Debug("Initializing Pod %q", cfg.Name())
 
+	if err := cfg.Purchasing().Validate(); err != nil {
+		return nil, fmt.Errorf("Pod %q: %s", cfg.Name(), err)
+	}
+	if cfg.AutoScaling() != nil {
+		if err := cfg.AutoScaling().Validate(); err != nil {
+			return nil, fmt.Errorf("Pod %q: %s", cfg.Name(), err)
+		}
+		if cfg.Purchasing().Reserved() {
+			return nil, fmt.Errorf("Pod %q: The reserved purchasing strategy can't be used with an autoscaling group", cfg.Name())
+		}
+	}
+	if cfg.Retention() != nil {
+		if err := cfg.Retention().Validate(); err != nil {
+			return nil, fmt.Errorf("Pod %q: %s", cfg.Name(), err)
+		}
+	}
+	if err := cfg.Placement().Validate(); err != nil {
+		return nil, fmt.Errorf("Pod %q: %s", cfg.Name(), err)
+	}
+	if cfg.LoadBalancer() != nil {
+		if err := validateLoadBalancer(cluster, cfg); err != nil {
+			return nil, fmt.Errorf("Pod %q: %s", cfg.Name(), err)
+		}
+	}
+	switch cfg.Bootstrap() {
+	case "ssh":
+	case "cloud-init":
+		if cfg.AutoScaling() != nil {
+			return nil, fmt.Errorf("Pod %q: The cloud-init bootstrap isn't supported with autoscaling", cfg.Name())
+		}
+	default:
+		return nil, fmt.Errorf("Pod %q: Unknown bootstrap %q", cfg.Name(), cfg.Bootstrap())
+	}
+
 	factory := podFactories[cfg.ServerType()]
 	if factory != nil {
 		return factory(cluster, prov, cfg)
@@ -79,19 +119,48 @@ func NewDefaultPod(cluster resource.Cluster, prov provider.DataCenter, cfg *conf
 		Resources: resource.NewResources(),
 		Pod:       cfg,
 		cluster:   cluster,
+		provider:  prov,
 	}
 	p.derived_ = p
 
+	var err error
+
+	// The autoscaling group is needed by the instances it manages.
+	if cfg.AutoScaling() != nil {
+		p.autoScaling, err = prov.NewAutoScalingGroup(p, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The placement group the instances are launched into.
+	if cfg.Placement().Group() != "" {
+		p.placement, err = prov.NewPlacementGroup(p, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The load balancer the instances register with.
+	if cfg.LoadBalancer() != nil {
+		p.loadBalancer, err = prov.NewLoadBalancer(p, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Allocate a config.Instances structure since it isn't part of the config file.
+	names, err := p.instanceNames(cfg.Count())
+	if err != nil {
+		return nil, err
+	}
 	instancesConfig := config.Instances{}
-	for i := 1; i <= cfg.Count(); i++ {
-		name := fmt.Sprintf("%s-%02d", cfg.Name(), i)
+	for _, name := range names {
 		conf := config.NewInstance(name, cfg)
 		instancesConfig = append(instancesConfig, conf)
 	}
 	cfg.Instances = &instancesConfig
 
-	var err error
 	p.instances, err = newInstances(p, prov, cfg.Instances)
 	if err != nil {
 		return nil, err
@@ -100,6 +169,41 @@ func NewDefaultPod(cluster resource.Cluster, prov provider.DataCenter, cfg *conf
 	return p, nil
 }
 
+// instanceNames returns the names of the first count instances of the pod,
+// expanded from the pod's naming template, or the datacenter compute's
+// template when the pod doesn't have one. The zones are those of the provider
+// target the pod's cluster is pinned to.
+func (p *Pod) instanceNames(count int) ([]string, error) {
+	if count == 0 {
+		return nil, nil
+	}
+	compute := p.Cluster().Compute()
+	template := p.Naming()
+	if template == "" {
+		template = compute.Naming()
+	}
+	zones, err := p.Placement().Zones(p.provider.AvailabilityZones(compute.DataCenter().Network().AvailabilityZones()), count)
+	if err != nil {
+		return nil, fmt.Errorf("Pod %q: %s", p.Name(), err)
+	}
+	names := []string{}
+	for n := 1; n <= count; n++ {
+		values := map[string]string{
+			"pod":        p.Name(),
+			"cluster":    p.Cluster().Name(),
+			"dc":         compute.Name(),
+			"servertype": p.ServerType(),
+			"az":         zones[n-1],
+		}
+		name, err := config.ExpandNaming(template, values, n)
+		if err != nil {
+			return nil, fmt.Errorf("Pod %q: %s", p.Name(), err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
 // Cluster provides access to Pod's parent. Cluster satisfies the resource.Pod interface.
 func (p *Pod) Cluster() resource.Cluster {
 	return p.cluster
@@ -110,9 +214,50 @@ func (p *Pod) Instances() resource.Instances {
 	return p.instances
 }
 
+// ProviderAutoScalingGroup provides access to the autoscaling group managing the pod's
+// instances. ProviderAutoScalingGroup satisfies the resource.Pod interface.
+func (p *Pod) ProviderAutoScalingGroup() resource.ProviderAutoScalingGroup {
+	return p.autoScaling
+}
+
+// ProviderLoadBalancer provides access to the load balancer the pod's instances are
+// registered with. ProviderLoadBalancer satisfies the resource.Pod interface.
+func (p *Pod) ProviderLoadBalancer() resource.ProviderLoadBalancer {
+	return p.loadBalancer
+}
+
+// Created satisfies the embedded resource.Resource interface in resource.Pod.
+// A pod backed by an autoscaling group, launched into a placement group or
+// behind a load balancer also requires the group or load balancer to be created.
+func (p *Pod) Created() bool {
+	if p.autoScaling != nil && !p.autoScaling.Created() {
+		return false
+	}
+	if p.placement != nil && !p.placement.Created() {
+		return false
+	}
+	if p.loadBalancer != nil && !p.loadBalancer.Created() {
+		return false
+	}
+	return p.Resources.Created()
+}
+
+// Destroyed satisfies the embedded resource.Resource interface in resource.Pod.
+// A pod backed by an autoscaling group or behind a load balancer also requires
+// the group or load balancer to be destroyed.
+func (p *Pod) Destroyed() bool {
+	if p.autoScaling != nil && !p.autoScaling.Destroyed() {
+		return false
+	}
+	if p.loadBalancer != nil && !p.loadBalancer.Destroyed() {
+		return false
+	}
+	return p.Resources.Destroyed()
+}
+
 // FindInstance finds the instance in this pod by name. This implies instances are named uniquely.
-// The name takes the form "<pod name>-<instance number>". Find instance satisfies the resource.Pod
-// interface.
+// The name is expanded from the naming template, "<pod name>-<instance number>" by default.
+// Find instance satisfies the resource.Pod interface.
 func (p *Pod) FindInstance(name string) resource.Instance {
 	return p.instances.Find(name)
 }
@@ -266,6 +411,15 @@ func (p *Pod) Route(req *route.Request) route.Response {
 	case route.Info:
 		p.info(req)
 		return route.OK
+	case route.Audit:
+		if err := aaa.NewAudit("Pod"); err != nil {
+			msg.Error(err.Error())
+		}
+		if err := p.Audit("Pod"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
 	case route.Create:
 		return p.create(req)
 	case route.Destroy:
@@ -280,6 +434,16 @@ func (p *Pod) Route(req *route.Request) route.Response {
 		return p.restart(req)
 	case route.Replace:
 		return p.replace(req)
+	case route.Resize:
+		return p.resize(req)
+	case route.Scale:
+		// See pod_scale.go
+		return p.scale(req)
+	case route.Bake:
+		// See pod_bake.go
+		return p.bake(req)
+	case route.Snapshot, route.Restore:
+		return p.routeToChildren(req)
 	default:
 		msg.Error("Unknown pod command %q.", req.Command().String())
 	}
@@ -307,6 +471,18 @@ func (p *Pod) load(req *route.Request) route.Response {
 	if dns != nil {
 		p.cnameRecords = dns.CNameRecords().FindByPod(p.Name())
 		p.primaryCName = dns.CNameRecords().Find(p.Name())
+		p.srvRecords = dns.SRVRecords().FindByPod(p.Name())
+		p.aliasRecords = dns.AliasRecords().FindByPod(p.Name())
+	}
+	if p.placement != nil && p.placement.Route(req) != route.OK {
+		return route.FAIL
+	}
+	if p.loadBalancer != nil && p.loadBalancer.Route(req) != route.OK {
+		return route.FAIL
+	}
+	// Load the autoscaling group first so its instances can be found.
+	if p.autoScaling != nil && p.autoScaling.Route(req) != route.OK {
+		return route.FAIL
 	}
 	return p.RouteInOrder(req)
 }
@@ -328,7 +504,13 @@ func podHelp(n string) {
 		{Name: route.Stop.String(), Desc: fmt.Sprintf("stop%s pod", name)},
 		{Name: route.Restart.String(), Desc: fmt.Sprintf("restart%s pod", name)},
 		{Name: route.Replace.String(), Desc: fmt.Sprintf("replace%s pod", name)},
+		{Name: route.Resize.String(), Desc: fmt.Sprintf("resize%s pod instances to the configured type", name)},
+		{Name: route.Scale.String() + " count=[number]", Desc: fmt.Sprintf("grow or shrink%s pod and update the count in the config file", name)},
 		{Name: route.Audit.String(), Desc: fmt.Sprintf("audit%s pod", name)},
+		{Name: route.Bake.String(), Desc: fmt.Sprintf("bake an image for%s pod", name)},
+		{Name: route.Snapshot.String(), Desc: fmt.Sprintf("snapshot%s pod volumes, optionally name=[name]", name)},
+		{Name: route.Snapshot.String() + " prune", Desc: fmt.Sprintf("prune%s pod snapshots per the retention policy", name)},
+		{Name: route.Restore.String(), Desc: fmt.Sprintf("restore%s pod volumes, optionally snapshot=[name]", name)},
 		{Name: route.Destroy.String(), Desc: fmt.Sprintf("destroy%s pod", name)},
 		{Name: route.Config.String(), Desc: fmt.Sprintf("provide the%s pod configuration", name)},
 		{Name: route.Info.String(), Desc: fmt.Sprintf("provide information about allocated%s pod", name)},
@@ -362,6 +544,8 @@ func (p *Pod) info(req *route.Request) {
 	}
 	msg.Detail("%-20s\t%s", "security_groups", securityGroups)
 	msg.Detail("%-20s\t%d", "count", p.Count())
+	msg.Detail("%-20s\t%s", "placement", p.Placement().Policy())
+	msg.Detail("%-20s\t%s", "distribution", config.FormatDistribution(config.Distribution(p.deployedZones())))
 	if p.DnsCNameRecords() != nil {
 		msg.Detail("")
 		for _, record := range p.DnsCNameRecords() {
@@ -379,6 +563,15 @@ func (p *Pod) info(req *route.Request) {
 		}
 	}
 	msg.IndentInc()
+	if p.placement != nil {
+		p.placement.Route(req)
+	}
+	if p.autoScaling != nil {
+		p.autoScaling.Route(req)
+	}
+	if p.loadBalancer != nil {
+		p.loadBalancer.Route(req)
+	}
 	p.RouteInOrder(req)
 	msg.IndentDec()
 }
@@ -410,16 +603,76 @@ func (p *Pod) PreCreate(req *route.Request) route.Response {
 }
 
 func (p *Pod) Create(req *route.Request) route.Response {
+	if p.placement != nil && !req.Flag("podonly") {
+		if resp := p.placement.Route(req); resp != route.OK {
+			return resp
+		}
+	}
+	// The load balancer is created first so the instances register with it
+	// as they are created.
+	if p.loadBalancer != nil && !req.Flag("podonly") {
+		if resp := p.loadBalancer.Route(req); resp != route.OK {
+			return resp
+		}
+	}
+	// The autoscaling group launches the instances, which are then claimed
+	// and set up by the pod's instances.
+	if p.autoScaling != nil && !req.Flag("podonly") {
+		if resp := p.setLaunchUserData(req); resp != route.OK {
+			return resp
+		}
+		if resp := p.autoScaling.Route(req); resp != route.OK {
+			return resp
+		}
+	}
 	return p.routeToChildren(req)
 }
 
-func (p *Pod) PostCreate(req *route.Request) route.Response {
-	// Create the cname record for this pod if it exists.
-	if p.cnameRecords == nil {
+// setLaunchUserData passes the first boot setup of the pod's instances to the
+// autoscaling group for its launch template.
+func (p *Pod) setLaunchUserData(req *route.Request) route.Response {
+	if p.autoScaling.Created() || p.instances.Length() == 0 {
 		return route.OK
 	}
-	for _, record := range p.cnameRecords {
-		if resp := record.Route(req); resp != route.OK {
+	i, ok := p.instances.Get()[0].(*Instance)
+	if !ok || !i.CloudInit() {
+		return route.OK
+	}
+	data, err := i.launchUserData(req)
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	p.autoScaling.SetUserData(data)
+	return route.OK
+}
+
+func (p *Pod) PostCreate(req *route.Request) route.Response {
+	// Create the cname, srv and alias records for this pod if they exist.
+	return p.routeDnsRecords(req)
+}
+
+// routeDnsRecords routes the request to the dns records derived from this pod.
+func (p *Pod) routeDnsRecords(req *route.Request) route.Response {
+	for _, records := range [][]resource.DnsRecord{p.cnameRecords, p.srvRecords, p.aliasRecords} {
+		for _, record := range records {
+			if resp := record.Route(req); resp != route.OK {
+				return resp
+			}
+		}
+	}
+	return route.OK
+}
+
+// updateSRVRecords points the srv records derived from this pod at the pod's
+// current instances, destroying them once the pod has none left.
+func (p *Pod) updateSRVRecords(req *route.Request) route.Response {
+	cmd := route.Provision
+	if p.Count() == 0 {
+		cmd = route.Destroy
+	}
+	for _, record := range p.srvRecords {
+		if resp := record.Route(req.Clone(cmd)); resp != route.OK {
 			return resp
 		}
 	}
@@ -449,23 +702,38 @@ func (p *Pod) destroy(req *route.Request) route.Response {
 }
 
 func (p *Pod) PreDestroy(req *route.Request) route.Response {
-	// Destroy the cname records for this pod if it exists.
-	if p.cnameRecords == nil {
-		return route.OK
+	// Destroy the cname, srv and alias records for this pod if they exist.
+	if resp := p.routeDnsRecords(req); resp != route.OK {
+		return resp
 	}
-	for _, record := range p.cnameRecords {
-		if resp := record.Route(req); resp != route.OK {
-			return resp
-		}
+	// Destroy the load balancer before the instances, so they don't each
+	// wait for their connections to drain.
+	if p.loadBalancer != nil && !req.Flag("podonly") {
+		return p.loadBalancer.Route(req)
 	}
 	return route.OK
 }
 
 func (p *Pod) Destroy(req *route.Request) route.Response {
-	return p.routeReverseToChildren(req)
+	if p.autoScaling == nil || req.Flag("podonly") {
+		return p.routeReverseToChildren(req)
+	}
+	// Keep the autoscaling group from replacing the instances as they are destroyed.
+	if err := p.autoScaling.Release(); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if resp := p.routeReverseToChildren(req); resp != route.OK {
+		return resp
+	}
+	return p.autoScaling.Route(req)
 }
 
 func (p *Pod) PostDestroy(req *route.Request) route.Response {
+	// The placement group can only be deleted once its instances are terminated.
+	if p.placement != nil && !req.Flag("podonly") {
+		return p.placement.Route(req)
+	}
 	return route.OK
 }
 
@@ -530,7 +798,14 @@ func (p *Pod) PreStart(req *route.Request) route.Response {
 }
 
 func (p *Pod) Start(req *route.Request) route.Response {
-	return p.routeToChildren(req)
+	if resp := p.routeToChildren(req); resp != route.OK {
+		return resp
+	}
+	// Resume the autoscaling group health checks once the instances are running.
+	if p.autoScaling != nil && !req.Flag("podonly") {
+		return p.autoScaling.Route(req)
+	}
+	return route.OK
 }
 
 func (p *Pod) PostStart(req *route.Request) route.Response {
@@ -564,6 +839,12 @@ func (p *Pod) PreStop(req *route.Request) route.Response {
 }
 
 func (p *Pod) Stop(req *route.Request) route.Response {
+	// Suspend the autoscaling group health checks so stopped instances aren't replaced.
+	if p.autoScaling != nil && !req.Flag("podonly") {
+		if resp := p.autoScaling.Route(req); resp != route.OK {
+			return resp
+		}
+	}
 	return p.routeReverseToChildren(req)
 }
 
@@ -636,6 +917,40 @@ func (p *Pod) Replace(req *route.Request) route.Response {
 }
 
 func (p *Pod) PostReplace(req *route.Request) route.Response {
+	return p.updateSRVRecords(req)
+}
+
+// Resize
+
+func (p *Pod) resize(req *route.Request) route.Response {
+	msg.Info("Pod Resize: %s", p.Name())
+	if p.Destroyed() {
+		msg.Detail("Pod does not exist, skipping...")
+		return route.OK
+	}
+	if resp := p.Derived().PreResize(req); resp != route.OK {
+		return resp
+	}
+	if resp := p.Derived().Resize(req); resp != route.OK {
+		return resp
+	}
+	if resp := p.Derived().PostResize(req); resp != route.OK {
+		return resp
+	}
+	msg.Detail("Pod Resized: %s", p.Name())
+	aaa.Accounting("Pod resized: %s", p.Name())
+	return route.OK
+}
+
+func (p *Pod) PreResize(req *route.Request) route.Response {
+	return route.OK
+}
+
+func (p *Pod) Resize(req *route.Request) route.Response {
+	return p.routeToChildren(req)
+}
+
+func (p *Pod) PostResize(req *route.Request) route.Response {
 	return route.OK
 }
 
@@ -659,6 +974,24 @@ func (p *Pod) PreAudit(flags ...string) error {
 }
 
 func (p *Pod) MidAudit(flags ...string) error {
+	if p.placement != nil {
+		if err := p.placement.Audit(flags...); err != nil {
+			return err
+		}
+	}
+	if err := p.auditPlacement(flags...); err != nil {
+		return err
+	}
+	if p.autoScaling != nil {
+		if err := p.autoScaling.Audit(flags...); err != nil {
+			return err
+		}
+	}
+	if p.loadBalancer != nil {
+		if err := p.loadBalancer.Audit(flags...); err != nil {
+			return err
+		}
+	}
 	return p.instances.Audit(flags...)
 }
 
@@ -0,0 +1,181 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package arc
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/command"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// bake creates an image for the pod's servertype and version. It provisions a
+// temporary instance from the base image, cleans it, creates the image from it
+// and then destroys the instance. With the update_config flag the image is
+// added to the provider images in the datacenter config file, so pods that are
+// baked launch from it. With the destroy flag the instance left behind by a
+// failed bake is destroyed.
+func (p *Pod) bake(req *route.Request) route.Response {
+	msg.Info("Pod Bake: %s, %s", p.Name(), p.BakedImage())
+
+	instance, err := p.newBakeInstance()
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if resp := instance.Route(req.Clone(route.Load)); resp != route.OK {
+		return resp
+	}
+
+	// The destroy flag cleans up the instance left behind by a failed bake.
+	if req.Flag("destroy") {
+		return instance.Route(req.Clone(route.Destroy))
+	}
+	if instance.Created() {
+		msg.Error("Instance %s exists from a previous bake, remove it with 'arc %s pod %s bake destroy'", instance.Name(), req.DataCenter(), p.Name())
+		return route.FAIL
+	}
+	if resp := instance.Route(req.Clone(route.Create)); resp != route.OK {
+		return resp
+	}
+
+	id, resp := p.bakeImage(req, instance)
+	if resp != route.OK {
+		msg.Warn("Leaving instance %s for inspection, remove it with 'arc %s pod %s bake destroy'", instance.Name(), req.DataCenter(), p.Name())
+		return resp
+	}
+	if resp := instance.Route(req.Clone(route.Destroy)); resp != route.OK {
+		return resp
+	}
+	msg.Detail("Baked: %s, %s", p.BakedImage(), id)
+	aaa.Accounting("Image baked: %s, %s", p.BakedImage(), id)
+
+	if !req.Flag("update_config") {
+		return route.OK
+	}
+	if err := p.updateImageConfig(req.DataCenter(), id); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	msg.Detail("Updated the %s config, image %s: %s", req.DataCenter(), p.BakedImage(), id)
+	return route.OK
+}
+
+// newBakeInstance creates the temporary instance used to bake the pod's image.
+// It is launched from the base image with only the boot volume.
+func (p *Pod) newBakeInstance() (resource.Instance, error) {
+	cfg := *p.Pod
+	cfg.Count_ = 1
+	cfg.Baked_ = false
+	cfg.AutoScaling_ = nil
+	cfg.Purchasing_ = nil
+	cfg.Instances = nil
+	if p.Pod.Volumes != nil {
+		volumes := config.Volumes{}
+		for _, v := range *p.Pod.Volumes {
+			if v.Boot() {
+				volumes = append(volumes, v)
+			}
+		}
+		cfg.Volumes = &volumes
+	}
+
+	instances := p.instances.Get()
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("Pod %q has no instances to bake an image from", p.Name())
+	}
+	subnet := instances[0].(resource.Instance).Subnet()
+	keypair := p.Cluster().Compute().KeyPair()
+
+	return newInstance(p.Derived(), subnet, keypair, p.provider, config.NewInstance(p.Name()+"-bake", &cfg))
+}
+
+func (p *Pod) bakeImage(req *route.Request, instance resource.Instance) (string, route.Response) {
+	if !command.RunRemote(command.Command{
+		Instance: instance,
+		Desc:     "clean image",
+		Src:      "/usr/lib/arc/create/clean_image",
+	}) {
+		return "", route.FAIL
+	}
+	if resp := instance.Route(req.Clone(route.Stop)); resp != route.OK {
+		return "", resp
+	}
+
+	msg.Info("Create Image: %s", p.BakedImage())
+	name := p.BakedImage() + "-" + time.Now().UTC().Format("20060102150405")
+	tags := map[string]string{
+		"Name":        p.BakedImage(),
+		"Image":       p.Image(),
+		"ServerType":  p.ServerType(),
+		"Version":     p.Version(),
+		"AideVersion": strconv.Itoa(p.Cluster().Compute().AideVersion()),
+		"Created By":  req.UserId(),
+		"DataCenter":  req.DataCenter(),
+	}
+	id, err := instance.CreateImage(name, tags)
+	if err != nil {
+		msg.Error(err.Error())
+		return "", route.FAIL
+	}
+	return id, route.OK
+}
+
+// updateImageConfig adds the baked image to the images of the provider in the
+// datacenter config file.
+func (p *Pod) updateImageConfig(dc, id string) error {
+	cfg, err := config.NewArc(dc)
+	if err != nil {
+		return err
+	}
+	path := []string{"provider", "images", p.BakedImage()}
+	if cfg.DataCenter != nil && cfg.DataCenter.Provider != nil {
+		path = append([]string{"datacenter"}, path...)
+	}
+	return config.UpdateArc(dc, path, id)
+}
+
+func imageHelp(n string) {
+	name := " [pod]"
+	if n != "" {
+		name = " " + n
+	}
+	commands := []help.Command{
+		{Name: route.Bake.String(), Desc: fmt.Sprintf("bake an image for the%s pod", name)},
+		{Name: route.Bake.String() + " update_config", Desc: "bake an image and add it to the provider images in the config"},
+		{Name: route.Bake.String() + " destroy", Desc: "destroy the instance left behind by a failed bake"},
+		{Name: route.Help.String(), Desc: "provide this help"},
+	}
+	help.Print(fmt.Sprintf("image%s", name), commands)
+}
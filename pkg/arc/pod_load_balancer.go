@@ -0,0 +1,94 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package arc
+
+import (
+	"fmt"
+
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// validateLoadBalancer checks the pod's load balancer configuration and that
+// the subnet group and security groups it uses exist in the network. An
+// internet-facing load balancer must be placed in a public subnet group.
+func validateLoadBalancer(cluster resource.Cluster, cfg *config.Pod) error {
+	lb := cfg.LoadBalancer()
+	if err := lb.Validate(); err != nil {
+		return err
+	}
+	network := cluster.Compute().DataCenter().Network()
+	name := lb.SubnetGroup()
+	if name == "" {
+		name = cfg.SubnetGroup()
+	}
+	subnetGroup := network.SubnetGroups().Find(name)
+	if subnetGroup == nil {
+		return fmt.Errorf("The load balancer uses the unknown subnet group %q", name)
+	}
+	if lb.Scheme() == "internet-facing" && subnetGroup.Access() != "public" && subnetGroup.Access() != "public_elastic" {
+		return fmt.Errorf("The internet-facing load balancer requires a public subnet group, %q has %s access", name, subnetGroup.Access())
+	}
+	for _, sg := range lb.SecurityGroups() {
+		if network.SecurityGroups().Find(sg) == nil {
+			return fmt.Errorf("The load balancer uses the unknown security group %q", sg)
+		}
+	}
+	return nil
+}
+
+// registerLoadBalancer registers the instance with its pod's load balancer once
+// the instance has been provisioned, so it only receives traffic when it is
+// ready. Registering is idempotent, so an instance created with noprovision
+// is registered by its first provision.
+func (i *Instance) registerLoadBalancer(req *route.Request) route.Response {
+	lb := i.Pod().ProviderLoadBalancer()
+	if lb == nil || !lb.Created() {
+		return route.OK
+	}
+	if err := lb.Register(i); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	return route.OK
+}
+
+// deregisterLoadBalancer deregisters the instance from its pod's load balancer,
+// returning once the instance's connections have drained.
+func (i *Instance) deregisterLoadBalancer(req *route.Request) route.Response {
+	lb := i.Pod().ProviderLoadBalancer()
+	if lb == nil || !lb.Created() {
+		return route.OK
+	}
+	if err := lb.Deregister(i); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	return route.OK
+}
@@ -0,0 +1,65 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package arc
+
+import (
+	"fmt"
+
+	"github.com/cisco/arc/pkg/aaa"
+)
+
+// deployedZones returns the availability zones of the pod's created instances.
+func (p *Pod) deployedZones() []string {
+	zones := []string{}
+	for _, i := range p.instances.GetInstances() {
+		if i.Created() && i.AvailabilityZone() != "" {
+			zones = append(zones, i.AvailabilityZone())
+		}
+	}
+	return zones
+}
+
+// auditPlacement flags a pod whose instances are deployed in availability
+// zones that violate its placement policy.
+func (p *Pod) auditPlacement(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil {
+		return fmt.Errorf("Audit Object does not exist")
+	}
+	zones := p.deployedZones()
+	if len(zones) == 0 {
+		return nil
+	}
+	available := p.Cluster().Compute().DataCenter().Network().AvailabilityZones()
+	if err := p.Placement().Check(available, zones); err != nil {
+		a.Audit(aaa.Mismatched, "Pod %q | Placement %s: %s", p.Name(), p.Placement().Policy(), err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package arc
+
+import (
+	"strconv"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// scale grows or shrinks the pod to the given count of instances and writes
+// the count back to the datacenter config file. The file is edited in place,
+// since arc doesn't layer config overlays over the datacenter config. When the
+// scale fails part way the count of the instances changed so far is written,
+// so the next load still finds them.
+func (p *Pod) scale(req *route.Request) route.Response {
+	value := req.Value("count")
+	if value == "" {
+		msg.Error("The scale command requires count=[number].")
+		return route.FAIL
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		msg.Error("Invalid count %q.", value)
+		return route.FAIL
+	}
+	if p.AutoScaling() != nil {
+		msg.Error("Pod %q is managed by an autoscaling group, change the group's max instead.", p.Name())
+		return route.FAIL
+	}
+
+	current := p.Count()
+	msg.Info("Pod Scale: %s, %d to %d instances", p.Name(), current, count)
+	var resp route.Response
+	switch {
+	case count > current:
+		resp = p.grow(req, count)
+	case count < current:
+		resp = p.shrink(req, count)
+	default:
+		msg.Detail("Pod has %d instances, skipping...", count)
+		return route.OK
+	}
+	if p.Count() != current {
+		if r := p.updateSRVRecords(req); resp == route.OK {
+			resp = r
+		}
+	}
+	if p.Count() == current {
+		return resp
+	}
+
+	path := []string{"datacenter", "compute", "clusters", "cluster=" + p.Cluster().Name(), "pods", "pod=" + p.Name(), "count"}
+	if err := config.UpdateArc(req.DataCenter(), path, p.Count()); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	msg.Detail("Updated the %s config, pod %s count: %d", req.DataCenter(), p.Name(), p.Count())
+	if resp != route.OK {
+		msg.Error("Pod %s scaled to %d of %d instances", p.Name(), p.Count(), count)
+		return resp
+	}
+	aaa.Accounting("Pod scaled: %s, %d to %d instances", p.Name(), current, count)
+	return route.OK
+}
+
+// grow adds instances to the pod. Creating an instance also provisions it.
+func (p *Pod) grow(req *route.Request, count int) route.Response {
+	names, err := p.instanceNames(count)
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	for n := p.Count() + 1; n <= count; n++ {
+		conf := config.NewInstance(names[n-1], p.Pod)
+		instance, err := p.instances.add(conf)
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		*p.Pod.Instances = append(*p.Pod.Instances, conf)
+		p.Pod.Count_ = n
+
+		if resp := instance.Route(req.Clone(route.Load)); resp != route.OK {
+			return resp
+		}
+		if resp := instance.Route(req.Clone(route.Create)); resp != route.OK {
+			return resp
+		}
+	}
+	return route.OK
+}
+
+// shrink destroys the highest numbered instances of the pod. Destroying an
+// instance stops its paging first.
+func (p *Pod) shrink(req *route.Request, count int) route.Response {
+	names, err := p.instanceNames(p.Count())
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	for n := p.Count(); n > count; n-- {
+		if instance := p.instances.Find(names[n-1]); instance != nil {
+			if resp := instance.Route(req.Clone(route.Destroy)); resp != route.OK {
+				return resp
+			}
+		}
+		p.Pod.Count_ = n - 1
+	}
+	return route.OK
+}
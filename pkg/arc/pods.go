@@ -61,6 +61,12 @@ func newPods(cluster *Cluster, provider provider.DataCenter, cfg *config.Pods) (
 		if err != nil {
 			return nil, err
 		}
+		// Instance names are expanded from naming templates, so they may collide.
+		for name := range pod.Instances().GetInstances() {
+			if p.FindInstance(name) != nil {
+				return nil, fmt.Errorf("Instance name %q of pod %q is used by another pod, check the naming templates", name, pod.Name())
+			}
+		}
 		p.pods[pod.Name()] = pod
 		p.Append(pod)
 	}
@@ -75,7 +81,7 @@ func (p *pods) Find(name string) resource.Pod {
 
 // FindInstance finds and instance of this pod by name.
 // This implies instances are uniquely named.
-// The name takes the form "<pod name>-<instance number>".
+// The name is expanded from the naming template, "<pod name>-<instance number>" by default.
 // FindInstance satisfies the resource.Pods interface.
 func (p *pods) FindInstance(name string) resource.Instance {
 	for _, pod := range p.pods {
@@ -87,6 +93,17 @@ func (p *pods) FindInstance(name string) resource.Instance {
 	return nil
 }
 
+// instanceNames returns the names of the instances of all the pods.
+func (p *pods) instanceNames() []string {
+	names := []string{}
+	for _, pod := range p.pods {
+		for name := range pod.Instances().GetInstances() {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // FindInstanceByIP finds and instance of this pod by ip address.
 // FindInstanceByIP satisfies the resource.Pods interface.
 func (p *pods) FindInstanceByIP(ip string) resource.Instance {
@@ -120,7 +137,7 @@ func (p *pods) Route(req *route.Request) route.Response {
 
 	// Handle the command.
 	switch req.Command() {
-	case route.Load, route.Create, route.Provision, route.Start, route.Stop, route.Restart, route.Replace:
+	case route.Load, route.Create, route.Provision, route.Start, route.Stop, route.Restart, route.Replace, route.Resize, route.Snapshot, route.Restore:
 		return p.RouteInOrder(req)
 	case route.Destroy:
 		return p.RouteReverseOrder(req)
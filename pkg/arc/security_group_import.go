@@ -0,0 +1,73 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package arc
+
+import (
+	"encoding/json"
+
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// importSecgroup emits the security_groups element for a security group that
+// was created outside of arc, e.g. "secgroup import group=sg-1234 name=web".
+// With the adopt flag the deployed security group is named so arc manages it
+// once the emitted configuration is added to the datacenter's config.
+func (s *securityGroups) importSecgroup(req *route.Request) route.Response {
+	group := req.Value("group")
+	if group == "" {
+		msg.Error("The group=id flag is required")
+		return route.FAIL
+	}
+	cfg, err := s.network.ImportSecgroup(group)
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if name := req.Value("name"); name != "" {
+		cfg.Name_ = name
+	}
+	if s.Find(cfg.Name()) != nil {
+		msg.Warn("Security group %q is already configured", cfg.Name())
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	msg.Raw("%s\n", data)
+
+	if !req.Flag("adopt") {
+		return route.OK
+	}
+	if err := s.network.AdoptSecgroup(req, group, cfg.Name()); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	return route.OK
+}
@@ -0,0 +1,105 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package arc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// reach evaluates the configured security rules for a flow between two endpoints,
+// e.g. "secgroup reach from=pod:web to=database:main port=5432". Endpoints are
+// pods, databases, subnet groups, security groups or cidr blocks, see
+// config.Arc.ReachEndpoint. The protocol defaults to tcp.
+func (s *securityGroups) reach(req *route.Request) route.Response {
+	r, err := s.evaluateReach(req)
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+
+	msg.Info("Reach %s -> %s, %s %d", r.Source.Name, r.Destination.Name, r.Protocol, r.Port)
+	msg.IndentInc()
+	printReach("Egress", r.Source, r.EgressChecked, r.EgressAllowed(), r.Egress)
+	printReach("Ingress", r.Destination, r.IngressChecked, r.IngressAllowed(), r.Ingress)
+	msg.IndentDec()
+	if r.Reachable() {
+		msg.Info("Reachable")
+	} else {
+		msg.Warn("Not reachable")
+	}
+	return route.OK
+}
+
+func (s *securityGroups) evaluateReach(req *route.Request) (*config.Reachability, error) {
+	from, to := req.Value("from"), req.Value("to")
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("Both from=endpoint and to=endpoint are required")
+	}
+	protocol := req.Value("protocol")
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	port, err := strconv.Atoi(req.Value("port"))
+	if err != nil {
+		return nil, fmt.Errorf("Malformed port=%q", req.Value("port"))
+	}
+
+	cfg := s.network.dc.arc.Arc
+	src, err := cfg.ReachEndpoint(from)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := cfg.ReachEndpoint(to)
+	if err != nil {
+		return nil, err
+	}
+	return s.network.Network.Reach(src, dst, protocol, port)
+}
+
+func printReach(direction string, e *config.ReachEndpoint, checked, allowed bool, matches []*config.ReachMatch) {
+	msg.Info("%s %s", direction, e.Name)
+	if !checked {
+		msg.Detail("%-20s\t%s", "not checked", "no security groups")
+		return
+	}
+	for _, m := range matches {
+		state := "allowed by"
+		if m.Partial {
+			state = "partially allowed by"
+		}
+		msg.Detail("%-20s\tsecurity group %s, rule %q, remote %s", state, m.SecurityGroup, m.Rule.Description(), m.Remote)
+	}
+	if !allowed {
+		msg.Detail("%-20s\tno %s rule of security groups %s allows the flow", "blocked", strings.ToLower(direction), strings.Join(e.SecurityGroups, ", "))
+	}
+}
@@ -178,6 +178,10 @@ func (s *securityGroups) Route(req *route.Request) route.Response {
 	case route.Info:
 		s.info(req)
 		return route.OK
+	case route.Reach:
+		return s.reach(req)
+	case route.Import:
+		return s.importSecgroup(req)
 	default:
 		msg.Error("Unknown secgroup command %q.", req.Command().String())
 	}
@@ -193,6 +197,9 @@ func (s *securityGroups) help() {
 		{Name: "'name'", Desc: "manage named security group"},
 		{Name: route.Config.String(), Desc: "show the security groups configuration"},
 		{Name: route.Info.String(), Desc: "show information about allocated security groups"},
+		{Name: route.Import.String() + " group=id [name=name]", Desc: "show the configuration of a security group created outside of arc"},
+		{Name: route.Import.String() + " group=id name=name adopt", Desc: "also name the security group so it is managed by arc"},
+		{Name: route.Reach.String() + " from=endpoint to=endpoint [protocol=tcp] port=n", Desc: "show the configured rules allowing a flow between two endpoints"},
 		{Name: route.Help.String(), Desc: "show this help"},
 	}
 	help.Print("secgroup", commands)
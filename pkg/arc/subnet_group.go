@@ -51,6 +51,12 @@ type subnetGroup struct {
 func newSubnetGroup(n *network, prov provider.DataCenter, cfg *config.SubnetGroup) (*subnetGroup, error) {
 	log.Debug("Initializing SubnetGroup %q", cfg.Name())
 
+	// Use the provider target the subnet group is pinned to.
+	prov, err := prov.Target(cfg.Target())
+	if err != nil {
+		return nil, err
+	}
+
 	s := &subnetGroup{
 		Resources:   resource.NewResources(),
 		SubnetGroup: cfg,
@@ -59,9 +65,14 @@ func newSubnetGroup(n *network, prov provider.DataCenter, cfg *config.SubnetGrou
 	}
 
 	cidrBlock := cfg.CidrBlock()
+	ipv6Subnet := cfg.Ipv6Subnet()
+	if ipv6Subnet != "" {
+		if _, err := net.ParseIpv6Subnet(ipv6Subnet); err != nil {
+			return nil, err
+		}
+	}
 	first := true
-	var err error
-	for _, az := range n.AvailabilityZones() {
+	for _, az := range prov.AvailabilityZones(n.AvailabilityZones()) {
 		if first {
 			first = false
 		} else {
@@ -69,6 +80,12 @@ func newSubnetGroup(n *network, prov provider.DataCenter, cfg *config.SubnetGrou
 			if err != nil {
 				return nil, err
 			}
+			if ipv6Subnet != "" {
+				ipv6Subnet, err = net.NextIpv6Subnet(ipv6Subnet)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 		name := cfg.Name() + "-" + az
 		conf := &config.Subnet{}
@@ -78,6 +95,7 @@ func newSubnetGroup(n *network, prov provider.DataCenter, cfg *config.SubnetGrou
 		conf.SetAccess(cfg.Access())
 		conf.SetAvailabilityZone(az)
 		conf.SetManageRoutes(cfg.ManageRoutes())
+		conf.SetIpv6Subnet(ipv6Subnet)
 
 		subnet, err := newSubnet(n, prov, conf)
 		if err != nil {
@@ -125,6 +125,27 @@ func (v *volume) SetTags(t map[string]string) error {
 	return v.providerVolume.SetTags(t)
 }
 
+func (v *volume) Snapshot(t map[string]string) (string, error) {
+	return v.providerVolume.Snapshot(t)
+}
+
+func (v *volume) Snapshots(t map[string]string) ([]*resource.Snapshot, error) {
+	return v.providerVolume.Snapshots(t)
+}
+
+func (v *volume) DeleteSnapshot(id string) error {
+	return v.providerVolume.DeleteSnapshot(id)
+}
+
+func (v *volume) Restore(id string) (string, error) {
+	msg.Detail("Volume Restore: %s, %s from %s", v.Device(), v.MountPoint(), id)
+	return v.providerVolume.Restore(id)
+}
+
+func (v *volume) UseSnapshot(id string) {
+	v.providerVolume.UseSnapshot(id)
+}
+
 func (v *volume) ProviderVolume() resource.ProviderVolume {
 	return v.providerVolume
 }
@@ -0,0 +1,529 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// scalingMetricTypes maps the configured scaling policy metrics to the
+// predefined aws metrics.
+var scalingMetricTypes = map[string]string{
+	"cpu":         "ASGAverageCPUUtilization",
+	"network_in":  "ASGAverageNetworkIn",
+	"network_out": "ASGAverageNetworkOut",
+}
+
+// healthProcesses are the autoscaling processes that replace unhealthy,
+// including stopped, instances.
+var healthProcesses = []string{"HealthCheck", "ReplaceUnhealthy"}
+
+// autoScalingGroup implements the resource.ProviderAutoScalingGroup interface.
+// The group is launched from a launch template of the same name. Instances
+// launched by the group are adopted by the pod's instances when they are
+// loaded and claimed when they are created, at which point they are named
+// like any other instance.
+type autoScalingGroup struct {
+	*config.AutoScaling
+	pod         *config.Pod
+	parent      resource.Pod
+	name        string
+	dataCenter  string
+	ec2         *ec2.EC2
+	autoscaling *autoscaling.AutoScaling
+
+	instances []*instance
+	group     *autoscaling.Group
+	unclaimed []*ec2.Instance
+	userData  []byte
+}
+
+// newAutoScalingGroup constructs the aws autoscaling group.
+func newAutoScalingGroup(pod resource.Pod, cfg *config.Pod, p *dataCenterProvider) (*autoScalingGroup, error) {
+	log.Debug("Initializing AWS AutoScaling Group %q", cfg.Name())
+	dc := pod.Cluster().Compute().Name()
+	return &autoScalingGroup{
+		AutoScaling: cfg.AutoScaling(),
+		pod:         cfg,
+		parent:      pod,
+		name:        dc + "-" + cfg.Name(),
+		dataCenter:  dc,
+		ec2:         p.ec2,
+		autoscaling: p.autoscaling,
+	}, nil
+}
+
+// register associates the pod's instances with the group.
+func (g *autoScalingGroup) register(i *instance) {
+	g.instances = append(g.instances, i)
+}
+
+func (g *autoScalingGroup) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS AutoScaling Group %q", g.name)
+
+	switch req.Command() {
+	case route.Load:
+		if err := g.load(); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Info:
+		g.info()
+		return route.OK
+	case route.Create:
+		return g.create(req)
+	case route.Destroy:
+		return g.destroy(req)
+	case route.Start:
+		return g.start(req)
+	case route.Stop:
+		return g.stop(req)
+	}
+	return route.OK
+}
+
+func (g *autoScalingGroup) Created() bool {
+	return g.group != nil
+}
+
+func (g *autoScalingGroup) Destroyed() bool {
+	return g.group == nil
+}
+
+func (g *autoScalingGroup) Id() string {
+	if g.group == nil {
+		return ""
+	}
+	return aws.StringValue(g.group.AutoScalingGroupARN)
+}
+
+func (g *autoScalingGroup) Available() bool {
+	return len(g.unclaimed) > 0
+}
+
+// SetUserData satisfies the resource.ProviderAutoScalingGroup interface.
+func (g *autoScalingGroup) SetUserData(data []byte) {
+	g.userData = data
+}
+
+// Release sets the group minimum to zero and stops it launching instances, so
+// the instances can be terminated one at a time without being replaced.
+func (g *autoScalingGroup) Release() error {
+	if g.Destroyed() {
+		return nil
+	}
+	msg.Info("Release AutoScaling Group: %s", g.name)
+	if _, err := g.autoscaling.UpdateAutoScalingGroup(&autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(g.name),
+		MinSize:              aws.Int64(0),
+	}); err != nil {
+		return err
+	}
+	_, err := g.autoscaling.SuspendProcesses(&autoscaling.ScalingProcessQuery{
+		AutoScalingGroupName: aws.String(g.name),
+		ScalingProcesses:     aws.StringSlice([]string{"Launch", "AZRebalance"}),
+	})
+	if err == nil {
+		g.group.MinSize = aws.Int64(0)
+	}
+	return err
+}
+
+func (g *autoScalingGroup) load() error {
+	resp, err := g.autoscaling.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(g.name)},
+	})
+	if err != nil {
+		return err
+	}
+	g.group = nil
+	g.unclaimed = nil
+	for _, group := range resp.AutoScalingGroups {
+		if group.Status != nil && strings.HasPrefix(*group.Status, "Delete") {
+			continue
+		}
+		g.group = group
+	}
+	if g.group == nil {
+		log.Debug("Didn't find autoscaling group %s, skipping...", g.name)
+		return nil
+	}
+
+	ids := []*string{}
+	for _, i := range g.group.Instances {
+		if aws.StringValue(i.LifecycleState) == "InService" {
+			ids = append(ids, i.InstanceId)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// Instances without the name of one of the pod's instances are unclaimed.
+	names := map[string]bool{}
+	for _, i := range g.instances {
+		names[i.Name()] = true
+	}
+	var token *string
+	for done := false; !done; {
+		resp, err := g.ec2.DescribeInstances(&ec2.DescribeInstancesInput{
+			InstanceIds: ids,
+			NextToken:   token,
+		})
+		if err != nil {
+			return err
+		}
+		for _, res := range resp.Reservations {
+			for _, inst := range res.Instances {
+				if !names[instanceTag(inst, "Name")] {
+					g.unclaimed = append(g.unclaimed, inst)
+				}
+			}
+		}
+		token = resp.NextToken
+		done = token == nil
+	}
+	log.Debug("AutoScaling group %s, %d instances, %d unclaimed", g.name, len(ids), len(g.unclaimed))
+	return nil
+}
+
+// claim returns an unclaimed instance from the group, preferring one in the
+// given subnet. It returns nil if there aren't any unclaimed instances.
+func (g *autoScalingGroup) claim(s *subnet) *ec2.Instance {
+	if len(g.unclaimed) == 0 {
+		return nil
+	}
+	n := 0
+	for k, inst := range g.unclaimed {
+		if aws.StringValue(inst.SubnetId) == s.Id() {
+			n = k
+			break
+		}
+	}
+	inst := g.unclaimed[n]
+	g.unclaimed = append(g.unclaimed[:n], g.unclaimed[n+1:]...)
+	return inst
+}
+
+// terminate terminates an instance of the group. The desired capacity is
+// decremented unless the group is at its minimum, in which case the group
+// replaces the instance.
+func (g *autoScalingGroup) terminate(id string) error {
+	decrement := g.group != nil && aws.Int64Value(g.group.DesiredCapacity) > aws.Int64Value(g.group.MinSize)
+	_, err := g.autoscaling.TerminateInstanceInAutoScalingGroup(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String(id),
+		ShouldDecrementDesiredCapacity: aws.Bool(decrement),
+	})
+	if err == nil && decrement {
+		g.group.DesiredCapacity = aws.Int64(aws.Int64Value(g.group.DesiredCapacity) - 1)
+	}
+	return err
+}
+
+func (g *autoScalingGroup) inService() int {
+	n := 0
+	if g.group != nil {
+		for _, i := range g.group.Instances {
+			if aws.StringValue(i.LifecycleState) == "InService" {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func (g *autoScalingGroup) create(req *route.Request) route.Response {
+	if g.Created() {
+		return route.OK
+	}
+	if len(g.instances) == 0 {
+		msg.Error("AutoScaling group %s has no instances", g.name)
+		return route.FAIL
+	}
+	msg.Info("AutoScaling Group Create: %s", g.name)
+
+	if err := g.createLaunchTemplate(); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+
+	// Spread the group across the subnets of the pod's instances.
+	subnets, seen := []string{}, map[string]bool{}
+	for _, i := range g.instances {
+		if !seen[i.subnet.Id()] {
+			seen[i.subnet.Id()] = true
+			subnets = append(subnets, i.subnet.Id())
+		}
+	}
+
+	params := &autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(g.name),
+		LaunchTemplate: &autoscaling.LaunchTemplateSpecification{
+			LaunchTemplateName: aws.String(g.name),
+			Version:            aws.String("$Latest"),
+		},
+		MinSize:           aws.Int64(int64(g.Min())),
+		MaxSize:           aws.Int64(int64(g.Max())),
+		DesiredCapacity:   aws.Int64(int64(g.Desired())),
+		VPCZoneIdentifier: aws.String(strings.Join(subnets, ",")),
+		Tags: []*autoscaling.Tag{
+			{
+				Key:               aws.String("DataCenter"),
+				Value:             aws.String(req.DataCenter()),
+				PropagateAtLaunch: aws.Bool(true),
+			},
+			{
+				Key:               aws.String("Pod"),
+				Value:             aws.String(g.pod.Name()),
+				PropagateAtLaunch: aws.Bool(true),
+			},
+		},
+	}
+	if g.pod.Placement().Group() != "" {
+		params.PlacementGroup = aws.String(placementGroupName(g.dataCenter, g.pod.Placement()))
+	}
+	// The group registers the instances it launches with the pod's load balancer.
+	if lb, ok := g.parent.ProviderLoadBalancer().(*loadBalancer); ok && lb != nil {
+		params.TargetGroupARNs = lb.targetGroupArns()
+	}
+	if _, err := g.autoscaling.CreateAutoScalingGroup(params); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+
+	for _, p := range g.Policies() {
+		msg.Detail("Scaling policy: %s", p.Name())
+		if _, err := g.autoscaling.PutScalingPolicy(&autoscaling.PutScalingPolicyInput{
+			AutoScalingGroupName: aws.String(g.name),
+			PolicyName:           aws.String(p.Name()),
+			PolicyType:           aws.String("TargetTrackingScaling"),
+			TargetTrackingConfiguration: &autoscaling.TargetTrackingConfiguration{
+				PredefinedMetricSpecification: &autoscaling.PredefinedMetricSpecification{
+					PredefinedMetricType: aws.String(scalingMetricTypes[p.Metric()]),
+				},
+				TargetValue: aws.Float64(p.Target()),
+			},
+		}); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+	}
+
+	if !msg.Wait(
+		fmt.Sprintf("Waiting for AutoScaling Group %s to launch %d instances", g.name, g.Desired()), // title
+		fmt.Sprintf("AutoScaling Group %s failed to launch %d instances", g.name, g.Desired()),      // err
+		300, // duration
+		func() bool { return g.Created() && g.inService() >= g.Desired() }, // test()
+		func() bool {
+			if err := g.load(); err != nil {
+				msg.Error(err.Error())
+				return false
+			}
+			return true
+		},
+	) {
+		return route.FAIL
+	}
+	msg.Detail("Created: %s", g.name)
+	aaa.Accounting("AutoScaling group created: %s", g.name)
+	return route.OK
+}
+
+// createLaunchTemplate creates the launch template from the first of the
+// pod's instances, since they all share the pod's configuration.
+func (g *autoScalingGroup) createLaunchTemplate() error {
+	i := g.instances[0]
+
+	securityGroupIds := []*string{}
+	for _, sg := range i.secgroups {
+		securityGroupIds = append(securityGroupIds, aws.String(sg.Id()))
+	}
+	volumes := []*ec2.LaunchTemplateBlockDeviceMappingRequest{}
+	for _, v := range i.volumes {
+		if err := v.encrypt(); err != nil {
+			return err
+		}
+		b := v.volumeParams
+		volumes = append(volumes, &ec2.LaunchTemplateBlockDeviceMappingRequest{
+			DeviceName: b.DeviceName,
+			Ebs: &ec2.LaunchTemplateEbsBlockDeviceRequest{
+				DeleteOnTermination: b.Ebs.DeleteOnTermination,
+				Encrypted:           b.Ebs.Encrypted,
+				KmsKeyId:            b.Ebs.KmsKeyId,
+				VolumeSize:          b.Ebs.VolumeSize,
+				VolumeType:          b.Ebs.VolumeType,
+			},
+		})
+	}
+
+	data := &ec2.RequestLaunchTemplateData{
+		BlockDeviceMappings: volumes,
+		ImageId:             aws.String(i.ImageId()),
+		InstanceType:        aws.String(g.pod.InstanceType()),
+		KeyName:             aws.String(i.KeyName()),
+		SecurityGroupIds:    securityGroupIds,
+	}
+	if i.Instance.Role() != "" {
+		data.IamInstanceProfile = &ec2.LaunchTemplateIamInstanceProfileSpecificationRequest{
+			Arn: aws.String(newIamInstanceProfile(i.provider.number, i.Instance.Role()).String()),
+		}
+	}
+	if g.userData != nil {
+		userData, err := encodeUserData(g.name, g.userData)
+		if err != nil {
+			return err
+		}
+		data.UserData = aws.String(userData)
+	}
+	if g.pod.Purchasing().Spot() {
+		data.InstanceMarketOptions = &ec2.LaunchTemplateInstanceMarketOptionsRequest{
+			MarketType:  aws.String("spot"),
+			SpotOptions: &ec2.LaunchTemplateSpotMarketOptionsRequest{},
+		}
+		if g.pod.Purchasing().MaxPrice() != "" {
+			data.InstanceMarketOptions.SpotOptions.MaxPrice = aws.String(g.pod.Purchasing().MaxPrice())
+		}
+	}
+
+	_, err := g.ec2.CreateLaunchTemplate(&ec2.CreateLaunchTemplateInput{
+		LaunchTemplateName: aws.String(g.name),
+		LaunchTemplateData: data,
+	})
+	return err
+}
+
+func (g *autoScalingGroup) destroy(req *route.Request) route.Response {
+	if g.Destroyed() {
+		return route.OK
+	}
+	msg.Info("AutoScaling Group Destroy: %s", g.name)
+	if _, err := g.autoscaling.DeleteAutoScalingGroup(&autoscaling.DeleteAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(g.name),
+		ForceDelete:          aws.Bool(true),
+	}); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if _, err := g.ec2.DeleteLaunchTemplate(&ec2.DeleteLaunchTemplateInput{
+		LaunchTemplateName: aws.String(g.name),
+	}); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	g.group = nil
+	g.unclaimed = nil
+	msg.Detail("Destroyed: %s", g.name)
+	aaa.Accounting("AutoScaling group destroyed: %s", g.name)
+	return route.OK
+}
+
+// start resumes the health checks suspended when the pod was stopped.
+func (g *autoScalingGroup) start(req *route.Request) route.Response {
+	if g.Destroyed() {
+		return route.OK
+	}
+	msg.Detail("Resume health checks: %s", g.name)
+	if _, err := g.autoscaling.ResumeProcesses(&autoscaling.ScalingProcessQuery{
+		AutoScalingGroupName: aws.String(g.name),
+		ScalingProcesses:     aws.StringSlice(healthProcesses),
+	}); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	return route.OK
+}
+
+// stop suspends the health checks so the group doesn't replace stopped instances.
+func (g *autoScalingGroup) stop(req *route.Request) route.Response {
+	if g.Destroyed() {
+		return route.OK
+	}
+	msg.Detail("Suspend health checks: %s", g.name)
+	if _, err := g.autoscaling.SuspendProcesses(&autoscaling.ScalingProcessQuery{
+		AutoScalingGroupName: aws.String(g.name),
+		ScalingProcesses:     aws.StringSlice(healthProcesses),
+	}); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	return route.OK
+}
+
+func (g *autoScalingGroup) info() {
+	if g.Destroyed() {
+		return
+	}
+	msg.Info("AutoScaling Group")
+	msg.Detail("%-20s\t%s", "name", g.name)
+	msg.Detail("%-20s\t%d", "min", aws.Int64Value(g.group.MinSize))
+	msg.Detail("%-20s\t%d", "max", aws.Int64Value(g.group.MaxSize))
+	msg.Detail("%-20s\t%d", "desired", aws.Int64Value(g.group.DesiredCapacity))
+	msg.Detail("%-20s\t%d", "in service", g.inService())
+	if len(g.unclaimed) > 0 {
+		msg.Detail("%-20s\t%d", "unclaimed", len(g.unclaimed))
+	}
+	for _, p := range g.group.SuspendedProcesses {
+		msg.Detail("%-20s\t%s", "suspended", aws.StringValue(p.ProcessName))
+	}
+}
+
+func (g *autoScalingGroup) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil {
+		return fmt.Errorf("Audit Object does not exist")
+	}
+	if g.Destroyed() {
+		a.Audit(aaa.Configured, "AutoScaling Group %s", g.name)
+		return nil
+	}
+	if d := aws.Int64Value(g.group.MinSize); d != int64(g.Min()) {
+		a.Audit(aaa.Mismatched, "AutoScaling Group %q | Configured Min: %d - Deployed Min: %d", g.name, g.Min(), d)
+	}
+	if d := aws.Int64Value(g.group.MaxSize); d != int64(g.Max()) {
+		a.Audit(aaa.Mismatched, "AutoScaling Group %q | Configured Max: %d - Deployed Max: %d", g.name, g.Max(), d)
+	}
+	for _, inst := range g.unclaimed {
+		a.Audit(aaa.Deployed, "AutoScaling Group %s instance %s is unclaimed, create the pod to claim it", g.name, aws.StringValue(inst.InstanceId))
+	}
+	return nil
+}
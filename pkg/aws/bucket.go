@@ -30,6 +30,7 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
 
 	"github.com/cisco/arc/pkg/aaa"
@@ -90,6 +91,23 @@ func (b *bucket) SetTags(tags map[string]string) error {
 	return nil
 }
 
+// Tags satisfies the resource.ProviderBucket interface.
+func (b *bucket) Tags() (map[string]string, error) {
+	tags := map[string]string{}
+	resp, err := b.s3.GetBucketTagging(&s3.GetBucketTaggingInput{Bucket: aws.String(b.Name())})
+	if err != nil {
+		// A bucket without tags has no tag set.
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NoSuchTagSet" {
+			return tags, nil
+		}
+		return nil, err
+	}
+	for _, t := range resp.TagSet {
+		tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+	return tags, nil
+}
+
 func (b *bucket) enableVersioning() error {
 	log.Debug("Enabling Bucket Versioning")
 	params := &s3.PutBucketVersioningInput{
@@ -0,0 +1,135 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/cisco/arc/pkg/env"
+	"github.com/cisco/arc/pkg/log"
+)
+
+// loadConcurrently runs the cache loaders in parallel, waits for all of
+// them to complete and returns the first error encountered.
+func loadConcurrently(loaders ...func() error) error {
+	errs := make(chan error, len(loaders))
+	for _, load := range loaders {
+		go func(load func() error) {
+			errs <- load()
+		}(load)
+	}
+	var err error
+	for range loaders {
+		if e := <-errs; e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// The disk cache keeps a copy of the provider caches between runs so that
+// repeated read only commands can skip querying the provider. It is enabled
+// by setting the cache_ttl environment variable to the number of seconds a
+// copy remains valid.
+
+func diskCacheTTL() time.Duration {
+	ttl, err := strconv.Atoi(os.Getenv("cache_ttl"))
+	if err != nil || ttl <= 0 {
+		return 0
+	}
+	return time.Duration(ttl) * time.Second
+}
+
+func diskCachePath(name string) string {
+	dir := env.Lookup("CACHE")
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, name+".json")
+}
+
+// readDiskCache decodes the named disk cache into v. It returns false if the
+// disk cache is disabled, missing, expired or unreadable.
+func readDiskCache(name string, v interface{}) bool {
+	ttl := diskCacheTTL()
+	path := diskCachePath(name)
+	if ttl == 0 || path == "" {
+		return false
+	}
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > ttl {
+		return false
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		log.Warn("Ignoring disk cache %s: %s", path, err.Error())
+		return false
+	}
+	log.Debug("Using disk cache %s", path)
+	return true
+}
+
+// writeDiskCache saves v as the named disk cache. Failures are logged and
+// otherwise ignored since the disk cache is only an optimization.
+func writeDiskCache(name string, v interface{}) {
+	path := diskCachePath(name)
+	if diskCacheTTL() == 0 || path == "" {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Warn("Unable to encode disk cache %s: %s", path, err.Error())
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		log.Warn("Unable to create disk cache directory: %s", err.Error())
+		return
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		log.Warn("Unable to write disk cache %s: %s", path, err.Error())
+	}
+}
+
+// removeDiskCache discards the named disk cache. This is done whenever a
+// command may modify the resources held in the cache.
+func removeDiskCache(name string) {
+	path := diskCachePath(name)
+	if path == "" {
+		return
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Warn("Unable to remove disk cache %s: %s", path, err.Error())
+	}
+}
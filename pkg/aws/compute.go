@@ -29,7 +29,9 @@ package aws
 import (
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/kms"
 
 	"github.com/cisco/arc/pkg/aaa"
 	"github.com/cisco/arc/pkg/config"
@@ -40,34 +42,126 @@ import (
 type compute struct {
 	*config.Compute
 	ec2 *ec2.EC2
+	kms *kms.KMS
 
 	volumeCache   *volumeCache
 	instanceCache *instanceCache
+
+	number  string
+	target  string
+	targets map[string]*compute
+
+	// instances are the configured instances of the compute's target.
+	instances []*instance
+
+	// keyArns maps the names of encryption keys to their arns.
+	keyArns map[string]string
 }
 
 // newCompute constructs the aws compute.
-func newCompute(cfg *config.Compute, e *ec2.EC2) (*compute, error) {
+func newCompute(cfg *config.Compute, p *dataCenterProvider) (*compute, error) {
 	log.Debug("Initializing AWS Compute")
 	c := &compute{
 		Compute: cfg,
-		ec2:     e,
+		ec2:     p.ec2,
+		kms:     p.kms,
+		number:  p.number,
+		target:  p.target,
+		keyArns: map[string]string{},
 	}
 
-	var err error
-	c.volumeCache, err = newVolumeCache(c)
-	if err != nil {
-		return nil, err
+	c.volumeCache = newVolumeCache(c)
+	c.instanceCache = newInstanceCache(c)
+	c.targets = map[string]*compute{}
+
+	return c, nil
+}
+
+// forProvider returns the compute for the provider target that instances and
+// volumes are pinned to. Each target has its own instance and volume caches
+// since the resources are only visible through the target's account. Targets
+// in the compute's own account share its caches.
+func (c *compute) forProvider(p *dataCenterProvider) *compute {
+	if p.target == c.target || p.number == c.number {
+		return c
+	}
+	if t := c.targets[p.target]; t != nil {
+		return t
 	}
-	c.instanceCache, err = newInstanceCache(c)
+	log.Debug("Initializing AWS Compute target %q", p.target)
+	t := &compute{
+		Compute: c.Compute,
+		ec2:     p.ec2,
+		kms:     p.kms,
+		number:  p.number,
+		target:  p.target,
+		keyArns: map[string]string{},
+	}
+	t.volumeCache = newVolumeCache(t)
+	t.instanceCache = newInstanceCache(t)
+	c.targets[p.target] = t
+	return t
+}
+
+// keyArn returns the arn of the named encryption key. The key is managed by amp
+// and must be in the datacenter's region.
+func (c *compute) keyArn(name string) (string, error) {
+	if arn := c.keyArns[name]; arn != "" {
+		return arn, nil
+	}
+	resp, err := c.kms.DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String("alias/" + name),
+	})
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("Unable to find encryption key %q: %s", name, err.Error())
 	}
+	arn := aws.StringValue(resp.KeyMetadata.Arn)
+	c.keyArns[name] = arn
+	return arn, nil
+}
 
-	return c, nil
+// all returns the compute along with the computes of its provider targets.
+func (c *compute) all() []*compute {
+	computes := []*compute{c}
+	for _, t := range c.targets {
+		computes = append(computes, t)
+	}
+	return computes
+}
+
+// cacheName returns the name of the on disk cache of the given kind.
+func (c *compute) cacheName(kind string) string {
+	if c.target != "" {
+		return c.Name() + "-" + c.target + "-" + kind
+	}
+	return c.Name() + "-" + kind
+}
+
+// LoadCache satisfies the resource.ProviderCompute interface. The instance
+// and volume caches are loaded concurrently. The volume cache is skipped
+// when the request is limited to the named instances, in which case the
+// volumes are loaded individually along with their instance.
+//
+// The caches of all the provider targets are loaded along with the compute's.
+func (c *compute) LoadCache(names []string, readOnly bool) error {
+	loaders := []func() error{}
+	for _, t := range c.all() {
+		t := t
+		loaders = append(loaders, func() error { return t.instanceCache.load(names, readOnly) })
+		if len(names) == 0 {
+			loaders = append(loaders, func() error { return t.volumeCache.load(readOnly) })
+		}
+	}
+	return loadConcurrently(loaders...)
 }
 
 func (c *compute) AuditVolumes(flags ...string) error {
-	return c.volumeCache.audit(flags...)
+	for _, t := range c.all() {
+		if err := t.volumeCache.audit(flags...); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (c *compute) AuditEIP(flags ...string) error {
@@ -83,18 +177,25 @@ func (c *compute) AuditEIP(flags ...string) error {
 		return fmt.Errorf("Audit Object does not exist")
 	}
 	params := &ec2.DescribeAddressesInput{}
-	addr, err := c.ec2.DescribeAddresses(params)
-	if err != nil {
-		return err
-	}
-	for _, v := range addr.Addresses {
-		if v.AssociationId == nil {
-			a.Audit(aaa.Deployed, "Elastic IP %q is not associated with anything", *v.PublicIp)
+	for _, t := range c.all() {
+		addr, err := t.ec2.DescribeAddresses(params)
+		if err != nil {
+			return err
+		}
+		for _, v := range addr.Addresses {
+			if v.AssociationId == nil {
+				a.Audit(aaa.Deployed, "Elastic IP %q is not associated with anything", *v.PublicIp)
+			}
 		}
 	}
 	return nil
 }
 
 func (c *compute) AuditInstances(flags ...string) error {
-	return c.instanceCache.audit(flags...)
+	for _, t := range c.all() {
+		if err := t.instanceCache.audit(flags...); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -45,13 +45,7 @@ func newDatabaseService(cfg *config.DatabaseService, p *databaseServiceProvider)
 }
 
 func (dbs *databaseService) Load() error {
-	if err := dbs.databaseCache.load(); err != nil {
-		return err
-	}
-	if err := dbs.dbSubnetGroupCache.load(); err != nil {
-		return err
-	}
-	return nil
+	return loadConcurrently(dbs.databaseCache.load, dbs.dbSubnetGroupCache.load)
 }
 
 func (dbs *databaseService) Audit(flags ...string) error {
@@ -29,8 +29,6 @@ package aws
 import (
 	"fmt"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/rds"
 
 	"github.com/cisco/arc/pkg/config"
@@ -61,16 +59,7 @@ func newDatabaseServiceProvider(cfg *config.DatabaseService) (provider.DatabaseS
 		return nil, fmt.Errorf("AWS DatabaseService provider/data config requires a 'region' field, being the aws region.")
 	}
 
-	opts := session.Options{
-		Config: aws.Config{
-			CredentialsChainVerboseErrors: aws.Bool(true),
-			Region: aws.String(region),
-		},
-		Profile:           account,
-		SharedConfigState: session.SharedConfigEnable,
-	}
-
-	sess, err := session.NewSessionWithOptions(opts)
+	sess, err := newSession(account, region, cfg.Provider.Data["role"])
 	if err != nil {
 		return nil, err
 	}
@@ -28,10 +28,12 @@ package aws
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/kms"
 
 	"github.com/cisco/arc/pkg/config"
 	"github.com/cisco/arc/pkg/log"
@@ -40,76 +42,163 @@ import (
 )
 
 type dataCenterProvider struct {
-	ec2    *ec2.EC2
-	name   string
-	number string
-	region string
-	images map[string]string
+	ec2         *ec2.EC2
+	autoscaling *autoscaling.AutoScaling
+	elbv2       *elbv2.ELBV2
+	kms         *kms.KMS
+	name        string
+	number      string
+	region      string
+	images      map[string]string
+
+	cfg     *config.Provider
+	target  string
+	targets map[string]*dataCenterProvider
+	home    string
+	homeKey string
 }
 
 func NewDataCenterProvider(cfg *config.DataCenter) (provider.DataCenter, error) {
 	log.Debug("Initializing AWS Datacenter Provider")
+	return newDataCenterProvider(cfg.Provider, "")
+}
 
-	name := cfg.Provider.Data["account"]
+func newDataCenterProvider(cfg *config.Provider, target string) (*dataCenterProvider, error) {
+	name := cfg.Data["account"]
 	if name == "" {
 		return nil, fmt.Errorf("AWS DataCenter provider/data config requires an 'account' field, being the aws account name.")
 	}
-	region := cfg.Provider.Data["region"]
+	region := cfg.Data["region"]
 	if region == "" {
 		return nil, fmt.Errorf("AWS DataCenter provider/data config requires a 'region' field, being the aws region.")
 	}
-	number := cfg.Provider.Data["number"]
+	number := cfg.Data["number"]
 	if number == "" {
 		return nil, fmt.Errorf("AWS DataCenter provider/data config requires a 'number' field, being the aws account number.")
 	}
 
-	opts := session.Options{
-		Config: aws.Config{
-			CredentialsChainVerboseErrors: aws.Bool(true),
-			Region: aws.String(region),
-		},
-		Profile:           name,
-		SharedConfigState: session.SharedConfigEnable,
-	}
-
-	sess, err := session.NewSessionWithOptions(opts)
+	sess, err := newSession(name, region, cfg.Data["role"])
 	if err != nil {
 		return nil, err
 	}
 
 	return &dataCenterProvider{
-		ec2:    ec2.New(sess),
-		name:   name,
-		number: number,
-		region: region,
-		images: cfg.Provider.Images,
+		ec2:         ec2.New(sess),
+		autoscaling: autoscaling.New(sess),
+		elbv2:       elbv2.New(sess),
+		kms:         kms.New(sess),
+		name:        name,
+		number:      number,
+		region:      region,
+		images:      cfg.Images,
+		cfg:         cfg,
+		target:      target,
+		targets:     map[string]*dataCenterProvider{},
 	}, nil
 }
 
+// Target satisfies the provider.DataCenter interface. Target providers are
+// created once and shared by all the resources pinned to them. A target may be
+// in another account or region than the datacenter, in which case the resources
+// pinned to it live in a network of their own, see network.forProvider.
+func (p *dataCenterProvider) Target(name string) (provider.DataCenter, error) {
+	return p.targetProvider(name)
+}
+
+func (p *dataCenterProvider) targetProvider(name string) (*dataCenterProvider, error) {
+	if name == "" || name == p.target {
+		return p, nil
+	}
+	if t := p.targets[name]; t != nil {
+		return t, nil
+	}
+	cfg, err := p.cfg.Target(name)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Initializing AWS Datacenter Provider target %q", name)
+	t, err := newDataCenterProvider(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	t.home = p.region
+	t.homeKey = networkKey(p)
+	p.targets[name] = t
+	return t, nil
+}
+
+// AvailabilityZones satisfies the provider.DataCenter interface. The zones are
+// configured for the datacenter's region. A target in another region uses the
+// zones given by its comma separated "availability_zones" data, or else the
+// same zones moved to its region.
+func (p *dataCenterProvider) AvailabilityZones(zones []string) []string {
+	if z := p.cfg.Data["availability_zones"]; z != "" && p.home != "" {
+		result := []string{}
+		for _, zone := range strings.Split(z, ",") {
+			result = append(result, strings.TrimSpace(zone))
+		}
+		return result
+	}
+	if p.home == "" || p.home == p.region {
+		return zones
+	}
+	result := []string{}
+	for _, zone := range zones {
+		result = append(result, p.region+strings.TrimPrefix(zone, p.home))
+	}
+	return result
+}
+
+// CidrBlock satisfies the provider.DataCenter interface. A target in another
+// account or region has a network of its own, whose cidr block is given by its
+// "cidr_block" data. Other targets share the datacenter's network.
+func (p *dataCenterProvider) CidrBlock(cidrBlock string) string {
+	if p.home == "" || networkKey(p) == p.homeKey {
+		return cidrBlock
+	}
+	return p.cfg.Data["cidr_block"]
+}
+
 func (p *dataCenterProvider) NewNetwork(cfg *config.Network) (resource.ProviderNetwork, error) {
-	return newNetwork(cfg, p.ec2)
+	return newNetwork(cfg, p)
 }
 
 func (p *dataCenterProvider) NewSubnet(net resource.Network, cfg *config.Subnet) (resource.ProviderSubnet, error) {
-	return newSubnet(net, cfg, p.ec2)
+	return newSubnet(net, cfg, p)
 }
 
 func (p *dataCenterProvider) NewSecurityGroup(net resource.Network, cfg *config.SecurityGroup) (resource.ProviderSecurityGroup, error) {
-	return newSecurityGroup(net, cfg, p.ec2)
+	return newSecurityGroup(net, cfg, p)
 }
 
 func (p *dataCenterProvider) NewNetworkPost(net resource.Network, cfg *config.Network) (resource.ProviderNetworkPost, error) {
-	return newNetworkPost(net, cfg, p.ec2)
+	return newNetworkPost(net, cfg, p)
+}
+
+func (p *dataCenterProvider) NewEndpoints(net resource.Network, cfg *config.Network) (resource.ProviderEndpoints, error) {
+	return newEndpoints(net, cfg, p)
 }
 
 func (p *dataCenterProvider) NewCompute(cfg *config.Compute) (resource.ProviderCompute, error) {
-	return newCompute(cfg, p.ec2)
+	return newCompute(cfg, p)
 }
 
 func (p *dataCenterProvider) NewKeyPair(cfg *config.KeyPair) (resource.ProviderKeyPair, error) {
 	return newKeyPair(cfg, p.ec2)
 }
 
+func (p *dataCenterProvider) NewAutoScalingGroup(pod resource.Pod, cfg *config.Pod) (resource.ProviderAutoScalingGroup, error) {
+	return newAutoScalingGroup(pod, cfg, p)
+}
+
+func (p *dataCenterProvider) NewPlacementGroup(pod resource.Pod, cfg *config.Pod) (resource.ProviderPlacementGroup, error) {
+	return newPlacementGroup(pod, cfg, p)
+}
+
+func (p *dataCenterProvider) NewLoadBalancer(pod resource.Pod, cfg *config.Pod) (resource.ProviderLoadBalancer, error) {
+	return newLoadBalancer(pod, cfg, p)
+}
+
 func (p *dataCenterProvider) NewInstance(i resource.Instance, cfg *config.Instance) (resource.ProviderInstance, error) {
 	return newInstance(i, cfg, p)
 }
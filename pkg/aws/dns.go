@@ -80,14 +80,19 @@ func newDns(cfg *config.Dns, p *dnsProvider) (resource.ProviderDns, error) {
 	log.Info("AWS Dns Hosted Zone ID: %s", hostedZoneId)
 	d.id = hostedZoneId
 
-	d.cache, err = newDnsCache(d)
-	if err != nil {
-		return nil, err
-	}
+	d.cache = newDnsCache(d)
 
 	return d, nil
 }
 
+// LoadCache satisfies the resource.ProviderDns interface.
+func (d *dns) LoadCache(names []string, readOnly bool) error {
+	if len(names) > 0 {
+		return d.cache.loadNames(names)
+	}
+	return d.cache.load(readOnly)
+}
+
 func (d *dns) Id() string {
 	return d.id
 }
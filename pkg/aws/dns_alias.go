@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+// s3WebsiteEndpoint is the hosted zone id and dns name of the s3 website
+// endpoint of a region.
+type s3WebsiteEndpoint struct {
+	hostedZoneId string
+	dnsName      string
+}
+
+// s3WebsiteEndpoints maps a region to its s3 website endpoint. Alias records
+// pointing at a bucket resolve to the endpoint of the bucket's region.
+var s3WebsiteEndpoints = map[string]s3WebsiteEndpoint{
+	"us-east-1":      {"Z3AQBSTGFYJSTF", "s3-website-us-east-1.amazonaws.com"},
+	"us-east-2":      {"Z2O1EMRO9K5GLX", "s3-website.us-east-2.amazonaws.com"},
+	"us-west-1":      {"Z2F56UZL2M1ACD", "s3-website-us-west-1.amazonaws.com"},
+	"us-west-2":      {"Z3BJ6K6RIION7M", "s3-website-us-west-2.amazonaws.com"},
+	"ca-central-1":   {"Z1QDHH18159H29", "s3-website.ca-central-1.amazonaws.com"},
+	"eu-west-1":      {"Z1BKCTXD74EZPE", "s3-website-eu-west-1.amazonaws.com"},
+	"eu-west-2":      {"Z3GKZC51ZF0DB4", "s3-website.eu-west-2.amazonaws.com"},
+	"eu-west-3":      {"Z3R1K369G5AVDG", "s3-website.eu-west-3.amazonaws.com"},
+	"eu-central-1":   {"Z21DNDUVLTQW6Q", "s3-website.eu-central-1.amazonaws.com"},
+	"ap-south-1":     {"Z11RGJOFQNVJUP", "s3-website.ap-south-1.amazonaws.com"},
+	"ap-northeast-1": {"Z2M4EHUR26P7ZW", "s3-website-ap-northeast-1.amazonaws.com"},
+	"ap-northeast-2": {"Z3W03O7B5YMIYP", "s3-website.ap-northeast-2.amazonaws.com"},
+	"ap-southeast-1": {"Z3O0J2DXBE1FTB", "s3-website-ap-southeast-1.amazonaws.com"},
+	"ap-southeast-2": {"Z1WCIGYICN2BYD", "s3-website-ap-southeast-2.amazonaws.com"},
+	"sa-east-1":      {"Z7KQH4QJS55SO", "s3-website-sa-east-1.amazonaws.com"},
+}
+
+// aliasTarget returns the route53 alias target of an alias record. The target of
+// a pod's load balancer is set by the record before creation, while a bucket
+// target is derived from the bucket's region.
+func (r *dnsRecord) aliasTarget() (*route53.AliasTarget, error) {
+	if t := r.AliasTarget(); t != nil {
+		return &route53.AliasTarget{
+			DNSName:              aws.String(t.DnsName()),
+			HostedZoneId:         aws.String(t.HostedZoneId()),
+			EvaluateTargetHealth: aws.Bool(false),
+		}, nil
+	}
+	if r.Bucket() == "" {
+		return nil, fmt.Errorf("Dns alias record %s has no target", r.Name())
+	}
+
+	// S3 only serves a website bucket under a name matching the bucket's.
+	if r.Bucket() != strings.TrimSuffix(r.Id(), ".") {
+		return nil, fmt.Errorf("Dns alias record %s must be named the same as bucket %s", r.Id(), r.Bucket())
+	}
+	region := r.Region()
+	if region == "" {
+		region = r.dns.Provider.Data["region"]
+	}
+	e, ok := s3WebsiteEndpoints[region]
+	if !ok {
+		return nil, fmt.Errorf("Unknown s3 website endpoint for region %s of bucket %s", region, r.Bucket())
+	}
+	return &route53.AliasTarget{
+		DNSName:              aws.String(e.dnsName),
+		HostedZoneId:         aws.String(e.hostedZoneId),
+		EvaluateTargetHealth: aws.Bool(false),
+	}, nil
+}
+
+// aliasName normalizes the dns name of an alias target so the configured and
+// deployed names can be compared.
+func aliasName(name string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	return strings.TrimPrefix(name, "dualstack.")
+}
+
+// txtValue quotes the value of a txt record, unless it is already quoted.
+func txtValue(value string) string {
+	if len(value) > 1 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		return value
+	}
+	value = strings.Replace(value, "\\", "\\\\", -1)
+	return "\"" + strings.Replace(value, "\"", "\\\"", -1) + "\""
+}
@@ -43,16 +43,38 @@ type dnsCacheEntry struct {
 }
 
 type dnsCache struct {
+	dns     *dns
+	loaded  bool
 	cache   map[string]*dnsCacheEntry
 	unnamed []*route53.ResourceRecordSet
 }
 
-func newDnsCache(d *dns) (*dnsCache, error) {
+func newDnsCache(d *dns) *dnsCache {
 	log.Debug("Initializing AWS DNS Cache")
-
-	c := &dnsCache{
+	return &dnsCache{
+		dns:   d,
 		cache: map[string]*dnsCacheEntry{},
 	}
+}
+
+// load populates the dns cache with the records in the hosted zone. When
+// readOnly is set a recent copy of the cache saved to disk may be used.
+func (c *dnsCache) load(readOnly bool) error {
+	if c.loaded {
+		log.Debug("Skipping dns cache load, loaded...")
+		return nil
+	}
+	log.Debug("Loading AWS DNS Cache")
+
+	d := c.dns
+	diskCache := d.Domain() + "-dns"
+	if !readOnly {
+		removeDiskCache(diskCache)
+	}
+	if readOnly && c.readDiskCache(diskCache) {
+		c.loaded = true
+		return nil
+	}
 
 	next := ""
 	for {
@@ -65,7 +87,7 @@ func newDnsCache(d *dns) (*dnsCache, error) {
 
 		resp, err := d.route53.ListResourceRecordSets(params)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		truncated := false
@@ -80,47 +102,123 @@ func newDnsCache(d *dns) (*dnsCache, error) {
 		log.Debug("Load DnsRecords: truncated: %t, next record name: %s ", truncated, next)
 
 		for _, r := range resp.ResourceRecordSets {
-			if r.Type == nil {
-				if r.Name == nil || len(*r.Name) == 0 {
-					c.unnamed = append(c.unnamed, r)
-					continue
-				}
-				log.Verbose("Skipping %+v", r)
-				continue
-			}
-			name := strings.Replace(*r.Name, "\\052", "*", -1)
-			if name[len(name)-1:] != "." {
-				name += "."
-			}
-			if !strings.HasSuffix(name, d.Domain()+".") {
-				continue
-			}
-			switch *r.Type {
-			case "A", "CNAME":
-				found := false
-				for _, v := range d.CacheIgnore {
-					if strings.HasPrefix(*r.Name, v) {
-						found = true
-						break
-					}
-				}
-				if found {
-					continue
-				}
-				log.Debug("Caching %s", name)
-				c.cache[name] = &dnsCacheEntry{deployed: r}
-			}
+			c.add(r)
 		}
 		if truncated == false {
 			break
 		}
 	}
+	c.loaded = true
 
-	return c, nil
+	if readOnly {
+		c.writeDiskCache(diskCache)
+	}
+	return nil
+}
+
+// loadNames populates the dns cache with the records with the given names, which
+// are relative to the domain. The disk cache isn't used for a partial load.
+func (c *dnsCache) loadNames(names []string) error {
+	if c.loaded {
+		log.Debug("Skipping dns cache load, loaded...")
+		return nil
+	}
+	log.Debug("Loading AWS DNS Cache for %q", names)
+
+	d := c.dns
+	for _, n := range names {
+		fqdn := n + "." + d.Domain() + "."
+		if n == "@" {
+			fqdn = d.Domain() + "."
+		}
+		params := &route53.ListResourceRecordSetsInput{
+			HostedZoneId:    aws.String(d.Id()),
+			StartRecordName: aws.String(fqdn),
+			MaxItems:        aws.String("10"),
+		}
+		resp, err := d.route53.ListResourceRecordSets(params)
+		if err != nil {
+			return err
+		}
+		// The records of a name are listed together, stop at the next name.
+		for _, r := range resp.ResourceRecordSets {
+			if aws.StringValue(r.Name) != fqdn {
+				break
+			}
+			c.add(r)
+		}
+	}
+	c.loaded = true
+	return nil
+}
+
+// add caches a record set from the hosted zone.
+func (c *dnsCache) add(r *route53.ResourceRecordSet) {
+	d := c.dns
+	if r.Type == nil {
+		if r.Name == nil || len(*r.Name) == 0 {
+			c.unnamed = append(c.unnamed, r)
+			return
+		}
+		log.Verbose("Skipping %+v", r)
+		return
+	}
+	name := strings.Replace(*r.Name, "\\052", "*", -1)
+	if name[len(name)-1:] != "." {
+		name += "."
+	}
+	if !strings.HasSuffix(name, d.Domain()+".") {
+		return
+	}
+	switch *r.Type {
+	case "A", "AAAA", "CNAME", "TXT", "MX", "SRV", "CAA":
+		for _, v := range d.CacheIgnore {
+			if strings.HasPrefix(*r.Name, v) {
+				return
+			}
+		}
+		log.Debug("Caching %s %s", *r.Type, name)
+		c.cache[dnsCacheKey(name, *r.Type)] = &dnsCacheEntry{deployed: r}
+	}
+}
+
+func (c *dnsCache) readDiskCache(name string) bool {
+	records := map[string]*route53.ResourceRecordSet{}
+	if !readDiskCache(name, &records) {
+		return false
+	}
+	for k, v := range records {
+		c.cache[k] = &dnsCacheEntry{deployed: v}
+	}
+	return true
+}
+
+func (c *dnsCache) writeDiskCache(name string) {
+	records := map[string]*route53.ResourceRecordSet{}
+	for k, v := range c.cache {
+		records[k] = v.deployed
+	}
+	writeDiskCache(name, records)
+}
+
+// dnsExclusiveType returns true for the A and CNAME record types, which can't share
+// a name with any other record.
+func dnsExclusiveType(recordType string) bool {
+	return recordType == "A" || recordType == "CNAME"
+}
+
+// dnsCacheKey returns the key of the cached record. A and CNAME records can't share
+// a name so they are cached by name, while AAAA records share the name of the
+// instance's A record and txt, mx, srv and caa records may share any name.
+func dnsCacheKey(name, recordType string) string {
+	if !dnsExclusiveType(recordType) {
+		return name + " " + recordType
+	}
+	return name
 }
 
 func (c *dnsCache) find(d *dnsRecord) *route53.ResourceRecordSet {
-	e := c.cache[d.Id()]
+	e := c.cache[dnsCacheKey(d.Id(), d.rrsetType())]
 	if e == nil {
 		return nil
 	}
@@ -130,7 +228,7 @@ func (c *dnsCache) find(d *dnsRecord) *route53.ResourceRecordSet {
 
 func (c *dnsCache) remove(d *dnsRecord) {
 	log.Debug("Deleting %s from dnsCache", d.Id())
-	delete(c.cache, d.Id())
+	delete(c.cache, dnsCacheKey(d.Id(), d.rrsetType()))
 }
 
 func (c *dnsCache) audit(flags ...string) error {
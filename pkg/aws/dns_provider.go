@@ -29,8 +29,6 @@ package aws
 import (
 	"fmt"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/route53"
 
 	"github.com/cisco/arc/pkg/config"
@@ -55,16 +53,7 @@ func NewDnsProvider(cfg *config.Dns) (provider.Dns, error) {
 		return nil, fmt.Errorf("AWS DNS provider/data config requires a 'region' field, being the aws region.")
 	}
 
-	opts := session.Options{
-		Config: aws.Config{
-			CredentialsChainVerboseErrors: aws.Bool(true),
-			Region: aws.String(region),
-		},
-		Profile:           name,
-		SharedConfigState: session.SharedConfigEnable,
-	}
-
-	sess, err := session.NewSessionWithOptions(opts)
+	sess, err := newSession(name, region, cfg.Provider.Data["role"])
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +48,7 @@ type dnsRecord struct {
 	record  resource.DnsRecord
 	id      string
 	rrset   *route53.ResourceRecordSet
+	loaded  bool
 }
 
 // newDnsRecord constructs the aws dnsRecord.
@@ -60,7 +61,11 @@ func newDnsRecord(rec resource.DnsRecord, cfg *config.DnsRecord, p *dnsProvider)
 		return nil, fmt.Errorf("AWS newDnsRecord: Unable to obtain prov dns associated with record %s", cfg.Name())
 	}
 
+	// A record named "@" is at the apex of the domain.
 	fqdn := cfg.Name() + "." + dns.Domain() + "."
+	if cfg.Name() == "@" {
+		fqdn = dns.Domain() + "."
+	}
 
 	r := &dnsRecord{
 		DnsRecord: cfg,
@@ -69,7 +74,6 @@ func newDnsRecord(rec resource.DnsRecord, cfg *config.DnsRecord, p *dnsProvider)
 		record:    rec,
 		id:        fqdn,
 	}
-	r.set(r.dns.cache.find(r))
 	return r, nil
 }
 
@@ -106,8 +110,21 @@ func (r *dnsRecord) Audit(flags ...string) error {
 	if r.rrset == nil {
 		return nil
 	}
-	if r.rrset.Type != nil && *r.rrset.Type != r.Type() {
-		a.Audit(aaa.Mismatched, "Dns Record %q | Configured: %q - Deployed: %q", r.Name(), r.Type(), *r.rrset.Type)
+	if r.rrset.Type != nil && *r.rrset.Type != r.rrsetType() {
+		a.Audit(aaa.Mismatched, "Dns Record %q | Configured: %q - Deployed: %q", r.Name(), r.rrsetType(), *r.rrset.Type)
+	}
+	if r.Type() == "ALIAS" {
+		if r.rrset.AliasTarget == nil {
+			a.Audit(aaa.Mismatched, "Dns Record %q | Configured: alias - Deployed: not an alias", r.Name())
+			return nil
+		}
+		if t, err := r.aliasTarget(); err == nil && aliasName(*t.DNSName) != aliasName(aws.StringValue(r.rrset.AliasTarget.DNSName)) {
+			a.Audit(aaa.Mismatched, "Dns Record %q | Configured: %q - Deployed: %q", r.Name(), *t.DNSName, aws.StringValue(r.rrset.AliasTarget.DNSName))
+		}
+		return nil
+	}
+	if r.rrset.AliasTarget != nil {
+		a.Audit(aaa.Mismatched, "Dns Record %q | Configured: %s - Deployed: alias", r.Name(), r.Type())
 	}
 	if r.rrset.TTL != nil && *r.rrset.TTL != int64(r.Ttl()) {
 		a.Audit(aaa.Mismatched, "Dns Record %q | Configured: \"%d\" - Deployed: \"%d\"", r.Name(), r.Ttl(), *r.rrset.TTL)
@@ -135,6 +152,23 @@ func (r *dnsRecord) Type() string {
 	return r.record.Type()
 }
 
+// rrsetType returns the route53 type of the record. Alias records are A records
+// with an alias target.
+func (r *dnsRecord) rrsetType() string {
+	if r.Type() == "ALIAS" {
+		return "A"
+	}
+	return r.Type()
+}
+
+// rrsetValue returns the route53 value of a configured value.
+func (r *dnsRecord) rrsetValue(value string) string {
+	if r.Type() == "TXT" {
+		return txtValue(value)
+	}
+	return value
+}
+
 func (r *dnsRecord) rrType() string {
 	if r.rrset == nil || r.rrset.Type == nil {
 		return ""
@@ -224,12 +258,22 @@ func (r *dnsRecord) Load() error {
 		return nil
 	}
 
+	// Use the dns cache on the first load, if it has been loaded. Later loads
+	// need the current value from AWS.
+	if !r.loaded {
+		r.loaded = true
+		if rrset := r.dns.cache.find(r); rrset != nil {
+			r.set(rrset)
+			return nil
+		}
+	}
+
 	// Ask AWS for the record
 	params := &route53.ListResourceRecordSetsInput{
 		HostedZoneId:    aws.String(r.dns.Id()),
 		MaxItems:        aws.String("1"),
 		StartRecordName: aws.String(r.Id()),
-		StartRecordType: aws.String(r.Type()),
+		StartRecordType: aws.String(r.rrsetType()),
 	}
 	resp, err := r.route53.ListResourceRecordSets(params)
 	if err != nil {
@@ -253,6 +297,10 @@ func (r *dnsRecord) Load() error {
 	if rrset.Name != nil && *rrset.Name != r.Id() {
 		return nil
 	}
+	// Records such as an instance's A and AAAA records share a name, don't mistake one for the other.
+	if rrset.Type != nil && *rrset.Type != r.rrsetType() && !(dnsExclusiveType(*rrset.Type) && dnsExclusiveType(r.rrsetType())) {
+		return nil
+	}
 
 	r.set(rrset)
 	return nil
@@ -324,21 +372,33 @@ func (r *dnsRecord) create(req *route.Request) route.Response {
 		}
 	}
 
-	resourceRecords := []*route53.ResourceRecord{}
-	for _, value := range r.Values() {
-		resourceRecords = append(resourceRecords, &route53.ResourceRecord{Value: aws.String(value)})
+	rrset := &route53.ResourceRecordSet{
+		Name: aws.String(r.Id()),
+		Type: aws.String(r.rrsetType()),
+	}
+	if r.Type() == "ALIAS" {
+		// Alias records take the ttl of their target.
+		target, err := r.aliasTarget()
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		msg.Detail("Alias target: %s", *target.DNSName)
+		rrset.AliasTarget = target
+	} else {
+		resourceRecords := []*route53.ResourceRecord{}
+		for _, value := range r.Values() {
+			resourceRecords = append(resourceRecords, &route53.ResourceRecord{Value: aws.String(r.rrsetValue(value))})
+		}
+		rrset.ResourceRecords = resourceRecords
+		rrset.TTL = aws.Int64(int64(r.Ttl()))
 	}
 	params := &route53.ChangeResourceRecordSetsInput{
 		ChangeBatch: &route53.ChangeBatch{
 			Changes: []*route53.Change{
 				{
-					Action: aws.String("UPSERT"),
-					ResourceRecordSet: &route53.ResourceRecordSet{
-						Name:            aws.String(r.Id()),
-						Type:            aws.String(r.Type()),
-						ResourceRecords: resourceRecords,
-						TTL:             aws.Int64(int64(r.Ttl())),
-					},
+					Action:            aws.String("UPSERT"),
+					ResourceRecordSet: rrset,
 				},
 			},
 		},
@@ -407,6 +467,11 @@ func (r *dnsRecord) info() {
 	}
 	msg.Info("Dns %s Record", r.Type())
 	msg.Detail("%-20s\t%s", "id", r.Id())
+	if r.rrset.AliasTarget != nil {
+		msg.Detail("%-20s\t%s", "alias hosted zone", aws.StringValue(r.rrset.AliasTarget.HostedZoneId))
+		msg.Detail("%-20s\t%s", "alias dns name", aws.StringValue(r.rrset.AliasTarget.DNSName))
+		return
+	}
 	msg.Detail("%-20s\t%d", "ttl", r.rrTtl())
 	msg.Detail("%-20s\t%q", "values", r.rrValues())
 }
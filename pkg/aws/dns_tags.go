@@ -0,0 +1,108 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// Dns records cannot be tagged, so the tag policy applies to the hosted zone.
+const hostedZoneKind = "hosted-zone"
+
+func (d *dns) hostedZoneId() string {
+	return strings.TrimPrefix(d.id, "/hostedzone/")
+}
+
+func (d *dns) tags() (map[string]string, error) {
+	resp, err := d.route53.ListTagsForResource(&route53.ListTagsForResourceInput{
+		ResourceType: aws.String(route53.TagResourceTypeHostedzone),
+		ResourceId:   aws.String(d.hostedZoneId()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string]string{}
+	if resp.ResourceTagSet != nil {
+		for _, t := range resp.ResourceTagSet.Tags {
+			tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+		}
+	}
+	return tags, nil
+}
+
+// AuditTags satisfies the resource.DynamicDns interface.
+func (d *dns) AuditTags(policy *config.TagPolicy, flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil {
+		return fmt.Errorf("Audit Object does not exist")
+	}
+	tags, err := d.tags()
+	if err != nil {
+		return err
+	}
+	for _, p := range policy.Check(hostedZoneKind, tags) {
+		a.Audit(aaa.Mismatched, "%s %s, %s | %s", hostedZoneKind, d.DomainName(), d.hostedZoneId(), p)
+	}
+	return nil
+}
+
+// FixTags satisfies the resource.DynamicDns interface.
+func (d *dns) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	tags, err := d.tags()
+	if err != nil {
+		return err
+	}
+	fixed, problems := policy.Fix(hostedZoneKind, tags, nil, defaults)
+	for _, p := range problems {
+		msg.Warn("Unable to fix %s %s, %s", hostedZoneKind, d.DomainName(), p)
+	}
+	if len(fixed) == 0 {
+		return nil
+	}
+	msg.Detail("Set tags: %s %s", hostedZoneKind, d.DomainName())
+	add := []*route53.Tag{}
+	for k, v := range fixed {
+		add = append(add, &route53.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	_, err = d.route53.ChangeTagsForResource(&route53.ChangeTagsForResourceInput{
+		ResourceType: aws.String(route53.TagResourceTypeHostedzone),
+		ResourceId:   aws.String(d.hostedZoneId()),
+		AddTags:      add,
+	})
+	return err
+}
@@ -0,0 +1,247 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// egressOnlyGateway provides outbound ipv6 access to the private subnets of a dual
+// stack network. Egress only internet gateways can't be tagged, so the gateway is
+// identified by its attachment to the vpc.
+type egressOnlyGateway struct {
+	ec2     *ec2.EC2
+	network *network
+	name_   string
+	id_     string
+	eigw    *ec2.EgressOnlyInternetGateway
+}
+
+func newEgressOnlyGateway(c *ec2.EC2, n *network, name string) (*egressOnlyGateway, error) {
+	log.Debug("Initializing AWS EgressOnlyGateway %q", name)
+
+	e := &egressOnlyGateway{
+		ec2:     c,
+		network: n,
+		name_:   name,
+	}
+	return e, nil
+}
+
+func (e *egressOnlyGateway) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS EgressOnlyGateway %q", e.name())
+
+	if req.Top() != "" {
+		e.help()
+		return route.FAIL
+	}
+
+	if req.TestFlag() {
+		msg.Detail("Test. Skipping...")
+		return route.OK
+	}
+
+	switch req.Command() {
+	case route.Load:
+		if err := e.Load(); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Create:
+		return e.create(req)
+	case route.Audit:
+		return route.OK
+	case route.Destroy:
+		return e.destroy(req)
+	case route.Help:
+		e.help()
+		return route.OK
+	case route.Info:
+		e.info()
+		return route.OK
+	}
+	return route.FAIL
+}
+
+func (e *egressOnlyGateway) Created() bool {
+	return e.eigw != nil
+}
+
+func (e *egressOnlyGateway) Destroyed() bool {
+	return !e.Created()
+}
+
+func (e *egressOnlyGateway) name() string {
+	return e.name_
+}
+
+func (e *egressOnlyGateway) id() string {
+	return e.id_
+}
+
+func (e *egressOnlyGateway) set(eigw *ec2.EgressOnlyInternetGateway) {
+	if eigw == nil || eigw.EgressOnlyInternetGatewayId == nil {
+		return
+	}
+	e.eigw = eigw
+	e.id_ = *eigw.EgressOnlyInternetGatewayId
+}
+
+func (e *egressOnlyGateway) clear() {
+	e.eigw = nil
+	e.id_ = ""
+}
+
+func (e *egressOnlyGateway) Load() error {
+	e.eigw = nil
+	if e.network.vpc.id() == "" {
+		return nil
+	}
+
+	params := &ec2.DescribeEgressOnlyInternetGatewaysInput{}
+	if e.id() != "" {
+		params.EgressOnlyInternetGatewayIds = []*string{aws.String(e.id())}
+	}
+	for {
+		resp, err := e.ec2.DescribeEgressOnlyInternetGateways(params)
+		if err != nil {
+			return err
+		}
+		for _, eigw := range resp.EgressOnlyInternetGateways {
+			for _, attachment := range eigw.Attachments {
+				if aws.StringValue(attachment.VpcId) == e.network.vpc.id() {
+					e.set(eigw)
+					return nil
+				}
+			}
+		}
+		if resp.NextToken == nil {
+			break
+		}
+		params.NextToken = resp.NextToken
+	}
+	return nil
+}
+
+func (e *egressOnlyGateway) create(req *route.Request) route.Response {
+	msg.Info("EgressOnlyGateway Creation: %s", e.name())
+	if e.Created() {
+		msg.Detail("EgressOnlyGateway exists, skipping...")
+		return route.OK
+	}
+
+	params := &ec2.CreateEgressOnlyInternetGatewayInput{
+		VpcId: aws.String(e.network.vpc.id()),
+	}
+	resp, err := e.ec2.CreateEgressOnlyInternetGateway(params)
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	e.set(resp.EgressOnlyInternetGateway)
+
+	msg.Detail("Created %s", e.id())
+	aaa.Accounting("EgressOnlyGateway created: %s", e.id())
+
+	for _, routeTable := range e.network.routeTables.routeTables {
+		if routeTable.access() == "private" {
+			if resp := routeTable.createRoute(req, "::/0", eigw, e); resp != route.OK {
+				return resp
+			}
+		}
+	}
+	return route.OK
+}
+
+func (e *egressOnlyGateway) destroy(req *route.Request) route.Response {
+	msg.Info("EgressOnlyGateway Destruction: %s", e.name())
+	if e.Destroyed() {
+		msg.Detail("EgressOnlyGateway does not exist, skipping...")
+		return route.OK
+	}
+
+	for _, routeTable := range e.network.routeTables.routeTables {
+		if routeTable.access() == "private" {
+			if resp := routeTable.deleteRoute(req, "::/0"); resp != route.OK {
+				return resp
+			}
+		}
+	}
+
+	params := &ec2.DeleteEgressOnlyInternetGatewayInput{
+		EgressOnlyInternetGatewayId: aws.String(e.id()),
+	}
+	if _, err := e.ec2.DeleteEgressOnlyInternetGateway(params); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+
+	msg.Detail("Destroyed %s", e.id())
+	aaa.Accounting("EgressOnlyGateway destroyed: %s", e.id())
+
+	e.clear()
+
+	return route.OK
+}
+
+func (e *egressOnlyGateway) help() {
+	commands := []help.Command{
+		{Name: route.Create.String(), Desc: "create egress only internet gateway"},
+		{Name: route.Destroy.String(), Desc: "destroy egress only internet gateway"},
+		{Name: route.Info.String(), Desc: "show information about allocated egress only internet gateway"},
+		{Name: route.Help.String(), Desc: "show this help"},
+	}
+	help.Print("network egressonlygateway", commands)
+}
+
+func (e *egressOnlyGateway) info() {
+	if e.Destroyed() {
+		return
+	}
+	msg.Info("EgressOnlyGateway")
+	msg.Detail("%-20s\t%s", "name", e.name())
+	msg.Detail("%-20s\t%s", "id", e.id())
+	msg.IndentInc()
+	for _, attachment := range e.eigw.Attachments {
+		msg.Info("Attachment")
+		if attachment.VpcId != nil {
+			msg.Detail("%-20s\t%s", "vpc", *attachment.VpcId)
+		}
+		if attachment.State != nil {
+			msg.Detail("%-20s\t%s", "state", *attachment.State)
+		}
+	}
+	msg.IndentDec()
+}
@@ -0,0 +1,444 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+type endpoint struct {
+	*config.Endpoint
+	network     *network
+	ec2         *ec2.EC2
+	serviceName string
+
+	routeTables   map[string]*routeTable
+	subnets       []*subnet
+	securityGroup resource.SecurityGroup
+
+	vpce *ec2.VpcEndpoint
+	id_  string
+}
+
+func newEndpoint(net resource.Network, cfg *config.Endpoint, p *dataCenterProvider) (*endpoint, error) {
+	log.Debug("Initializing AWS Endpoint %q", cfg.Name())
+
+	n, ok := net.ProviderNetwork().(*network)
+	if !ok {
+		return nil, fmt.Errorf("AWS newEndpoint: Unable to obtain provider network")
+	}
+
+	// Services are given by their short name, e.g. s3, or by their full name.
+	serviceName := cfg.Service()
+	if !strings.Contains(serviceName, ".") {
+		serviceName = "com.amazonaws." + p.region + "." + serviceName
+	}
+
+	e := &endpoint{
+		Endpoint:    cfg,
+		network:     n,
+		ec2:         p.ec2,
+		serviceName: serviceName,
+		routeTables: map[string]*routeTable{},
+	}
+
+	switch cfg.Type() {
+	case "gateway":
+		for _, name := range cfg.RouteTables() {
+			if n.routeTables.find(name) == nil {
+				return nil, fmt.Errorf("Endpoint %q: Unknown route table %q", cfg.Name(), name)
+			}
+		}
+		for name, r := range n.routeTables.routeTables {
+			if cfg.AttachedTo(name) {
+				e.routeTables[name] = r
+			}
+		}
+	case "interface":
+		subnetGroup := net.SubnetGroups().Find(cfg.SubnetGroup())
+		if subnetGroup == nil {
+			return nil, fmt.Errorf("Endpoint %q: Unknown subnet group %q", cfg.Name(), cfg.SubnetGroup())
+		}
+		for _, sub := range subnetGroup.Subnets() {
+			s, ok := sub.ProviderSubnet().(*subnet)
+			if !ok {
+				return nil, fmt.Errorf("AWS newEndpoint: Unable to obtain provider subnet")
+			}
+			e.subnets = append(e.subnets, s)
+		}
+		e.securityGroup = net.SecurityGroups().Find(cfg.SecurityGroup())
+		if e.securityGroup == nil {
+			return nil, fmt.Errorf("Endpoint %q: Unknown security group %q", cfg.Name(), cfg.SecurityGroup())
+		}
+	}
+	return e, nil
+}
+
+func (e *endpoint) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS Endpoint %q", e.Name())
+
+	if req.Top() != "" {
+		e.help()
+		return route.FAIL
+	}
+
+	if req.TestFlag() {
+		msg.Detail("Test. Skipping...")
+		return route.OK
+	}
+
+	switch req.Command() {
+	case route.Load:
+		if err := e.Load(); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Create:
+		return e.create(req)
+	case route.Destroy:
+		return e.destroy(req)
+	case route.Audit:
+		if err := aaa.NewAudit("Endpoint"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		if err := e.Audit("Endpoint"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Help:
+		e.help()
+		return route.OK
+	case route.Info:
+		e.info()
+		return route.OK
+	}
+	msg.Error("Unknown endpoint command %q.", req.Command())
+	return route.FAIL
+}
+
+func (e *endpoint) Created() bool {
+	return e != nil && e.vpce != nil
+}
+
+func (e *endpoint) Destroyed() bool {
+	return !e.Created()
+}
+
+func (e *endpoint) id() string {
+	return e.id_
+}
+
+func (e *endpoint) state() string {
+	if e.vpce == nil {
+		return ""
+	}
+	return aws.StringValue(e.vpce.State)
+}
+
+func (e *endpoint) set(vpce *ec2.VpcEndpoint) {
+	if vpce == nil || vpce.VpcEndpointId == nil {
+		return
+	}
+	e.vpce = vpce
+	e.id_ = *vpce.VpcEndpointId
+}
+
+func (e *endpoint) clear() {
+	e.vpce = nil
+	e.id_ = ""
+}
+
+// Load finds the endpoint by its service and type, since the vpc can only
+// have one endpoint per service.
+func (e *endpoint) Load() error {
+	e.vpce = nil
+	if e.network.vpc.id() == "" {
+		return nil
+	}
+
+	params := &ec2.DescribeVpcEndpointsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{aws.String(e.network.vpc.id())},
+			},
+			{
+				Name:   aws.String("service-name"),
+				Values: []*string{aws.String(e.serviceName)},
+			},
+		},
+	}
+	resp, err := e.ec2.DescribeVpcEndpoints(params)
+	if err != nil {
+		return err
+	}
+	for _, vpce := range resp.VpcEndpoints {
+		switch aws.StringValue(vpce.State) {
+		case "deleting", "deleted", "failed", "rejected", "expired":
+			continue
+		}
+		if strings.EqualFold(aws.StringValue(vpce.VpcEndpointType), e.Type()) {
+			e.set(vpce)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (e *endpoint) reload() bool {
+	return msg.Wait(
+		fmt.Sprintf("Waiting for Endpoint %s, %s to become available", e.Name(), e.id()), // title
+		fmt.Sprintf("Endpoint %s, %s never became available", e.Name(), e.id()),          // err
+		300, // duration
+		func() bool { return e.state() == "available" }, // test()
+		func() bool { // load()
+			switch e.state() {
+			case "failed", "rejected", "deleted":
+				msg.Error("Endpoint %s, %s failed to become available, state: %s", e.Name(), e.id(), e.state())
+				return false
+			}
+			if err := e.Load(); err != nil {
+				msg.Error(err.Error())
+				return false
+			}
+			return true
+		},
+	)
+}
+
+func (e *endpoint) routeTableIds() []*string {
+	ids := []*string{}
+	for _, r := range e.routeTables {
+		if r.id() != "" {
+			ids = append(ids, aws.String(r.id()))
+		}
+	}
+	return ids
+}
+
+func (e *endpoint) subnetIds() []*string {
+	ids := []*string{}
+	for _, s := range e.subnets {
+		if s.Id() != "" {
+			ids = append(ids, aws.String(s.Id()))
+		}
+	}
+	return ids
+}
+
+func (e *endpoint) create(req *route.Request) route.Response {
+	msg.Info("Endpoint Creation: %s", e.Name())
+	if e.Created() {
+		msg.Detail("Endpoint exists, skipping...")
+		return route.OK
+	}
+
+	params := &ec2.CreateVpcEndpointInput{
+		VpcId:       aws.String(e.network.vpc.id()),
+		ServiceName: aws.String(e.serviceName),
+	}
+	policy, err := e.Policy()
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if policy != "" {
+		params.PolicyDocument = aws.String(policy)
+	}
+	switch e.Type() {
+	case "gateway":
+		params.VpcEndpointType = aws.String(ec2.VpcEndpointTypeGateway)
+		params.RouteTableIds = e.routeTableIds()
+	case "interface":
+		params.VpcEndpointType = aws.String(ec2.VpcEndpointTypeInterface)
+		params.SubnetIds = e.subnetIds()
+		params.SecurityGroupIds = []*string{aws.String(e.securityGroup.Id())}
+		params.PrivateDnsEnabled = aws.Bool(e.PrivateDns())
+	}
+	resp, err := e.ec2.CreateVpcEndpoint(params)
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	e.set(resp.VpcEndpoint)
+	if !e.reload() {
+		return route.FAIL
+	}
+	msg.Detail("Created: %s", e.id())
+	aaa.Accounting("Endpoint created: %s", e.id())
+	return route.OK
+}
+
+func (e *endpoint) destroy(req *route.Request) route.Response {
+	msg.Info("Endpoint Destruction: %s", e.Name())
+	if e.Destroyed() {
+		msg.Detail("Endpoint does not exist, skipping...")
+		return route.OK
+	}
+
+	params := &ec2.DeleteVpcEndpointsInput{
+		VpcEndpointIds: []*string{aws.String(e.id())},
+	}
+	resp, err := e.ec2.DeleteVpcEndpoints(params)
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	for _, u := range resp.Unsuccessful {
+		if u.Error != nil {
+			msg.Error("Endpoint %s, %s", e.id(), aws.StringValue(u.Error.Message))
+			return route.FAIL
+		}
+	}
+
+	ok := msg.Wait(
+		fmt.Sprintf("Waiting for Endpoint %s to delete", e.id()), // title
+		fmt.Sprintf("Endpoint %s never deleted", e.id()),         // err
+		300,                                    // duration
+		e.Destroyed,                            // test()
+		func() bool { return e.Load() == nil }, // load()
+	)
+	if !ok {
+		return route.FAIL
+	}
+	msg.Detail("Destroyed: %s", e.id())
+	aaa.Accounting("Endpoint destroyed: %s", e.id())
+	e.clear()
+	return route.OK
+}
+
+// Audit checks the endpoint is available, is attached to the configured route
+// tables or subnets and security group and has the configured policy.
+func (e *endpoint) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil {
+		return fmt.Errorf("Audit Object does not exist")
+	}
+	if e.Destroyed() {
+		a.Audit(aaa.Configured, "Endpoint %s for %s", e.Name(), e.serviceName)
+		return nil
+	}
+	if e.state() != "available" {
+		a.Audit(aaa.Mismatched, "Endpoint %s, %s is %s", e.Name(), e.id(), e.state())
+	}
+
+	switch e.Type() {
+	case "gateway":
+		if c, d := idList(e.routeTableIds()), idList(e.vpce.RouteTableIds); c != d {
+			a.Audit(aaa.Mismatched, "Endpoint %s, %s | Configured route tables: %s - Deployed route tables: %s", e.Name(), e.id(), c, d)
+		}
+	case "interface":
+		if c, d := idList(e.subnetIds()), idList(e.vpce.SubnetIds); c != d {
+			a.Audit(aaa.Mismatched, "Endpoint %s, %s | Configured subnets: %s - Deployed subnets: %s", e.Name(), e.id(), c, d)
+		}
+		groups := []*string{}
+		for _, g := range e.vpce.Groups {
+			groups = append(groups, g.GroupId)
+		}
+		if c, d := e.securityGroup.Id(), idList(groups); c != d {
+			a.Audit(aaa.Mismatched, "Endpoint %s, %s | Configured security group: %s - Deployed security groups: %s", e.Name(), e.id(), c, d)
+		}
+	}
+
+	policy, err := e.Policy()
+	if err != nil {
+		return err
+	}
+	if policy != "" && !samePolicy(policy, aws.StringValue(e.vpce.PolicyDocument)) {
+		a.Audit(aaa.Mismatched, "Endpoint %s, %s | The deployed policy differs from the configured policy", e.Name(), e.id())
+	}
+	return nil
+}
+
+// idList returns the sorted, comma separated ids.
+func idList(ids []*string) string {
+	s := aws.StringValueSlice(ids)
+	sort.Strings(s)
+	return strings.Join(s, ", ")
+}
+
+// samePolicy compares two policy documents ignoring formatting.
+func samePolicy(a, b string) bool {
+	var x, y interface{}
+	if json.Unmarshal([]byte(a), &x) != nil || json.Unmarshal([]byte(b), &y) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func (e *endpoint) help() {
+	commands := []help.Command{
+		{Name: route.Create.String(), Desc: fmt.Sprintf("create %s endpoint", e.Name())},
+		{Name: route.Destroy.String(), Desc: fmt.Sprintf("destroy %s endpoint", e.Name())},
+		{Name: route.Audit.String(), Desc: fmt.Sprintf("audit %s endpoint", e.Name())},
+		{Name: route.Info.String(), Desc: fmt.Sprintf("show information about allocated %s endpoint", e.Name())},
+		{Name: route.Help.String(), Desc: "show this help"},
+	}
+	help.Print(fmt.Sprintf("network endpoint %s", e.Name()), commands)
+}
+
+func (e *endpoint) info() {
+	if e.Destroyed() {
+		return
+	}
+	msg.Info("Endpoint")
+	msg.Detail("%-20s\t%s", "name", e.Name())
+	msg.Detail("%-20s\t%s", "id", e.id())
+	msg.Detail("%-20s\t%s", "service", e.serviceName)
+	msg.Detail("%-20s\t%s", "type", aws.StringValue(e.vpce.VpcEndpointType))
+	msg.Detail("%-20s\t%s", "state", e.state())
+	if len(e.vpce.RouteTableIds) > 0 {
+		msg.Detail("%-20s\t%s", "route tables", idList(e.vpce.RouteTableIds))
+	}
+	if len(e.vpce.SubnetIds) > 0 {
+		msg.Detail("%-20s\t%s", "subnets", idList(e.vpce.SubnetIds))
+	}
+	for _, d := range e.vpce.DnsEntries {
+		msg.Detail("%-20s\t%s", "dns name", aws.StringValue(d.DnsName))
+	}
+}
@@ -0,0 +1,209 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// endpoints implements the resource.ProviderEndpoints interface.
+type endpoints struct {
+	*resource.Resources
+	*config.Network
+	network   *network
+	ec2       *ec2.EC2
+	endpoints map[string]*endpoint
+}
+
+// newEndpoints constructs the aws vpc endpoints.
+func newEndpoints(net resource.Network, cfg *config.Network, p *dataCenterProvider) (*endpoints, error) {
+	log.Debug("Initializing AWS Endpoints")
+
+	n, ok := net.ProviderNetwork().(*network)
+	if !ok {
+		return nil, fmt.Errorf("AWS newEndpoints: Unable to obtain provider network")
+	}
+
+	e := &endpoints{
+		Resources: resource.NewResources(),
+		Network:   cfg,
+		network:   n,
+		ec2:       p.ec2,
+		endpoints: map[string]*endpoint{},
+	}
+	for _, c := range cfg.Endpoints() {
+		endpoint, err := newEndpoint(net, c, p)
+		if err != nil {
+			return nil, err
+		}
+		e.endpoints[c.Name()] = endpoint
+		e.Append(endpoint)
+	}
+	return e, nil
+}
+
+func (e *endpoints) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS Endpoints")
+
+	switch req.Top() {
+	case "endpoints", "endpoint", "vpce":
+		req.Pop()
+	}
+	if endpoint := e.find(req.Top()); endpoint != nil {
+		return endpoint.Route(req.Pop())
+	}
+	if req.Top() != "" {
+		msg.Error("Unknown endpoint %q.", req.Top())
+		return route.FAIL
+	}
+
+	if req.TestFlag() {
+		msg.Detail("Test. Skipping...")
+		return route.OK
+	}
+
+	switch req.Command() {
+	case route.Load, route.Create:
+		return e.RouteInOrder(req)
+	case route.Destroy:
+		return e.RouteReverseOrder(req)
+	case route.Audit:
+		if err := e.Audit("Endpoint"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Help:
+		e.help()
+		return route.OK
+	case route.Info:
+		e.info(req)
+		return route.OK
+	}
+	msg.Error("Unknown endpoint command %q.", req.Command())
+	return route.FAIL
+}
+
+// Created is true when all of the configured endpoints have been created.
+func (e *endpoints) Created() bool {
+	return len(e.endpoints) == 0 || e.Resources.Created()
+}
+
+func (e *endpoints) find(s string) *endpoint {
+	return e.endpoints[s]
+}
+
+// Audit checks the configured endpoints and identifies any endpoints in the
+// datacenter's vpc that aren't configured.
+func (e *endpoints) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	if err := aaa.NewAudit(flags[0]); err != nil {
+		return err
+	}
+	configured := map[string]bool{}
+	for _, endpoint := range e.endpoints {
+		if err := endpoint.Audit(flags...); err != nil {
+			return err
+		}
+		configured[endpoint.id()] = true
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil || e.network.vpc.id() == "" {
+		return nil
+	}
+
+	params := &ec2.DescribeVpcEndpointsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{aws.String(e.network.vpc.id())},
+			},
+		},
+	}
+	resp, err := e.ec2.DescribeVpcEndpoints(params)
+	if err != nil {
+		return err
+	}
+	for _, vpce := range resp.VpcEndpoints {
+		id := aws.StringValue(vpce.VpcEndpointId)
+		if configured[id] || aws.StringValue(vpce.State) == "deleted" {
+			continue
+		}
+		a.Audit(aaa.Deployed, "Endpoint %s for %s", id, aws.StringValue(vpce.ServiceName))
+	}
+	return nil
+}
+
+func (e *endpoints) CanRoute(req *route.Request) bool {
+	switch req.Top() {
+	case "endpoints", "endpoint", "vpce":
+		return true
+	}
+	return false
+}
+
+func (e *endpoints) HelpCommands() []help.Command {
+	return []help.Command{
+		{Name: "endpoints", Desc: "manage aws vpc endpoints"},
+		{Name: "endpoint [name]", Desc: "manage named aws vpc endpoint"},
+	}
+}
+
+func (e *endpoints) help() {
+	commands := []help.Command{
+		{Name: "'name'", Desc: "manage named endpoint"},
+		{Name: route.Create.String(), Desc: "create all endpoints"},
+		{Name: route.Destroy.String(), Desc: "destroy all endpoints"},
+		{Name: route.Audit.String(), Desc: "audit all endpoints"},
+		{Name: route.Info.String(), Desc: "show information about all allocated endpoints"},
+		{Name: route.Help.String(), Desc: "show this help"},
+	}
+	help.Print("network endpoint", commands)
+}
+
+func (e *endpoints) info(req *route.Request) {
+	if len(e.endpoints) == 0 || e.Destroyed() {
+		return
+	}
+	msg.Info("Endpoints")
+	msg.IndentInc()
+	e.RouteInOrder(req)
+	msg.IndentDec()
+}
@@ -0,0 +1,328 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// flowLog manages the flow logs of the network's vpc, or of a subnet group's
+// subnets, delivered to an s3 bucket. Flow logs can't be tagged, so they are
+// identified by the resource they capture. Flow logs delivered to cloudwatch
+// logs instead of a bucket aren't managed by arc.
+type flowLog struct {
+	*config.FlowLog
+	name         string
+	ec2          *ec2.EC2
+	network      *network
+	resourceType string
+	resourceIds  func() []string
+
+	flowLogs map[string]*ec2.FlowLog
+}
+
+func newFlowLog(c *ec2.EC2, net resource.Network, name string, cfg *config.FlowLog) (*flowLog, error) {
+	log.Debug("Initializing AWS FlowLog %q", name)
+
+	n, ok := net.ProviderNetwork().(*network)
+	if !ok {
+		return nil, fmt.Errorf("AWS newFlowLog: Unable to obtain provider network")
+	}
+	f := &flowLog{
+		FlowLog:  cfg,
+		name:     name,
+		ec2:      c,
+		network:  n,
+		flowLogs: map[string]*ec2.FlowLog{},
+	}
+
+	// The network's flow log captures the vpc, a subnet group's flow log captures its subnets.
+	if name == "network" {
+		f.resourceType = "VPC"
+		f.resourceIds = func() []string {
+			if n.vpc.id() == "" {
+				return nil
+			}
+			return []string{n.vpc.id()}
+		}
+		return f, nil
+	}
+	subnetGroup := net.SubnetGroups().Find(name)
+	if subnetGroup == nil {
+		return nil, fmt.Errorf("AWS newFlowLog: Unknown subnet group %q", name)
+	}
+	f.resourceType = "Subnet"
+	f.resourceIds = func() []string {
+		ids := []string{}
+		for _, s := range subnetGroup.Subnets() {
+			if s.Id() != "" {
+				ids = append(ids, s.Id())
+			}
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	return f, nil
+}
+
+func (f *flowLog) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS FlowLog %q", f.name)
+
+	if req.Top() != "" {
+		f.help()
+		return route.FAIL
+	}
+
+	if req.TestFlag() {
+		msg.Detail("Test. Skipping...")
+		return route.OK
+	}
+
+	switch req.Command() {
+	case route.Load:
+		if err := f.Load(); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Create:
+		return f.create(req)
+	case route.Destroy:
+		return f.destroy(req)
+	case route.Audit:
+		if err := aaa.NewAudit("FlowLog"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		if err := f.Audit("FlowLog"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Help:
+		f.help()
+		return route.OK
+	case route.Info:
+		f.info()
+		return route.OK
+	}
+	msg.Error("Unknown flow log command %q.", req.Command())
+	return route.FAIL
+}
+
+// Created returns true when every captured resource has a flow log.
+func (f *flowLog) Created() bool {
+	ids := f.resourceIds()
+	if len(ids) == 0 {
+		return false
+	}
+	for _, id := range ids {
+		if f.flowLogs[id] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *flowLog) Destroyed() bool {
+	return len(f.flowLogs) == 0
+}
+
+func (f *flowLog) Load() error {
+	f.flowLogs = map[string]*ec2.FlowLog{}
+	ids := f.resourceIds()
+	if len(ids) == 0 {
+		return nil
+	}
+	params := &ec2.DescribeFlowLogsInput{
+		Filter: []*ec2.Filter{
+			{
+				Name:   aws.String("resource-id"),
+				Values: aws.StringSlice(ids),
+			},
+		},
+	}
+	resp, err := f.ec2.DescribeFlowLogs(params)
+	if err != nil {
+		return err
+	}
+	for _, fl := range resp.FlowLogs {
+		if aws.StringValue(fl.LogGroupName) != "" {
+			continue
+		}
+		f.flowLogs[aws.StringValue(fl.ResourceId)] = fl
+	}
+	return nil
+}
+
+// createFlowLogsInput is ec2.CreateFlowLogsInput with the bucket destination,
+// which the vendored aws sdk predates.
+type createFlowLogsInput struct {
+	_ struct{} `type:"structure"`
+
+	LogDestination     *string   `type:"string"`
+	LogDestinationType *string   `type:"string"`
+	ResourceIds        []*string `locationName:"ResourceId" locationNameList:"item" type:"list"`
+	ResourceType       *string   `type:"string"`
+	TrafficType        *string   `type:"string"`
+}
+
+func (f *flowLog) create(req *route.Request) route.Response {
+	msg.Info("FlowLog Creation: %s", f.name)
+	if f.Created() {
+		msg.Detail("FlowLog exists, skipping...")
+		return route.OK
+	}
+	ids := []string{}
+	for _, id := range f.resourceIds() {
+		if f.flowLogs[id] == nil {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		msg.Error("FlowLog %s: Nothing to capture, the %s doesn't exist", f.name, strings.ToLower(f.resourceType))
+		return route.FAIL
+	}
+
+	params := &createFlowLogsInput{
+		LogDestination:     aws.String(f.Destination()),
+		LogDestinationType: aws.String("s3"),
+		ResourceIds:        aws.StringSlice(ids),
+		ResourceType:       aws.String(f.resourceType),
+		TrafficType:        aws.String(strings.ToUpper(f.Traffic())),
+	}
+	op := &request.Operation{Name: "CreateFlowLogs", HTTPMethod: "POST", HTTPPath: "/"}
+	resp := &ec2.CreateFlowLogsOutput{}
+	if err := f.ec2.NewRequest(op, params, resp).Send(); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	for _, u := range resp.Unsuccessful {
+		if u.Error != nil {
+			msg.Error("FlowLog %s, %s: %s", f.name, aws.StringValue(u.ResourceId), aws.StringValue(u.Error.Message))
+			return route.FAIL
+		}
+	}
+	if err := f.Load(); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	msg.Detail("Created: %s", strings.Join(aws.StringValueSlice(resp.FlowLogIds), ", "))
+	aaa.Accounting("FlowLog created: %s, %s", f.name, strings.Join(aws.StringValueSlice(resp.FlowLogIds), ", "))
+	return route.OK
+}
+
+func (f *flowLog) destroy(req *route.Request) route.Response {
+	msg.Info("FlowLog Destruction: %s", f.name)
+	if f.Destroyed() {
+		msg.Detail("FlowLog does not exist, skipping...")
+		return route.OK
+	}
+	ids := []string{}
+	for _, fl := range f.flowLogs {
+		ids = append(ids, aws.StringValue(fl.FlowLogId))
+	}
+	sort.Strings(ids)
+	params := &ec2.DeleteFlowLogsInput{
+		FlowLogIds: aws.StringSlice(ids),
+	}
+	if _, err := f.ec2.DeleteFlowLogs(params); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	msg.Detail("Destroyed: %s", strings.Join(ids, ", "))
+	aaa.Accounting("FlowLog destroyed: %s, %s", f.name, strings.Join(ids, ", "))
+	f.flowLogs = map[string]*ec2.FlowLog{}
+	return route.OK
+}
+
+// Audit checks every captured resource has a flow log capturing the configured traffic.
+func (f *flowLog) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil {
+		return fmt.Errorf("Audit Object does not exist")
+	}
+	for _, id := range f.resourceIds() {
+		fl := f.flowLogs[id]
+		if fl == nil {
+			a.Audit(aaa.Configured, "FlowLog %s for %s", f.name, id)
+			continue
+		}
+		if traffic := strings.ToLower(aws.StringValue(fl.TrafficType)); traffic != f.Traffic() {
+			a.Audit(aaa.Mismatched, "FlowLog %s, %s | captures %s traffic, configured traffic %s", f.name, aws.StringValue(fl.FlowLogId), traffic, f.Traffic())
+		}
+		if status := aws.StringValue(fl.DeliverLogsStatus); status != "" && status != "SUCCESS" {
+			a.Audit(aaa.Mismatched, "FlowLog %s, %s | delivery %s: %s", f.name, aws.StringValue(fl.FlowLogId), status, aws.StringValue(fl.DeliverLogsErrorMessage))
+		}
+	}
+	return nil
+}
+
+func (f *flowLog) help() {
+	commands := []help.Command{
+		{Name: route.Create.String(), Desc: fmt.Sprintf("create %s flow log", f.name)},
+		{Name: route.Destroy.String(), Desc: fmt.Sprintf("destroy %s flow log", f.name)},
+		{Name: route.Audit.String(), Desc: fmt.Sprintf("audit %s flow log", f.name)},
+		{Name: route.Info.String(), Desc: fmt.Sprintf("show information about allocated %s flow log", f.name)},
+		{Name: route.Help.String(), Desc: "show this help"},
+	}
+	help.Print(fmt.Sprintf("network flowlog %s", f.name), commands)
+}
+
+func (f *flowLog) info() {
+	if f.Destroyed() {
+		return
+	}
+	msg.Info("FlowLog")
+	msg.Detail("%-20s\t%s", "name", f.name)
+	msg.Detail("%-20s\t%s", "destination", f.Destination())
+	for _, id := range f.resourceIds() {
+		fl := f.flowLogs[id]
+		if fl == nil {
+			continue
+		}
+		msg.Detail("%-20s\t%s, %s, %s, %s", "flow log", aws.StringValue(fl.FlowLogId), id,
+			strings.ToLower(aws.StringValue(fl.TrafficType)), aws.StringValue(fl.FlowLogStatus))
+	}
+}
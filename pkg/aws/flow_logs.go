@@ -0,0 +1,159 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+type flowLogs struct {
+	*resource.Resources
+	flowLogs map[string]*flowLog
+}
+
+// newFlowLogs creates the flow log of the network and those of the subnet
+// groups. It returns nil if no flow logs are configured.
+func newFlowLogs(c *ec2.EC2, n resource.Network, cfg *config.Network) (*flowLogs, error) {
+	f := &flowLogs{
+		Resources: resource.NewResources(),
+		flowLogs:  map[string]*flowLog{},
+	}
+	add := func(name string, fl *flowLog, err error) error {
+		if err != nil {
+			return err
+		}
+		f.flowLogs[name] = fl
+		f.Append(fl)
+		return nil
+	}
+	if n.FlowLog() != nil {
+		fl, err := newFlowLog(c, n, "network", n.FlowLog())
+		if err := add("network", fl, err); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range *cfg.SubnetGroups {
+		if s.FlowLog() == nil {
+			continue
+		}
+		fl, err := newFlowLog(c, n, s.Name(), s.FlowLog())
+		if err := add(s.Name(), fl, err); err != nil {
+			return nil, err
+		}
+	}
+	if len(f.flowLogs) == 0 {
+		log.Debug("AWS FlowLogs not needed. No flow logs configured.")
+		return nil, nil
+	}
+	log.Debug("Initializing AWS FlowLogs")
+	return f, nil
+}
+
+func (f *flowLogs) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS FlowLogs")
+
+	if fl := f.flowLogs[req.Top()]; fl != nil {
+		return fl.Route(req.Pop())
+	}
+	if req.Top() != "" {
+		msg.Error("Unknown flow log %q.", req.Top())
+		return route.FAIL
+	}
+
+	if req.TestFlag() {
+		msg.Detail("Test. Skipping...")
+		return route.OK
+	}
+
+	switch req.Command() {
+	case route.Load, route.Create:
+		return f.RouteInOrder(req)
+	case route.Destroy:
+		return f.RouteReverseOrder(req)
+	case route.Audit:
+		if err := f.Audit("FlowLog"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Help:
+		f.help()
+		return route.OK
+	case route.Info:
+		f.info(req)
+		return route.OK
+	}
+	return route.FAIL
+}
+
+// Audit checks the configured flow logs.
+func (f *flowLogs) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	if err := aaa.NewAudit(flags[0]); err != nil {
+		return err
+	}
+	for _, fl := range f.flowLogs {
+		if err := fl.Audit(flags...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *flowLogs) help() {
+	commands := []help.Command{
+		{Name: "'name'", Desc: "manage the named flow log, network or a subnet group's name"},
+		{Name: route.Create.String(), Desc: "create all flow logs"},
+		{Name: route.Destroy.String(), Desc: "destroy all flow logs"},
+		{Name: route.Audit.String(), Desc: "audit all flow logs"},
+		{Name: route.Info.String(), Desc: "show information about all allocated flow logs"},
+		{Name: route.Help.String(), Desc: "show this help"},
+	}
+	help.Print("network flowlog", commands)
+}
+
+func (f *flowLogs) info(req *route.Request) {
+	if f.Destroyed() {
+		return
+	}
+	msg.Info("FlowLogs")
+	msg.IndentInc()
+	f.RouteInOrder(req)
+	msg.IndentDec()
+}
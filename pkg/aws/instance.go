@@ -27,10 +27,15 @@
 package aws
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"fmt"
 	"path/filepath"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 
 	"github.com/cisco/arc/pkg/aaa"
@@ -48,6 +53,7 @@ type instance struct {
 	ec2      *ec2.EC2
 
 	compute   *compute
+	group     *autoScalingGroup
 	network   *network
 	subnet    *subnet
 	secgroups []*securityGroup
@@ -59,6 +65,8 @@ type instance struct {
 	volumes        []*volume
 	instance       *ec2.Instance
 	cached         bool
+	adopted        bool
+	userData       []byte
 }
 
 // newInstance constructs the aws instance.
@@ -70,16 +78,24 @@ func newInstance(in resource.Instance, cfg *config.Instance, p *dataCenterProvid
 	if !ok {
 		return nil, fmt.Errorf("AWS newInstance: Unable to obtain prov compute associated with instance %s", cfg.Name())
 	}
+	c = c.forProvider(p)
 	n, ok := in.Network().ProviderNetwork().(*network)
 	if !ok {
 		return nil, fmt.Errorf("AWS newInstance: Unable to obtain prov network associated with instance %s", cfg.Name())
 	}
+	n, err := n.forProvider(p)
+	if err != nil {
+		return nil, err
+	}
 
 	// Type assertion to get underlying aws subnet for this instance.
 	s, ok := in.Subnet().ProviderSubnet().(*subnet)
 	if !ok {
 		return nil, fmt.Errorf("AWS newInstance: Unable to obtain subnet %s associated with instance %s", in.Subnet().Name(), cfg.Name())
 	}
+	if s.network != n {
+		return nil, fmt.Errorf("AWS newInstance: Subnet %s of instance %s isn't pinned to the cluster's target", in.Subnet().Name(), cfg.Name())
+	}
 
 	// Type assertions to get underlying aws securityGroups associated with this instance.
 	secgroups := []*securityGroup{}
@@ -88,14 +104,22 @@ func newInstance(in resource.Instance, cfg *config.Instance, p *dataCenterProvid
 		if !ok {
 			return nil, fmt.Errorf("AWS newInstance: Unable to obtain security group %s associated with instance %s", sg.Name(), cfg.Name())
 		}
-		secgroups = append(secgroups, secgroup)
+		secgroups = append(secgroups, secgroup.forNetwork(n))
 	}
 
-	// Get the id of the image to be used with the instance.
+	// Get the id of the image to be used with the instance. Pods launching from a
+	// baked image use the base image until the baked image is available.
 	imageId := p.images[cfg.Image()]
 	if imageId == "" {
 		return nil, fmt.Errorf("newInstance: Unknown image %s", cfg.Image())
 	}
+	if cfg.Baked() {
+		if id := p.images[cfg.BakedImage()]; id != "" {
+			imageId = id
+		} else {
+			log.Warn("No baked image %s for instance %s, using %s", cfg.BakedImage(), cfg.Name(), cfg.Image())
+		}
+	}
 
 	// Get the name of the keypair that will be installed for the root user.
 	keyname := in.KeyPair().Name()
@@ -117,14 +141,20 @@ func newInstance(in resource.Instance, cfg *config.Instance, p *dataCenterProvid
 		keyname:   keyname,
 		volumes:   volumes,
 	}
-	i.set(c.instanceCache.find(i))
-	if i.instance != nil {
-		i.cached = true
-	}
-
 	for _, v := range in.ProviderVolumes() {
 		v.(*volume).associateInstance(i)
 	}
+	c.instances = append(c.instances, i)
+
+	// Instances of a pod backed by an autoscaling group are launched by the group.
+	if cfg.AutoScaling() != nil {
+		g, ok := in.Pod().ProviderAutoScalingGroup().(*autoScalingGroup)
+		if !ok {
+			return nil, fmt.Errorf("AWS newInstance: Unable to obtain autoscaling group associated with instance %s", cfg.Name())
+		}
+		i.group = g
+		g.register(i)
+	}
 
 	return i, nil
 }
@@ -138,11 +168,21 @@ func (i *instance) Route(req *route.Request) route.Response {
 			// Clear the cached value
 			log.Debug("Clearing cached value for %s", i.Name())
 			i.cached = false
+		} else if !i.cached && i.instance == nil {
+			// Use the instance cache, if it has been loaded.
+			i.set(i.compute.instanceCache.find(i))
+			if i.instance != nil {
+				i.cached = true
+			}
 		}
 		if err := i.load(); err != nil {
 			msg.Error(err.Error())
 			return route.FAIL
 		}
+		// Adopt an instance the autoscaling group launched since the pod was created.
+		if i.instance == nil && i.group != nil {
+			i.adopt()
+		}
 		return route.OK
 	case route.Create:
 		return i.create(req)
@@ -166,7 +206,7 @@ func (i *instance) Created() bool {
 }
 
 func (i *instance) Destroyed() bool {
-	return i.instance == nil
+	return i.instance == nil || i.State() == "terminated"
 }
 
 func (i *instance) Id() string {
@@ -196,6 +236,69 @@ func (i *instance) Stopped() bool {
 	return i.State() == "stopped"
 }
 
+func (i *instance) Pending() bool {
+	return i.State() == "pending"
+}
+
+func (i *instance) Stopping() bool {
+	return i.State() == "stopping"
+}
+
+func (i *instance) ShuttingDown() bool {
+	return i.State() == "shutting-down"
+}
+
+// Lifecycle satisfies the resource.DynamicInstance interface. It notes the
+// capacity reservation reserved instances are launched into and the fallback
+// instance type, if one was used.
+func (i *instance) Lifecycle() string {
+	if i.instance == nil {
+		return ""
+	}
+	lifecycle := "on-demand"
+	if aws.StringValue(i.instance.InstanceLifecycle) == "spot" {
+		lifecycle = "spot"
+	} else if i.Purchasing().Reserved() {
+		lifecycle = "reserved " + i.Purchasing().Reservation()
+	}
+	if t := aws.StringValue(i.instance.InstanceType); t != i.Instance.InstanceType() {
+		lifecycle += ", fallback " + t
+	}
+	return lifecycle
+}
+
+// AvailabilityZone satisfies the resource.DynamicInstance interface.
+func (i *instance) AvailabilityZone() string {
+	if i.instance == nil || i.instance.Placement == nil {
+		return ""
+	}
+	return aws.StringValue(i.instance.Placement.AvailabilityZone)
+}
+
+// DeployedType satisfies the resource.DynamicInstance interface.
+func (i *instance) DeployedType() string {
+	if i.instance == nil {
+		return ""
+	}
+	return aws.StringValue(i.instance.InstanceType)
+}
+
+// SetInstanceType satisfies the resource.DynamicInstance interface. The
+// instance must be stopped for the change to be accepted.
+func (i *instance) SetInstanceType(instanceType string) error {
+	params := &ec2.ModifyInstanceAttributeInput{
+		InstanceId: aws.String(i.Id()),
+		InstanceType: &ec2.AttributeValue{
+			Value: aws.String(instanceType),
+		},
+	}
+	if _, err := i.ec2.ModifyInstanceAttribute(params); err != nil {
+		return err
+	}
+	i.instance.InstanceType = aws.String(instanceType)
+	return nil
+}
+
 func (i *instance) PrivateIPAddress() string {
 	if i.instance == nil || i.instance.PrivateIpAddress == nil {
 		return ""
@@ -211,6 +314,104 @@ func (i *instance) PublicIPAddress() string {
 	return *i.instance.PublicIpAddress
 }
 
+func (i *instance) Ipv6Address() string {
+	if i.instance == nil {
+		return ""
+	}
+	for _, ni := range i.instance.NetworkInterfaces {
+		for _, a := range ni.Ipv6Addresses {
+			if a.Ipv6Address != nil {
+				return *a.Ipv6Address
+			}
+		}
+	}
+	return ""
+}
+
+// Baked satisfies the resource.DynamicInstance interface.
+func (i *instance) Baked() bool {
+	id := i.provider.images[i.BakedImage()]
+	if !i.Instance.Baked() || id == "" {
+		return false
+	}
+	if i.instance != nil {
+		return aws.StringValue(i.instance.ImageId) == id
+	}
+	return i.imageId == id
+}
+
+// CreateImage satisfies the resource.DynamicInstance interface.
+func (i *instance) CreateImage(name string, tags map[string]string) (string, error) {
+	params := &ec2.CreateImageInput{
+		InstanceId:  aws.String(i.Id()),
+		Name:        aws.String(name),
+		Description: aws.String("Baked from " + i.Name()),
+	}
+	resp, err := i.ec2.CreateImage(params)
+	if err != nil {
+		return "", err
+	}
+	id := aws.StringValue(resp.ImageId)
+	if err := setTags(i.ec2, tags, id); err != nil {
+		return id, err
+	}
+
+	state := ""
+	if !msg.Wait(
+		fmt.Sprintf("Waiting for Image %s, %s to become available", name, id), // title
+		fmt.Sprintf("Image %s, %s failed to become available", name, id),      // err
+		300, // duration
+		func() bool { return state == "available" }, // test()
+		func() bool {
+			params := &ec2.DescribeImagesInput{
+				ImageIds: []*string{aws.String(id)},
+			}
+			resp, err := i.ec2.DescribeImages(params)
+			if err != nil {
+				msg.Error(err.Error())
+				return false
+			}
+			if len(resp.Images) == 1 {
+				state = aws.StringValue(resp.Images[0].State)
+			}
+			return state != "failed"
+		},
+	) {
+		return id, fmt.Errorf("Image %s, %s is %s", name, id, state)
+	}
+	return id, nil
+}
+
+// SetUserData satisfies the resource.ProviderInstance interface.
+func (i *instance) SetUserData(data []byte) {
+	i.userData = data
+}
+
+// maxUserData is the largest user-data, before base64 encoding, that aws accepts.
+const maxUserData = 16 * 1024
+
+// encodeUserData gzips the user-data, which cloud-init recognizes, to keep it
+// within the aws limit and then base64 encodes it for RunInstances.
+func (i *instance) encodeUserData() (string, error) {
+	return encodeUserData(i.Name(), i.userData)
+}
+
+// encodeUserData gzips and base64 encodes the named user-data.
+func encodeUserData(name string, data []byte) (string, error) {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	if b.Len() > maxUserData {
+		return "", fmt.Errorf("The user-data for %s is %d bytes compressed, the limit is %d", name, b.Len(), maxUserData)
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
 func (i *instance) SetTags(t map[string]string) error {
 	return setTags(i.ec2, t, i.Id())
 }
@@ -226,10 +427,21 @@ func (i *instance) Audit(flags ...string) error {
 	}
 	// Configured but not deployed
 	if i.Destroyed() {
+		// The autoscaling group decides how many of the pod's instances exist.
+		if i.group != nil {
+			return nil
+		}
+		if i.compute.instanceCache.interrupted(i.Name()) {
+			a.Audit(aaa.Configured, "%s, spot interrupted", i.Name())
+			return nil
+		}
 		a.Audit(aaa.Configured, "%s", i.Name())
 		return nil
 	}
 	// Mismatched
+	if i.adopted {
+		a.Audit(aaa.Mismatched, "Instance %q | Adopted autoscaling group instance %s, provision the pod to claim it", i.Name(), i.Id())
+	}
 	i.compareImage(a)
 	i.compareInstanceType(a)
 	i.compareRole(a)
@@ -244,15 +456,25 @@ func (i *instance) compareImage(a *aaa.Audit) {
 	if i.instance.ImageId != nil {
 		d = i.provider.findById(*i.instance.ImageId)
 	}
+	if d == i.BakedImage() && i.Instance.Baked() {
+		return
+	}
 	if i.instance.ImageId != nil && d != "" && d != i.Image() {
 		a.Audit(aaa.Mismatched, "Instance %q | Configured Image: %q - Deployed Image: %q", i.Name(), i.Image(), d)
 	}
 }
 
 func (i *instance) compareInstanceType(a *aaa.Audit) {
-	if i.instance.InstanceType != nil && *i.instance.InstanceType != i.Instance.InstanceType() {
-		a.Audit(aaa.Mismatched, "Instance %q | Configured Instance Type: %q - Deployed Instance Type: %q", i.Name(), i.Instance.InstanceType(), *i.instance.InstanceType)
+	if i.instance.InstanceType == nil {
+		return
 	}
+	// Instances running on a fallback instance type aren't mismatched.
+	for _, t := range i.InstanceTypes() {
+		if *i.instance.InstanceType == t {
+			return
+		}
+	}
+	a.Audit(aaa.Mismatched, "Instance %q | Configured Instance Type: %q - Deployed Instance Type: %q", i.Name(), i.Instance.InstanceType(), *i.instance.InstanceType)
 }
 
 func (i *instance) compareRole(a *aaa.Audit) {
@@ -308,6 +530,10 @@ func (i *instance) compareSecgroups(a *aaa.Audit) {
 }
 
 func (i *instance) compareSubnet(a *aaa.Audit) {
+	// The autoscaling group chooses the subnet.
+	if i.group != nil {
+		return
+	}
 	if i.instance.SubnetId != nil && *i.instance.SubnetId != i.subnet.Id() {
 		d := i.network.subnetCache.findById(*i.instance.SubnetId)
 		depName := ""
@@ -358,6 +584,11 @@ func (i *instance) clear() {
 
 func (i *instance) load() error {
 
+	// The subnet isn't loaded yet when the load is scoped to the instance's pod.
+	if err := i.subnet.Load(); err != nil {
+		return err
+	}
+
 	// Use a cached value if it exists.
 	if i.cached {
 		log.Debug("Skipping instance load, cached...")
@@ -368,6 +599,7 @@ func (i *instance) load() error {
 	}
 
 	var token *string
+	var shuttingDown *ec2.Instance
 	done := false
 
 	for !done {
@@ -419,10 +651,16 @@ func (i *instance) load() error {
 				if instance.Tags == nil {
 					break
 				}
-				// If the state is "shutting-down" or "terminated" skip.
-				if instance.State != nil && instance.State.Name != nil &&
-					(*instance.State.Name == "shutting-down" || *instance.State.Name == "terminated") {
-					log.Debug("Skipping instance state %s.", *instance.State.Name)
+				// Skip terminated instances. An instance shutting down is only
+				// used when there is no other instance with the same name.
+				switch instanceState(instance) {
+				case "terminated":
+					log.Debug("Skipping instance state terminated.")
+					continue
+				case "shutting-down":
+					if instanceTag(instance, "Name") == i.Name() {
+						shuttingDown = instance
+					}
 					continue
 				}
 				// See if the names match.
@@ -444,6 +682,11 @@ func (i *instance) load() error {
 			done = true
 		}
 	}
+	if shuttingDown != nil {
+		i.set(shuttingDown)
+		log.Debug("Loaded %s, %s, %s -- via name", i.Name(), i.Id(), i.State())
+		return i.loadVolumes()
+	}
 	log.Debug("Didn't find %s, skipping...", i.Name())
 	return nil
 }
@@ -491,7 +734,7 @@ func (i *instance) reloadStopped() bool {
 }
 
 func (i *instance) create(req *route.Request) route.Response {
-	if i.Created() {
+	if i.Created() && !i.adopted {
 		return route.OK
 	}
 
@@ -501,6 +744,10 @@ func (i *instance) create(req *route.Request) route.Response {
 	}
 	volumes := []*ec2.BlockDeviceMapping{}
 	for _, v := range i.volumes {
+		if err := v.encrypt(); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
 		if req.Flag("preserve_volume") {
 			if !v.Preserve() {
 				volumes = append(volumes, v.volumeParams)
@@ -510,18 +757,60 @@ func (i *instance) create(req *route.Request) route.Response {
 		}
 	}
 
+	if i.group != nil {
+		return i.claim()
+	}
+	if i.compute.instanceCache.interrupted(i.Name()) {
+		msg.Info("Replacing spot interrupted instance %s", i.Name())
+	}
+
 	params := &ec2.RunInstancesInput{
 		BlockDeviceMappings: volumes,
 		ImageId:             aws.String(i.ImageId()),
 		MaxCount:            aws.Int64(1),
 		MinCount:            aws.Int64(1),
-		InstanceType:        aws.String(i.InstanceType()),
 		KeyName:             aws.String(i.KeyName()),
 		SubnetId:            aws.String(i.subnet.Id()),
 		SecurityGroupIds:    securityGroupIds,
 	}
+	if i.userData != nil {
+		userData, err := i.encodeUserData()
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		params.UserData = aws.String(userData)
+	}
+	if i.Placement().Group() != "" {
+		params.Placement = &ec2.Placement{
+			GroupName: aws.String(placementGroupName(i.compute.Name(), i.Placement())),
+		}
+	}
+	if i.Purchasing().Spot() {
+		params.InstanceMarketOptions = &ec2.InstanceMarketOptionsRequest{
+			MarketType: aws.String("spot"),
+			SpotOptions: &ec2.SpotMarketOptions{
+				InstanceInterruptionBehavior: aws.String("terminate"),
+				SpotInstanceType:             aws.String("one-time"),
+			},
+		}
+		if i.Purchasing().MaxPrice() != "" {
+			params.InstanceMarketOptions.SpotOptions.MaxPrice = aws.String(i.Purchasing().MaxPrice())
+		}
+	}
 
-	reservation, err := i.ec2.RunInstances(params)
+	// Try the instance types in order until one has capacity.
+	var reservation *ec2.Reservation
+	var err error
+	types := i.InstanceTypes()
+	for n, t := range types {
+		params.InstanceType = aws.String(t)
+		reservation, err = i.runInstances(params)
+		if err == nil || n == len(types)-1 || !insufficientCapacity(err) {
+			break
+		}
+		msg.Warn("No %s capacity for %s, falling back to %s", t, i.Name(), types[n+1])
+	}
 	if err != nil {
 		msg.Error(err.Error())
 		return route.FAIL
@@ -537,19 +826,143 @@ func (i *instance) create(req *route.Request) route.Response {
 	return route.OK
 }
 
+// runInstancesInput is ec2.RunInstancesInput with the capacity reservation
+// specification, which the vendored aws sdk predates. It has the fields used
+// by create.
+type runInstancesInput struct {
+	_ struct{} `type:"structure"`
+
+	BlockDeviceMappings              []*ec2.BlockDeviceMapping         `locationName:"BlockDeviceMapping" locationNameList:"BlockDeviceMapping" type:"list"`
+	CapacityReservationSpecification *capacityReservationSpecification `type:"structure"`
+	ImageId                          *string                           `type:"string"`
+	InstanceMarketOptions            *ec2.InstanceMarketOptionsRequest `type:"structure"`
+	InstanceType                     *string                           `type:"string"`
+	KeyName                          *string                           `type:"string"`
+	MaxCount                         *int64                            `type:"integer"`
+	MinCount                         *int64                            `type:"integer"`
+	Placement                        *ec2.Placement                    `type:"structure"`
+	SecurityGroupIds                 []*string                         `locationName:"SecurityGroupId" locationNameList:"SecurityGroupId" type:"list"`
+	SubnetId                         *string                           `type:"string"`
+	UserData                         *string                           `type:"string"`
+}
+
+type capacityReservationSpecification struct {
+	_ struct{} `type:"structure"`
+
+	CapacityReservationTarget *capacityReservationTarget `type:"structure"`
+}
+
+type capacityReservationTarget struct {
+	_ struct{} `type:"structure"`
+
+	CapacityReservationId *string `type:"string"`
+}
+
+// runInstances runs the instance, launching it into the pod's capacity
+// reservation when the instance is reserved.
+func (i *instance) runInstances(params *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+	if !i.Purchasing().Reserved() {
+		return i.ec2.RunInstances(params)
+	}
+	input := &runInstancesInput{
+		BlockDeviceMappings: params.BlockDeviceMappings,
+		CapacityReservationSpecification: &capacityReservationSpecification{
+			CapacityReservationTarget: &capacityReservationTarget{
+				CapacityReservationId: aws.String(i.Purchasing().Reservation()),
+			},
+		},
+		ImageId:               params.ImageId,
+		InstanceMarketOptions: params.InstanceMarketOptions,
+		InstanceType:          params.InstanceType,
+		KeyName:               params.KeyName,
+		MaxCount:              params.MaxCount,
+		MinCount:              params.MinCount,
+		Placement:             params.Placement,
+		SecurityGroupIds:      params.SecurityGroupIds,
+		SubnetId:              params.SubnetId,
+		UserData:              params.UserData,
+	}
+	op := &request.Operation{Name: "RunInstances", HTTPMethod: "POST", HTTPPath: "/"}
+	reservation := &ec2.Reservation{}
+	if err := i.ec2.NewRequest(op, input, reservation).Send(); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// adopt takes an unclaimed instance of the autoscaling group when the instance
+// is loaded, so that info and audit show the instances the group launched on
+// its own. The adopted instance is named and set up when it is provisioned.
+func (i *instance) adopt() {
+	inst := i.group.claim(i.subnet)
+	if inst == nil {
+		return
+	}
+	log.Debug("Adopting autoscaling group instance %s for %s", aws.StringValue(inst.InstanceId), i.Name())
+	i.set(inst)
+	i.adopted = true
+}
+
+// Adopted satisfies the resource.DynamicInstance interface.
+func (i *instance) Adopted() bool {
+	return i.adopted
+}
+
+// claim takes an instance launched by the autoscaling group rather than
+// running a new instance.
+func (i *instance) claim() route.Response {
+	if i.adopted {
+		msg.Detail("Claiming autoscaling group instance %s", i.Id())
+		i.adopted = false
+		return route.OK
+	}
+	inst := i.group.claim(i.subnet)
+	if inst == nil {
+		msg.Error("No instance available in autoscaling group %s for %s", i.group.name, i.Name())
+		return route.FAIL
+	}
+	msg.Detail("Claiming autoscaling group instance %s", aws.StringValue(inst.InstanceId))
+	i.set(inst)
+	if !i.reloadStarted() {
+		return route.FAIL
+	}
+	return route.OK
+}
+
+// insufficientCapacity returns true if the error is due to a lack of capacity
+// for the requested instance type.
+func insufficientCapacity(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+	switch aerr.Code() {
+	case "InsufficientInstanceCapacity", "InsufficientCapacity", "SpotMaxPriceTooLow", "Unsupported":
+		return true
+	}
+	return false
+}
+
 func (i *instance) destroy(req *route.Request) route.Response {
 	if i.Destroyed() {
 		return route.OK
 	}
 
-	params := &ec2.TerminateInstancesInput{
-		InstanceIds: []*string{
-			aws.String(i.Id()),
-		},
-	}
-	if _, err := i.ec2.TerminateInstances(params); err != nil {
-		msg.Error(err.Error())
-		return route.FAIL
+	if i.group != nil {
+		if err := i.group.terminate(i.Id()); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+	} else {
+		params := &ec2.TerminateInstancesInput{
+			InstanceIds: []*string{
+				aws.String(i.Id()),
+			},
+		}
+		if _, err := i.ec2.TerminateInstances(params); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
 	}
 
 	i.clear()
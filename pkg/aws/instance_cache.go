@@ -43,15 +43,47 @@ type instanceCacheEntry struct {
 }
 
 type instanceCache struct {
-	cache   map[string]*instanceCacheEntry
-	unnamed []*ec2.Instance
+	compute         *compute
+	loaded          bool
+	cache           map[string]*instanceCacheEntry
+	unnamed         []*ec2.Instance
+	spotInterrupted map[string]*ec2.Instance
 }
 
-func newInstanceCache(c *compute) (*instanceCache, error) {
+// instanceDiskCache is the on disk representation of the instance cache.
+type instanceDiskCache struct {
+	Instances   map[string]*ec2.Instance
+	Unnamed     []*ec2.Instance
+	Interrupted map[string]*ec2.Instance
+}
+
+func newInstanceCache(c *compute) *instanceCache {
 	log.Debug("Initializing AWS Instance Cache")
+	return &instanceCache{
+		compute:         c,
+		cache:           map[string]*instanceCacheEntry{},
+		spotInterrupted: map[string]*ec2.Instance{},
+	}
+}
 
-	i := &instanceCache{
-		cache: map[string]*instanceCacheEntry{},
+// load populates the instance cache. If names are given only those instances
+// are cached, otherwise all the instances in the datacenter are cached. When
+// readOnly is set a recent copy of the cache saved to disk may be used.
+func (c *instanceCache) load(names []string, readOnly bool) error {
+	if c.loaded {
+		log.Debug("Skipping instance cache load, loaded...")
+		return nil
+	}
+	log.Debug("Loading AWS Instance Cache")
+
+	scoped := len(names) > 0
+	diskCache := c.compute.cacheName("instances")
+	if !scoped && !readOnly {
+		removeDiskCache(diskCache)
+	}
+	if !scoped && readOnly && c.readDiskCache(diskCache) {
+		c.loaded = true
+		return nil
 	}
 
 	var token *string
@@ -59,12 +91,25 @@ func newInstanceCache(c *compute) (*instanceCache, error) {
 
 	for !done {
 		params := &ec2.DescribeInstancesInput{
-			MaxResults: aws.Int64(1000),
-			NextToken:  token,
+			NextToken: token,
+		}
+		if scoped {
+			params.Filters = []*ec2.Filter{
+				{
+					Name:   aws.String("tag:DataCenter"),
+					Values: []*string{aws.String(c.compute.Name())},
+				},
+				{
+					Name:   aws.String("tag:Name"),
+					Values: aws.StringSlice(names),
+				},
+			}
+		} else {
+			params.MaxResults = aws.Int64(1000)
 		}
-		resp, err := c.ec2.DescribeInstances(params)
+		resp, err := c.compute.ec2.DescribeInstances(params)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		log.Debug("Load Reservations: %d", len(resp.Reservations))
@@ -80,8 +125,14 @@ func newInstanceCache(c *compute) (*instanceCache, error) {
 					continue
 				}
 
-				if inst.State != nil && inst.State.Name != nil && *inst.State.Name != "running" {
-					log.Verbose("Non-running instance")
+				// Terminated instances no longer count. Instances being terminated
+				// are kept so they aren't launched again until they are gone.
+				switch instanceState(inst) {
+				case "shutting-down":
+					c.noteInterruption(inst)
+				case "terminated":
+					c.noteInterruption(inst)
+					log.Verbose("Terminated instance")
 					if inst.InstanceId != nil {
 						log.Verbose("\t\t%s", *inst.InstanceId)
 					}
@@ -93,7 +144,7 @@ func newInstanceCache(c *compute) (*instanceCache, error) {
 					if inst.InstanceId != nil {
 						log.Verbose("\t\t%s", *inst.InstanceId)
 					}
-					i.unnamed = append(i.unnamed, inst)
+					c.unnamed = append(c.unnamed, inst)
 					continue
 				}
 
@@ -125,12 +176,21 @@ func newInstanceCache(c *compute) (*instanceCache, error) {
 					continue
 				}
 
-				if dc != c.Compute.Name() {
+				if dc != c.compute.Name() {
 					continue
 				}
 
-				log.Debug("Caching instance %s", name)
-				i.cache[name] = &instanceCacheEntry{deployed: inst}
+				// Prefer a running instance, and any instance over one shutting down,
+				// if more than one instance has the same name.
+				if e := c.cache[name]; e != nil {
+					log.Warn("Multiple instances named %s: %s, %s", name, aws.StringValue(e.deployed.InstanceId), aws.StringValue(inst.InstanceId))
+					if instanceState(e.deployed) == "running" || instanceState(inst) == "shutting-down" {
+						continue
+					}
+				}
+
+				log.Debug("Caching instance %s, %s", name, instanceState(inst))
+				c.cache[name] = &instanceCacheEntry{deployed: inst}
 			}
 		}
 
@@ -140,8 +200,39 @@ func newInstanceCache(c *compute) (*instanceCache, error) {
 			done = true
 		}
 	}
+	c.loaded = true
 
-	return i, nil
+	if !scoped && readOnly {
+		c.writeDiskCache(diskCache)
+	}
+	return nil
+}
+
+func (c *instanceCache) readDiskCache(name string) bool {
+	d := &instanceDiskCache{}
+	if !readDiskCache(name, d) {
+		return false
+	}
+	for k, v := range d.Instances {
+		c.cache[k] = &instanceCacheEntry{deployed: v}
+	}
+	c.unnamed = d.Unnamed
+	if d.Interrupted != nil {
+		c.spotInterrupted = d.Interrupted
+	}
+	return true
+}
+
+func (c *instanceCache) writeDiskCache(name string) {
+	d := &instanceDiskCache{
+		Instances:   map[string]*ec2.Instance{},
+		Unnamed:     c.unnamed,
+		Interrupted: c.spotInterrupted,
+	}
+	for k, v := range c.cache {
+		d.Instances[k] = v.deployed
+	}
+	writeDiskCache(name, d)
 }
 
 func (c *instanceCache) find(i *instance) *ec2.Instance {
@@ -153,6 +244,29 @@ func (c *instanceCache) find(i *instance) *ec2.Instance {
 	return e.deployed
 }
 
+// noteInterruption records terminated spot instances that were reclaimed
+// by aws so they can be reported by audit and replaced by create.
+func (c *instanceCache) noteInterruption(inst *ec2.Instance) {
+	if aws.StringValue(inst.InstanceLifecycle) != "spot" || inst.StateReason == nil {
+		return
+	}
+	if aws.StringValue(inst.StateReason.Code) != "Server.SpotInstanceTermination" {
+		return
+	}
+	name := instanceTag(inst, "Name")
+	if name == "" || instanceTag(inst, "DataCenter") != c.compute.Name() {
+		return
+	}
+	log.Debug("Spot instance %s, %s was interrupted", name, aws.StringValue(inst.InstanceId))
+	c.spotInterrupted[name] = inst
+}
+
+// interrupted returns true if the last instance with the given name was a
+// spot instance reclaimed by aws.
+func (c *instanceCache) interrupted(name string) bool {
+	return c.cache[name] == nil && c.spotInterrupted[name] != nil
+}
+
 func (c *instanceCache) remove(i *instance) {
 	log.Debug("Deleting %s from instanceCache", i.Name())
 	delete(c.cache, i.Name())
@@ -175,6 +289,10 @@ func (c *instanceCache) audit(flags ...string) error {
 			}
 		}
 		if !skip && v.configured == nil {
+			if state := instanceState(v.deployed); state != "running" {
+				a.Audit(aaa.Deployed, "%s, %s", k, state)
+				continue
+			}
 			a.Audit(aaa.Deployed, "%s", k)
 		}
 	}
@@ -188,3 +306,20 @@ func (c *instanceCache) audit(flags ...string) error {
 	}
 	return nil
 }
+
+func instanceState(i *ec2.Instance) string {
+	if i == nil || i.State == nil || i.State.Name == nil {
+		return ""
+	}
+	return *i.State.Name
+}
+
+// instanceTag returns the value of the instance's tag with the given key.
+func instanceTag(i *ec2.Instance, key string) string {
+	for _, t := range i.Tags {
+		if t != nil && aws.StringValue(t.Key) == key {
+			return aws.StringValue(t.Value)
+		}
+	}
+	return ""
+}
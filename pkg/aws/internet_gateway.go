@@ -190,7 +190,7 @@ func (i *internetGateway) create(req *route.Request) route.Response {
 	msg.Info("InternetGateway Creation: %s", i.name())
 	if i.Created() {
 		msg.Detail("InternetGateway exists, skipping...")
-		return route.OK
+		return i.createIpv6Routes(req)
 	}
 	resp, err := i.ec2.CreateInternetGateway(&ec2.CreateInternetGatewayInput{})
 	if err != nil {
@@ -223,6 +223,22 @@ func (i *internetGateway) create(req *route.Request) route.Response {
 		}
 	}
 
+	return i.createIpv6Routes(req)
+}
+
+// createIpv6Routes routes ipv6 traffic from the public route tables of a dual stack
+// network to the internet gateway.
+func (i *internetGateway) createIpv6Routes(req *route.Request) route.Response {
+	if !i.network.Ipv6() {
+		return route.OK
+	}
+	for _, routeTable := range i.network.routeTables.routeTables {
+		if routeTable.access() == "public" || routeTable.access() == "public_elastic" {
+			if resp := routeTable.createRoute(req, "::/0", igw, i); resp != route.OK {
+				return resp
+			}
+		}
+	}
 	return route.OK
 }
 
@@ -238,6 +254,12 @@ func (i *internetGateway) destroy(req *route.Request) route.Response {
 			if resp := routeTable.deleteRoute(req, "0.0.0.0/0"); resp != route.OK {
 				return resp
 			}
+			if !i.network.Ipv6() {
+				continue
+			}
+			if resp := routeTable.deleteRoute(req, "::/0"); resp != route.OK {
+				return resp
+			}
 		}
 	}
 
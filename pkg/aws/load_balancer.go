@@ -0,0 +1,629 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// loadBalancer implements the resource.ProviderLoadBalancer interface. The
+// load balancer is named after the datacenter and the pod, and has a target
+// group for each of the instance ports its listeners forward to, named after
+// the load balancer and the port.
+type loadBalancer struct {
+	*config.LoadBalancer
+	pod   resource.Pod
+	name  string
+	elbv2 *elbv2.ELBV2
+
+	lb           *elbv2.LoadBalancer
+	targetGroups map[int]*elbv2.TargetGroup
+	listeners    map[int]*elbv2.Listener
+}
+
+// newLoadBalancer constructs the aws load balancer.
+func newLoadBalancer(pod resource.Pod, cfg *config.Pod, p *dataCenterProvider) (*loadBalancer, error) {
+	log.Debug("Initializing AWS Load Balancer %q", cfg.Name())
+	l := &loadBalancer{
+		LoadBalancer: cfg.LoadBalancer(),
+		pod:          pod,
+		name:         pod.Cluster().Compute().Name() + "-" + cfg.Name(),
+		elbv2:        p.elbv2,
+		targetGroups: map[int]*elbv2.TargetGroup{},
+		listeners:    map[int]*elbv2.Listener{},
+	}
+	// Load balancer and target group names are limited to 32 characters.
+	for _, port := range l.TargetPorts() {
+		if n := l.targetGroupName(port); len(n) > 32 {
+			return nil, fmt.Errorf("AWS newLoadBalancer: The target group name %s is longer than 32 characters", n)
+		}
+	}
+	return l, nil
+}
+
+func (l *loadBalancer) targetGroupName(port int) string {
+	return l.name + "-" + strconv.Itoa(port)
+}
+
+func (l *loadBalancer) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS Load Balancer %q", l.name)
+
+	switch req.Command() {
+	case route.Load:
+		if err := l.load(); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Info:
+		l.info()
+		return route.OK
+	case route.Create:
+		return l.create(req)
+	case route.Destroy:
+		return l.destroy(req)
+	}
+	return route.OK
+}
+
+func (l *loadBalancer) Created() bool {
+	return l.lb != nil
+}
+
+func (l *loadBalancer) Destroyed() bool {
+	return l.lb == nil
+}
+
+func (l *loadBalancer) Id() string {
+	if l.lb == nil {
+		return ""
+	}
+	return aws.StringValue(l.lb.LoadBalancerArn)
+}
+
+func (l *loadBalancer) DNSName() string {
+	if l.lb == nil {
+		return ""
+	}
+	return aws.StringValue(l.lb.DNSName)
+}
+
+func (l *loadBalancer) HostedZoneId() string {
+	if l.lb == nil {
+		return ""
+	}
+	return aws.StringValue(l.lb.CanonicalHostedZoneId)
+}
+
+func (l *loadBalancer) load() error {
+	l.lb = nil
+	l.targetGroups = map[int]*elbv2.TargetGroup{}
+	l.listeners = map[int]*elbv2.Listener{}
+
+	resp, err := l.elbv2.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		Names: []*string{aws.String(l.name)},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != elbv2.ErrCodeLoadBalancerNotFoundException {
+			return err
+		}
+	} else if len(resp.LoadBalancers) > 0 {
+		l.lb = resp.LoadBalancers[0]
+	}
+
+	// Target groups are loaded by name, since they may outlive a failed create.
+	for _, port := range l.TargetPorts() {
+		resp, err := l.elbv2.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+			Names: []*string{aws.String(l.targetGroupName(port))},
+		})
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elbv2.ErrCodeTargetGroupNotFoundException {
+				continue
+			}
+			return err
+		}
+		if len(resp.TargetGroups) > 0 {
+			l.targetGroups[port] = resp.TargetGroups[0]
+		}
+	}
+
+	if l.lb == nil {
+		return nil
+	}
+	listeners, err := l.elbv2.DescribeListeners(&elbv2.DescribeListenersInput{
+		LoadBalancerArn: l.lb.LoadBalancerArn,
+	})
+	if err != nil {
+		return err
+	}
+	for _, listener := range listeners.Listeners {
+		l.listeners[int(aws.Int64Value(listener.Port))] = listener
+	}
+	return nil
+}
+
+func (l *loadBalancer) create(req *route.Request) route.Response {
+	msg.Info("Load Balancer Create: %s", l.name)
+	net := l.pod.Cluster().Compute().DataCenter().Network()
+
+	if !l.Created() {
+		subnetGroup := net.SubnetGroups().Find(l.subnetGroup())
+		if subnetGroup == nil {
+			msg.Error("Load balancer %s: Unknown subnet group %s", l.name, l.subnetGroup())
+			return route.FAIL
+		}
+		subnets := []*string{}
+		for _, s := range subnetGroup.Subnets() {
+			subnets = append(subnets, aws.String(s.Id()))
+		}
+		params := &elbv2.CreateLoadBalancerInput{
+			Name:    aws.String(l.name),
+			Type:    aws.String(l.Type()),
+			Scheme:  aws.String(l.Scheme()),
+			Subnets: subnets,
+			Tags: []*elbv2.Tag{
+				{Key: aws.String("DataCenter"), Value: aws.String(req.DataCenter())},
+				{Key: aws.String("Pod"), Value: aws.String(l.pod.Name())},
+			},
+		}
+		for _, name := range l.SecurityGroups() {
+			sg := net.SecurityGroups().Find(name)
+			if sg == nil {
+				msg.Error("Load balancer %s: Unknown security group %s", l.name, name)
+				return route.FAIL
+			}
+			params.SecurityGroups = append(params.SecurityGroups, aws.String(sg.Id()))
+		}
+		resp, err := l.elbv2.CreateLoadBalancer(params)
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		if len(resp.LoadBalancers) > 0 {
+			l.lb = resp.LoadBalancers[0]
+		}
+		msg.Detail("Created: %s", l.DNSName())
+		aaa.Accounting("Load balancer created: %s", l.Id())
+	}
+
+	vpcId := ""
+	if n, ok := net.ProviderNetwork().(*network); ok {
+		vpcId = n.vpc.id()
+	}
+	for _, port := range l.TargetPorts() {
+		if l.targetGroups[port] != nil {
+			continue
+		}
+		if err := l.createTargetGroup(port, vpcId); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+	}
+
+	for _, listener := range l.Listeners() {
+		if l.listeners[listener.Port()] != nil {
+			continue
+		}
+		if err := l.createListener(listener); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+	}
+
+	// Instances created before the load balancer are registered now, later
+	// instances register themselves when they are created.
+	for _, i := range l.pod.Instances().GetInstances() {
+		if i.Created() {
+			if err := l.Register(i); err != nil {
+				msg.Error(err.Error())
+				return route.FAIL
+			}
+		}
+	}
+	return route.OK
+}
+
+// subnetGroup returns the name of the subnet group the load balancer is placed in.
+func (l *loadBalancer) subnetGroup() string {
+	if l.SubnetGroup() != "" {
+		return l.SubnetGroup()
+	}
+	return l.pod.SubnetGroup()
+}
+
+// listenerFor returns the first listener forwarding to the port.
+func (l *loadBalancer) listenerFor(port int) *config.Listener {
+	for _, listener := range l.Listeners() {
+		if listener.TargetPort() == port {
+			return listener
+		}
+	}
+	return nil
+}
+
+func (l *loadBalancer) createTargetGroup(port int, vpcId string) error {
+	name := l.targetGroupName(port)
+	msg.Detail("Target group: %s", name)
+
+	h := l.HealthCheck()
+	params := &elbv2.CreateTargetGroupInput{
+		Name:                       aws.String(name),
+		Port:                       aws.Int64(int64(port)),
+		Protocol:                   aws.String(l.listenerFor(port).TargetProtocol()),
+		VpcId:                      aws.String(vpcId),
+		TargetType:                 aws.String("instance"),
+		HealthCheckIntervalSeconds: aws.Int64(int64(h.Interval())),
+		HealthyThresholdCount:      aws.Int64(int64(h.Healthy())),
+		UnhealthyThresholdCount:    aws.Int64(int64(h.Unhealthy())),
+	}
+	protocol := h.Protocol()
+	if protocol == "" {
+		protocol = aws.StringValue(params.Protocol)
+	}
+	params.HealthCheckProtocol = aws.String(protocol)
+	if protocol == "HTTP" || protocol == "HTTPS" {
+		params.HealthCheckPath = aws.String(h.Path())
+	}
+	if h.Port() != 0 {
+		params.HealthCheckPort = aws.String(strconv.Itoa(h.Port()))
+	}
+	resp, err := l.elbv2.CreateTargetGroup(params)
+	if err != nil {
+		return err
+	}
+	if len(resp.TargetGroups) == 0 {
+		return fmt.Errorf("Target group %s wasn't created", name)
+	}
+	tg := resp.TargetGroups[0]
+	l.targetGroups[port] = tg
+	aaa.Accounting("Target group created: %s", aws.StringValue(tg.TargetGroupArn))
+
+	// Deregistered instances are given the draining period to complete their connections.
+	_, err = l.elbv2.ModifyTargetGroupAttributes(&elbv2.ModifyTargetGroupAttributesInput{
+		TargetGroupArn: tg.TargetGroupArn,
+		Attributes: []*elbv2.TargetGroupAttribute{
+			{
+				Key:   aws.String("deregistration_delay.timeout_seconds"),
+				Value: aws.String(strconv.Itoa(l.Draining())),
+			},
+		},
+	})
+	return err
+}
+
+func (l *loadBalancer) createListener(listener *config.Listener) error {
+	msg.Detail("Listener: %s %d", listener.Protocol(), listener.Port())
+	params := &elbv2.CreateListenerInput{
+		LoadBalancerArn: l.lb.LoadBalancerArn,
+		Port:            aws.Int64(int64(listener.Port())),
+		Protocol:        aws.String(listener.Protocol()),
+		DefaultActions: []*elbv2.Action{
+			{
+				Type:           aws.String("forward"),
+				TargetGroupArn: l.targetGroups[listener.TargetPort()].TargetGroupArn,
+			},
+		},
+	}
+	if listener.Certificate() != "" {
+		params.Certificates = []*elbv2.Certificate{{CertificateArn: aws.String(listener.Certificate())}}
+	}
+	resp, err := l.elbv2.CreateListener(params)
+	if err != nil {
+		return err
+	}
+	if len(resp.Listeners) > 0 {
+		l.listeners[listener.Port()] = resp.Listeners[0]
+	}
+	return nil
+}
+
+// targetGroupArns returns the arns of the created target groups.
+func (l *loadBalancer) targetGroupArns() []*string {
+	arns := []*string{}
+	for _, port := range l.TargetPorts() {
+		if tg := l.targetGroups[port]; tg != nil {
+			arns = append(arns, tg.TargetGroupArn)
+		}
+	}
+	return arns
+}
+
+// Register adds the instance to each of the target groups.
+func (l *loadBalancer) Register(i resource.Instance) error {
+	if l.Destroyed() || i.Id() == "" {
+		return nil
+	}
+	msg.Info("Register Instance %s with Load Balancer %s", i.Name(), l.name)
+	for _, port := range l.TargetPorts() {
+		tg := l.targetGroups[port]
+		if tg == nil {
+			continue
+		}
+		if _, err := l.elbv2.RegisterTargets(&elbv2.RegisterTargetsInput{
+			TargetGroupArn: tg.TargetGroupArn,
+			Targets:        []*elbv2.TargetDescription{{Id: aws.String(i.Id())}},
+		}); err != nil {
+			return err
+		}
+	}
+	aaa.Accounting("Instance %s, %s registered with load balancer %s", i.Name(), i.Id(), l.name)
+	return nil
+}
+
+// Deregister removes the instance from each of the target groups and waits for
+// its connections to drain.
+func (l *loadBalancer) Deregister(i resource.Instance) error {
+	if l.Destroyed() || i.Id() == "" {
+		return nil
+	}
+	msg.Info("Deregister Instance %s from Load Balancer %s", i.Name(), l.name)
+	for _, port := range l.TargetPorts() {
+		tg := l.targetGroups[port]
+		if tg == nil {
+			continue
+		}
+		if _, err := l.elbv2.DeregisterTargets(&elbv2.DeregisterTargetsInput{
+			TargetGroupArn: tg.TargetGroupArn,
+			Targets:        []*elbv2.TargetDescription{{Id: aws.String(i.Id())}},
+		}); err != nil {
+			return err
+		}
+	}
+	aaa.Accounting("Instance %s, %s deregistered from load balancer %s", i.Name(), i.Id(), l.name)
+
+	draining := true
+	if !msg.Wait(
+		fmt.Sprintf("Waiting for the connections to Instance %s to drain", i.Name()), // title
+		fmt.Sprintf("The connections to Instance %s never drained", i.Name()),        // err
+		l.Draining()+60,                  // duration
+		func() bool { return !draining }, // test()
+		func() bool {
+			var err error
+			draining, err = l.draining(i.Id())
+			if err != nil {
+				msg.Error(err.Error())
+				return false
+			}
+			return true
+		},
+	) {
+		return fmt.Errorf("Failed to deregister instance %s from load balancer %s", i.Name(), l.name)
+	}
+	return nil
+}
+
+// draining returns true while the instance is draining from any of the target groups.
+func (l *loadBalancer) draining(id string) (bool, error) {
+	for _, tg := range l.targetGroups {
+		resp, err := l.elbv2.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: tg.TargetGroupArn,
+			Targets:        []*elbv2.TargetDescription{{Id: aws.String(id)}},
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, t := range resp.TargetHealthDescriptions {
+			if t.TargetHealth != nil && aws.StringValue(t.TargetHealth.State) == elbv2.TargetHealthStateEnumDraining {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// targetHealth returns the health of the targets of the target group.
+func (l *loadBalancer) targetHealth(tg *elbv2.TargetGroup) ([]*elbv2.TargetHealthDescription, error) {
+	resp, err := l.elbv2.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: tg.TargetGroupArn,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.TargetHealthDescriptions, nil
+}
+
+// instanceName returns the name of the pod's instance with the id, or the id
+// if it isn't one of the pod's instances.
+func (l *loadBalancer) instanceName(id string) string {
+	for _, i := range l.pod.Instances().GetInstances() {
+		if i.Id() == id {
+			return i.Name()
+		}
+	}
+	return id
+}
+
+func (l *loadBalancer) destroy(req *route.Request) route.Response {
+	if l.Created() {
+		msg.Info("Load Balancer Destroy: %s", l.name)
+		id := l.Id()
+		if _, err := l.elbv2.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{
+			LoadBalancerArn: l.lb.LoadBalancerArn,
+		}); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		// The target groups can only be deleted once the load balancer is gone.
+		if !msg.Wait(
+			fmt.Sprintf("Waiting for Load Balancer %s to delete", l.name), // title
+			fmt.Sprintf("Load Balancer %s never deleted", l.name),         // err
+			300,                                  // duration
+			func() bool { return l.Destroyed() }, // test()
+			func() bool {
+				if err := l.load(); err != nil {
+					msg.Error(err.Error())
+					return false
+				}
+				return true
+			},
+		) {
+			return route.FAIL
+		}
+		msg.Detail("Destroyed: %s", id)
+		aaa.Accounting("Load balancer destroyed: %s", id)
+	}
+
+	for port, tg := range l.targetGroups {
+		msg.Detail("Target group destroy: %s", aws.StringValue(tg.TargetGroupName))
+		if _, err := l.elbv2.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{
+			TargetGroupArn: tg.TargetGroupArn,
+		}); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		delete(l.targetGroups, port)
+		aaa.Accounting("Target group destroyed: %s", aws.StringValue(tg.TargetGroupArn))
+	}
+	return route.OK
+}
+
+func (l *loadBalancer) info() {
+	if l.Destroyed() {
+		return
+	}
+	msg.Info("Load Balancer")
+	msg.Detail("%-20s\t%s", "name", l.name)
+	msg.Detail("%-20s\t%s", "type", aws.StringValue(l.lb.Type))
+	msg.Detail("%-20s\t%s", "scheme", aws.StringValue(l.lb.Scheme))
+	msg.Detail("%-20s\t%s", "dns name", l.DNSName())
+	if l.lb.State != nil {
+		msg.Detail("%-20s\t%s", "state", aws.StringValue(l.lb.State.Code))
+	}
+	for _, listener := range l.Listeners() {
+		msg.Detail("%-20s\t%s %d -> %d", "listener", listener.Protocol(), listener.Port(), listener.TargetPort())
+	}
+	msg.IndentInc()
+	for _, port := range l.TargetPorts() {
+		tg := l.targetGroups[port]
+		if tg == nil {
+			continue
+		}
+		msg.Info("Target Group")
+		msg.Detail("%-20s\t%s", "name", aws.StringValue(tg.TargetGroupName))
+		msg.Detail("%-20s\t%s %d", "target", aws.StringValue(tg.Protocol), port)
+		health, err := l.targetHealth(tg)
+		if err != nil {
+			msg.Warn(err.Error())
+			continue
+		}
+		for _, t := range health {
+			if t.Target == nil || t.TargetHealth == nil {
+				continue
+			}
+			state := aws.StringValue(t.TargetHealth.State)
+			if reason := aws.StringValue(t.TargetHealth.Reason); reason != "" {
+				state += ", " + reason
+			}
+			msg.Detail("%-20s\t%s", l.instanceName(aws.StringValue(t.Target.Id)), state)
+		}
+	}
+	msg.IndentDec()
+}
+
+// Audit checks the load balancer, its listeners and target groups are in place,
+// the pod's instances are registered and the targets are healthy.
+func (l *loadBalancer) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil {
+		return fmt.Errorf("Audit Object does not exist")
+	}
+	if l.Destroyed() {
+		a.Audit(aaa.Configured, "Load Balancer %s", l.name)
+		return nil
+	}
+	if t := aws.StringValue(l.lb.Type); t != l.Type() {
+		a.Audit(aaa.Mismatched, "Load Balancer %q | Configured Type: %q - Deployed Type: %q", l.name, l.Type(), t)
+	}
+	if s := aws.StringValue(l.lb.Scheme); s != l.Scheme() {
+		a.Audit(aaa.Mismatched, "Load Balancer %q | Configured Scheme: %q - Deployed Scheme: %q", l.name, l.Scheme(), s)
+	}
+	for _, listener := range l.Listeners() {
+		deployed := l.listeners[listener.Port()]
+		if deployed == nil {
+			a.Audit(aaa.Configured, "Load Balancer %s Listener %s %d", l.name, listener.Protocol(), listener.Port())
+			continue
+		}
+		if p := aws.StringValue(deployed.Protocol); p != listener.Protocol() {
+			a.Audit(aaa.Mismatched, "Load Balancer %q Listener %d | Configured Protocol: %q - Deployed Protocol: %q", l.name, listener.Port(), listener.Protocol(), p)
+		}
+	}
+	for port := range l.listeners {
+		found := false
+		for _, listener := range l.Listeners() {
+			found = found || listener.Port() == port
+		}
+		if !found {
+			a.Audit(aaa.Deployed, "Load Balancer %s Listener %d", l.name, port)
+		}
+	}
+
+	for _, port := range l.TargetPorts() {
+		tg := l.targetGroups[port]
+		if tg == nil {
+			a.Audit(aaa.Configured, "Load Balancer %s Target Group %s", l.name, l.targetGroupName(port))
+			continue
+		}
+		health, err := l.targetHealth(tg)
+		if err != nil {
+			return err
+		}
+		registered := map[string]bool{}
+		for _, t := range health {
+			if t.Target == nil || t.TargetHealth == nil {
+				continue
+			}
+			id := aws.StringValue(t.Target.Id)
+			registered[id] = true
+			if state := aws.StringValue(t.TargetHealth.State); state != elbv2.TargetHealthStateEnumHealthy {
+				a.Audit(aaa.Mismatched, "Load Balancer %s Target Group %s | Instance %s is %s: %s", l.name, aws.StringValue(tg.TargetGroupName),
+					l.instanceName(id), state, strings.TrimSpace(aws.StringValue(t.TargetHealth.Description)))
+			}
+		}
+		for _, i := range l.pod.Instances().GetInstances() {
+			if i.Created() && !registered[i.Id()] {
+				a.Audit(aaa.Mismatched, "Load Balancer %s Target Group %s | Instance %s is not registered", l.name, aws.StringValue(tg.TargetGroupName), i.Name())
+			}
+		}
+	}
+	return nil
+}
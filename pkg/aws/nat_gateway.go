@@ -42,6 +42,7 @@ import (
 
 type natGateway struct {
 	network           *network
+	natGateways       *natGateways
 	ec2               *ec2.EC2
 	name_             string
 	availabilityZone_ string
@@ -293,6 +294,10 @@ func (n *natGateway) reload() bool {
 }
 
 func (n *natGateway) create(req *route.Request) route.Response {
+	if !n.natGateways.wanted(n) {
+		log.Debug("NatGateway %s not needed by the %s topology, skipping", n.name(), n.natGateways.topology)
+		return route.OK
+	}
 	if n.Created() {
 		msg.Info("NatGateway Creation: %s", n.name())
 		msg.Detail("NatGateway exists, skipping...")
@@ -334,8 +339,8 @@ func (n *natGateway) create(req *route.Request) route.Response {
 	aaa.Accounting("natGateway created: %s", n.id())
 
 	for _, routeTable := range n.network.routeTables.routeTables {
-		if routeTable.access() == "private" && n.availabilityZone() == routeTable.availabilityZone() {
-			if resp := routeTable.createRoute(req, "0.0.0.0/0", ngw, n); resp != route.OK {
+		if routeTable.access() == "private" && n.natGateways.target(routeTable) == n {
+			if resp := routeTable.provisionRoute(req, "0.0.0.0/0", ngw, n); resp != route.OK {
 				return resp
 			}
 		}
@@ -354,7 +359,7 @@ func (n *natGateway) destroy(req *route.Request) route.Response {
 	allocationId := n.allocationId()
 
 	for _, routeTable := range n.network.routeTables.routeTables {
-		if routeTable.access() == "private" && n.availabilityZone() == routeTable.availabilityZone() {
+		if routeTable.access() == "private" && routeTable.routesTo("0.0.0.0/0", n.id()) {
 			if resp := routeTable.deleteRoute(req, "0.0.0.0/0"); resp != route.OK {
 				return resp
 			}
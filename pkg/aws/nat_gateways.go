@@ -27,6 +27,8 @@
 package aws
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/service/ec2"
 
 	"github.com/cisco/arc/pkg/help"
@@ -38,7 +40,10 @@ import (
 
 type natGateways struct {
 	*resource.Resources
+	network     *network
+	topology    string
 	natGateways map[string]*natGateway
+	shared      *natGateway
 }
 
 func newNatGateways(c *ec2.EC2, n resource.Network) (*natGateways, error) {
@@ -56,8 +61,22 @@ func newNatGateways(c *ec2.EC2, n resource.Network) (*natGateways, error) {
 		return nil, nil
 	}
 
+	if n.NatGateways() == "none" && n.SubnetGroups().Find("public") == nil {
+		log.Debug("AWS NatGateways not needed. No nat gateway topology and no public subnets.")
+		return nil, nil
+	}
+
+	net, ok := n.ProviderNetwork().(*network)
+	if !ok {
+		return nil, fmt.Errorf("AWS newNatGateways: Unable to obtain provider network")
+	}
+
+	// A nat gateway is initialized for every availability zone, whatever the topology,
+	// so nat gateways left over from a previous topology can be found and removed.
 	ngws := &natGateways{
 		Resources:   resource.NewResources(),
+		network:     net,
+		topology:    n.NatGateways(),
 		natGateways: map[string]*natGateway{},
 	}
 	for _, availabilityZone := range n.AvailabilityZones() {
@@ -66,6 +85,10 @@ func newNatGateways(c *ec2.EC2, n resource.Network) (*natGateways, error) {
 		if err != nil {
 			return nil, err
 		}
+		ngw.natGateways = ngws
+		if ngws.shared == nil {
+			ngws.shared = ngw
+		}
 		ngws.natGateways[name] = ngw
 		ngws.Append(ngw)
 	}
@@ -91,6 +114,8 @@ func (n *natGateways) Route(req *route.Request) route.Response {
 	switch req.Command() {
 	case route.Load, route.Create:
 		return n.RouteInOrder(req)
+	case route.Provision:
+		return n.provision(req)
 	case route.Destroy:
 		return n.RouteReverseOrder(req)
 	case route.Help:
@@ -107,10 +132,79 @@ func (n *natGateways) find(s string) *natGateway {
 	return n.natGateways[s]
 }
 
+// wanted returns true if the topology calls for the nat gateway.
+func (n *natGateways) wanted(ngw *natGateway) bool {
+	switch n.topology {
+	case "single":
+		return ngw == n.shared
+	case "none":
+		return false
+	}
+	return true
+}
+
+// target returns the nat gateway the private route table sends its
+// default route to, or nil if the topology has no nat gateways.
+func (n *natGateways) target(rt *routeTable) *natGateway {
+	switch n.topology {
+	case "single":
+		return n.shared
+	case "none":
+		return nil
+	}
+	return n.natGateways["private-"+rt.availabilityZone()]
+}
+
+// provision moves the network to the configured topology. The wanted nat gateways
+// are created first and the private default routes are replaced to point at them,
+// so egress keeps working, before the nat gateways no longer needed are destroyed.
+func (n *natGateways) provision(req *route.Request) route.Response {
+	msg.Info("NatGateways Provision: %s topology", n.topology)
+
+	for _, natGateway := range n.natGateways {
+		if !n.wanted(natGateway) {
+			continue
+		}
+		if resp := natGateway.create(req); resp != route.OK {
+			return resp
+		}
+	}
+
+	for _, rt := range n.network.routeTables.routeTables {
+		if rt.access() != "private" {
+			continue
+		}
+		if natGateway := n.target(rt); natGateway != nil {
+			if resp := rt.provisionRoute(req, "0.0.0.0/0", ngw, natGateway); resp != route.OK {
+				return resp
+			}
+			continue
+		}
+		for _, natGateway := range n.natGateways {
+			if natGateway.Created() && rt.routesTo("0.0.0.0/0", natGateway.id()) {
+				if resp := rt.deleteRoute(req, "0.0.0.0/0"); resp != route.OK {
+					return resp
+				}
+			}
+		}
+	}
+
+	for _, natGateway := range n.natGateways {
+		if n.wanted(natGateway) || natGateway.Destroyed() {
+			continue
+		}
+		if resp := natGateway.destroy(req); resp != route.OK {
+			return resp
+		}
+	}
+	return route.OK
+}
+
 func (n *natGateways) help() {
 	commands := []help.Command{
 		{Name: "'name'", Desc: "manage named nat gateways"},
 		{Name: route.Create.String(), Desc: "create all nat gateways"},
+		{Name: route.Provision.String(), Desc: "update the nat gateways to the configured topology"},
 		{Name: route.Destroy.String(), Desc: "destroy all nat gateways"},
 		{Name: route.Info.String(), Desc: "show information about all allocated nat gateways"},
 		{Name: route.Help.String(), Desc: "show this help"},
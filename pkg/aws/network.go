@@ -27,11 +27,15 @@
 package aws
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/aws/aws-sdk-go/service/ec2"
 
 	"github.com/cisco/arc/pkg/config"
 	"github.com/cisco/arc/pkg/help"
 	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
 	"github.com/cisco/arc/pkg/resource"
 	"github.com/cisco/arc/pkg/route"
 )
@@ -45,17 +49,71 @@ type network struct {
 	vpc             *vpc
 	routeTables     *routeTables
 	internetGateway *internetGateway
+	eigw            *egressOnlyGateway
 	subnetCache     *subnetCache
 	secgroupCache   *securityGroupCache
+	cachesLoaded    bool
+
+	provider *dataCenterProvider
+	key      string
+	base     *network
+	targets  map[string]*network
 }
 
 // newNetwork constructs the aws network.
-func newNetwork(cfg *config.Network, c *ec2.EC2) (*network, error) {
+func newNetwork(cfg *config.Network, p *dataCenterProvider) (*network, error) {
 	log.Debug("Initializing AWS Network")
+	n, err := newProviderNetwork(cfg, p, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.validateTargets(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// validateTargets checks the subnet groups pinned to a provider target in
+// another account or region. The nat gateways, network acls, flow logs and
+// static routes are only built for the datacenter's network, so these subnet
+// groups can't be private or use them.
+func (n *network) validateTargets() error {
+	for _, s := range *n.SubnetGroups {
+		if n.owns(s.Target()) {
+			continue
+		}
+		if _, err := n.provider.targetProvider(s.Target()); err != nil {
+			return fmt.Errorf("Subnet group %q: %s", s.Name(), err)
+		}
+		switch {
+		case s.Access() == "private":
+			return fmt.Errorf("Subnet group %q: A private subnet group can't be pinned to target %q in another account or region", s.Name(), s.Target())
+		case s.NetworkAcl() != "":
+			return fmt.Errorf("Subnet group %q: A network acl can't be used with target %q in another account or region", s.Name(), s.Target())
+		case s.FlowLog() != nil:
+			return fmt.Errorf("Subnet group %q: A flow log can't be used with target %q in another account or region", s.Name(), s.Target())
+		case len(s.Routes()) > 0:
+			return fmt.Errorf("Subnet group %q: Static routes can't be used with target %q in another account or region", s.Name(), s.Target())
+		}
+	}
+	return nil
+}
+
+// newProviderNetwork constructs the network of a provider. The base is the
+// datacenter's network when constructing the network of a provider target.
+func newProviderNetwork(cfg *config.Network, p *dataCenterProvider, base *network) (*network, error) {
+	c := p.ec2
 	n := &network{
 		Resources: resource.NewResources(),
 		Network:   cfg,
 		ec2:       c,
+		provider:  p,
+		key:       networkKey(p),
+		base:      base,
+		targets:   map[string]*network{},
+	}
+	if base == nil {
+		n.base = n
 	}
 
 	vpc, err := newVpc(c, n)
@@ -79,21 +137,109 @@ func newNetwork(cfg *config.Network, c *ec2.EC2) (*network, error) {
 	n.internetGateway = internetGateway
 	n.Append(internetGateway)
 
+	// Dual stack networks route the private subnets' ipv6 traffic through an egress only internet gateway.
+	if cfg.Ipv6() {
+		eigw, err := newEgressOnlyGateway(c, n, "private")
+		if err != nil {
+			return nil, err
+		}
+		n.eigw = eigw
+		n.Append(eigw)
+	}
+
+	// The caches are loaded on first use, see loadCaches.
+	n.subnetCache = newSubnetCache(n)
+	n.secgroupCache = newSecurityGroupCache(n)
+	return n, nil
+}
+
+// loadCaches loads the subnet and security group caches the first time a
+// subnet or security group is loaded, so commands that don't touch the
+// network don't pay for them.
+func (n *network) loadCaches() error {
+	if n.cachesLoaded {
+		return nil
+	}
 	// Load the vpc since it is needed for the caches.
-	err = n.vpc.Load()
-	if err != nil {
-		return nil, err
+	if err := n.vpc.Load(); err != nil {
+		return err
+	}
+	if err := loadConcurrently(n.subnetCache.load, n.secgroupCache.load); err != nil {
+		return err
 	}
+	n.cachesLoaded = true
+	return nil
+}
 
-	n.subnetCache, err = newSubnetCache(n)
-	if err != nil {
-		return nil, err
+// networkKey identifies the network of a provider. Providers in the same
+// account and region share a network.
+func networkKey(p *dataCenterProvider) string {
+	return p.number + "/" + p.region
+}
+
+// forProvider returns the network of the provider target that subnet groups
+// and clusters are pinned to. A target in another account or region has a vpc
+// with its own cidr block, route tables, gateways and subnet and security group
+// caches of its own, all holding the subnet groups pinned to it. Targets in the network's own account
+// and region share the network.
+func (n *network) forProvider(p *dataCenterProvider) (*network, error) {
+	base := n.base
+	key := networkKey(p)
+	if key == base.key {
+		return base, nil
+	}
+	if t := base.targets[key]; t != nil {
+		return t, nil
 	}
-	n.secgroupCache, err = newSecurityGroupCache(n)
+	log.Debug("Initializing AWS Network target %q", p.target)
+
+	cfg := *base.Network
+	cfg.AvailabilityZones_ = p.AvailabilityZones(base.AvailabilityZones())
+	cfg.CidrBlock_ = p.CidrBlock(base.CidrBlock())
+	t, err := newProviderNetwork(&cfg, p, base)
 	if err != nil {
 		return nil, err
 	}
-	return n, nil
+	base.targets[key] = t
+	return t, nil
+}
+
+// owns returns true if the subnet groups pinned to the target live in the network.
+func (n *network) owns(target string) bool {
+	p, err := n.base.provider.targetProvider(target)
+	return err == nil && networkKey(p) == n.key
+}
+
+// all returns the network along with the networks of its provider targets.
+func (n *network) all() []*network {
+	networks := []*network{n}
+	keys := []string{}
+	for k := range n.targets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		networks = append(networks, n.targets[k])
+	}
+	return networks
+}
+
+// routeTargets routes the request to the networks of the provider targets.
+func (n *network) routeTargets(req *route.Request) route.Response {
+	for _, t := range n.all()[1:] {
+		if req.Command() == route.Info {
+			msg.Info("Network Target %s", t.key)
+			msg.IndentInc()
+		}
+		resp := t.Route(req)
+		if req.Command() == route.Info {
+			msg.IndentDec()
+		}
+		if resp != route.OK {
+			return resp
+		}
+	}
+	return route.OK
 }
 
 func (n *network) Route(req *route.Request) route.Response {
@@ -107,13 +253,25 @@ func (n *network) Route(req *route.Request) route.Response {
 		return n.routeTables.Route(req.Pop())
 	case "internetgateway", "igw":
 		return n.internetGateway.Route(req.Pop())
+	case "egressonlygateway", "eigw":
+		if n.eigw == nil {
+			msg.Error("Ipv6 isn't enabled for the network")
+			return route.FAIL
+		}
+		return n.eigw.Route(req.Pop())
 	}
 
 	// Handle commands
 	switch req.Command() {
 	case route.Load, route.Create, route.Info, route.Audit:
-		return n.RouteInOrder(req)
+		if resp := n.RouteInOrder(req); resp != route.OK {
+			return resp
+		}
+		return n.routeTargets(req)
 	case route.Destroy:
+		if resp := n.routeTargets(req); resp != route.OK {
+			return resp
+		}
 		return n.RouteReverseOrder(req)
 	}
 	return route.FAIL
@@ -134,16 +292,32 @@ func (n *network) State() string {
 }
 
 func (n *network) AuditSubnets(flags ...string) error {
-	return n.subnetCache.audit(flags...)
+	for _, t := range n.all() {
+		if err := t.loadCaches(); err != nil {
+			return err
+		}
+		if err := t.subnetCache.audit(flags...); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (n *network) AuditSecgroups(flags ...string) error {
-	return n.secgroupCache.audit(flags...)
+	for _, t := range n.all() {
+		if err := t.loadCaches(); err != nil {
+			return err
+		}
+		if err := t.secgroupCache.audit(flags...); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (n *network) CanRoute(req *route.Request) bool {
 	switch req.Top() {
-	case "vpc", "routetables", "routetable", "rt", "internetgateway", "igw":
+	case "vpc", "routetables", "routetable", "rt", "internetgateway", "igw", "egressonlygateway", "eigw":
 		return true
 	}
 	return false
@@ -155,5 +329,6 @@ func (n *network) HelpCommands() []help.Command {
 		{Name: "routetables", Desc: "manage aws routetables"},
 		{Name: "routetable [name]", Desc: "manage named aws routetable"},
 		{Name: "internetgateway", Desc: "manage aws internet gateway"},
+		{Name: "egressonlygateway", Desc: "manage aws egress only internet gateway"},
 	}
 }
@@ -0,0 +1,621 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/net"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// The highest rule number is the network acl's default entry, which denies
+// all traffic.
+const aclDefaultRule = 32767
+
+var aclProtocols = map[string]string{
+	"tcp":  "6",
+	"udp":  "17",
+	"icmp": "1",
+	"all":  "-1",
+}
+
+type networkAcl struct {
+	*config.NetworkAcl
+	ec2     *ec2.EC2
+	net     resource.Network
+	network *network
+	subnets []*subnet
+
+	acl *ec2.NetworkAcl
+	id_ string
+}
+
+func newNetworkAcl(c *ec2.EC2, net resource.Network, cfg *config.NetworkAcl) (*networkAcl, error) {
+	log.Debug("Initializing AWS NetworkAcl %q", cfg.Name())
+
+	n, ok := net.ProviderNetwork().(*network)
+	if !ok {
+		return nil, fmt.Errorf("AWS newNetworkAcl: Unable to obtain provider network")
+	}
+
+	acl := &networkAcl{
+		NetworkAcl: cfg,
+		ec2:        c,
+		net:        net,
+		network:    n,
+	}
+	for _, subnetGroup := range net.SubnetGroups().Get() {
+		if subnetGroup.NetworkAcl() != cfg.Name() {
+			continue
+		}
+		for _, sub := range subnetGroup.Subnets() {
+			s, ok := sub.ProviderSubnet().(*subnet)
+			if !ok {
+				return nil, fmt.Errorf("AWS newNetworkAcl: Unable to obtain provider subnet")
+			}
+			acl.subnets = append(acl.subnets, s)
+		}
+	}
+	return acl, nil
+}
+
+func (n *networkAcl) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS NetworkAcl %q", n.Name())
+
+	if req.Top() != "" {
+		n.help()
+		return route.FAIL
+	}
+
+	if req.TestFlag() {
+		msg.Detail("Test. Skipping...")
+		return route.OK
+	}
+
+	switch req.Command() {
+	case route.Load:
+		if err := n.Load(); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Create:
+		return n.create(req)
+	case route.Provision:
+		return n.provision(req)
+	case route.Destroy:
+		return n.destroy(req)
+	case route.Audit:
+		if err := aaa.NewAudit("NetworkAcl"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		if err := n.Audit("NetworkAcl"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Help:
+		n.help()
+		return route.OK
+	case route.Info:
+		n.info()
+		return route.OK
+	}
+	msg.Error("Unknown network acl command %q.", req.Command())
+	return route.FAIL
+}
+
+func (n *networkAcl) Created() bool {
+	return n != nil && n.acl != nil
+}
+
+func (n *networkAcl) Destroyed() bool {
+	return !n.Created()
+}
+
+func (n *networkAcl) id() string {
+	return n.id_
+}
+
+func (n *networkAcl) set(acl *ec2.NetworkAcl) {
+	if acl == nil || acl.NetworkAclId == nil {
+		return
+	}
+	n.acl = acl
+	n.id_ = *acl.NetworkAclId
+}
+
+func (n *networkAcl) clear() {
+	n.acl = nil
+	n.id_ = ""
+}
+
+func (n *networkAcl) Load() error {
+	n.acl = nil
+	if n.network.vpc.id() == "" {
+		return nil
+	}
+
+	params := &ec2.DescribeNetworkAclsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{aws.String(n.network.vpc.id())},
+			},
+		},
+	}
+	if n.id() != "" {
+		params.NetworkAclIds = []*string{aws.String(n.id())}
+	} else {
+		params.Filters = append(params.Filters, &ec2.Filter{
+			Name:   aws.String("tag:Name"),
+			Values: []*string{aws.String(n.Name())},
+		})
+	}
+	resp, err := n.ec2.DescribeNetworkAcls(params)
+	if err != nil {
+		return err
+	}
+	for _, acl := range resp.NetworkAcls {
+		n.set(acl)
+		return nil
+	}
+	return nil
+}
+
+// entries expands the configured entries into the network acl's entries. The
+// entries expanded from a configured entry are numbered from its rule number.
+func (n *networkAcl) entries() ([]*ec2.NetworkAclEntry, error) {
+	used := map[bool]map[int64]bool{false: {}, true: {}}
+	entries := []*ec2.NetworkAclEntry{}
+	for _, e := range n.Entries() {
+		for _, direction := range e.Directions() {
+			egress := direction == "egress"
+			rule := e.Rule()
+			for _, remote := range e.Remotes() {
+				ipRanges, ipv6Ranges, _, err := parseRemote(n.net, n.network, remote)
+				if err != nil {
+					return nil, err
+				}
+				cidrs := []string{}
+				for _, ipRange := range ipRanges {
+					cidrs = append(cidrs, aws.StringValue(ipRange.CidrIp))
+				}
+				for _, ipv6Range := range ipv6Ranges {
+					cidrs = append(cidrs, aws.StringValue(ipv6Range.CidrIpv6))
+				}
+				for _, protocol := range e.Protocols() {
+					ports := e.Ports()
+					if protocol == "all" {
+						ports = []string{""}
+					}
+					for _, port := range ports {
+						for _, cidr := range cidrs {
+							if rule >= aclDefaultRule || used[egress][rule] {
+								return nil, fmt.Errorf("NetworkAcl %s, %s rule %d: No room for rule number %d, leave more room between the rule numbers", n.Name(), direction, e.Rule(), rule)
+							}
+							used[egress][rule] = true
+							entry := &ec2.NetworkAclEntry{
+								Egress:     aws.Bool(egress),
+								Protocol:   aws.String(aclProtocols[protocol]),
+								RuleAction: aws.String(e.Action()),
+								RuleNumber: aws.Int64(rule),
+							}
+							if net.IsIpv6(cidr) {
+								entry.Ipv6CidrBlock = aws.String(cidr)
+							} else {
+								entry.CidrBlock = aws.String(cidr)
+							}
+							switch protocol {
+							case "tcp", "udp":
+								toPort, fromPort, err := parsePort(port)
+								if err != nil {
+									return nil, err
+								}
+								entry.PortRange = &ec2.PortRange{From: aws.Int64(fromPort), To: aws.Int64(toPort)}
+							case "icmp":
+								icmpType, icmpCode, err := parseIcmp(port)
+								if err != nil {
+									return nil, err
+								}
+								entry.IcmpTypeCode = &ec2.IcmpTypeCode{Type: aws.Int64(icmpType), Code: aws.Int64(icmpCode)}
+							}
+							entries = append(entries, entry)
+							rule++
+						}
+					}
+				}
+			}
+		}
+	}
+	return entries, nil
+}
+
+// parseIcmp parses the icmp type and code, e.g. 8:0. A missing code matches all codes.
+func parseIcmp(s string) (icmpType, icmpCode int64, err error) {
+	parts := strings.Split(s, ":")
+	if icmpType, err = strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64); err != nil {
+		return
+	}
+	icmpCode = -1
+	if len(parts) > 1 {
+		icmpCode, err = strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+	}
+	return
+}
+
+func aclEntryKey(e *ec2.NetworkAclEntry) string {
+	return fmt.Sprintf("%t:%d", aws.BoolValue(e.Egress), aws.Int64Value(e.RuleNumber))
+}
+
+// aclEntryString provides a user friendly view of the entry.
+func aclEntryString(e *ec2.NetworkAclEntry) string {
+	cidr := aws.StringValue(e.CidrBlock)
+	if e.Ipv6CidrBlock != nil {
+		cidr = *e.Ipv6CidrBlock
+	}
+	s := fmt.Sprintf("%s %s %s", aws.StringValue(e.RuleAction), aws.StringValue(e.Protocol), cidr)
+	if e.PortRange != nil {
+		s += fmt.Sprintf(" %d:%d", aws.Int64Value(e.PortRange.From), aws.Int64Value(e.PortRange.To))
+	}
+	if e.IcmpTypeCode != nil {
+		s += fmt.Sprintf(" %d:%d", aws.Int64Value(e.IcmpTypeCode.Type), aws.Int64Value(e.IcmpTypeCode.Code))
+	}
+	return s
+}
+
+func (n *networkAcl) deployedEntries() map[string]*ec2.NetworkAclEntry {
+	deployed := map[string]*ec2.NetworkAclEntry{}
+	if n.acl == nil {
+		return deployed
+	}
+	for _, e := range n.acl.Entries {
+		if aws.Int64Value(e.RuleNumber) == aclDefaultRule {
+			continue
+		}
+		deployed[aclEntryKey(e)] = e
+	}
+	return deployed
+}
+
+// associated returns true if the subnet is associated with the network acl.
+func (n *networkAcl) associated(subnetId string) bool {
+	if n.acl == nil {
+		return false
+	}
+	for _, a := range n.acl.Associations {
+		if aws.StringValue(a.SubnetId) == subnetId {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *networkAcl) create(req *route.Request) route.Response {
+	msg.Info("NetworkAcl Creation: %s", n.Name())
+	if n.Created() {
+		msg.Detail("NetworkAcl exists, skipping...")
+		return route.OK
+	}
+
+	params := &ec2.CreateNetworkAclInput{
+		VpcId: aws.String(n.network.vpc.id()),
+	}
+	resp, err := n.ec2.CreateNetworkAcl(params)
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	n.set(resp.NetworkAcl)
+	if err := createTags(n.ec2, n.Name(), n.id(), req); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	msg.Detail("Created: %s", n.id())
+	aaa.Accounting("NetworkAcl created: %s", n.id())
+
+	return n.provision(req)
+}
+
+// provision brings the network acl's entries and subnet associations in line
+// with the configuration.
+func (n *networkAcl) provision(req *route.Request) route.Response {
+	if n.Destroyed() {
+		msg.Detail("NetworkAcl %s does not exist, skipping...", n.Name())
+		return route.OK
+	}
+	if err := n.updateEntries(); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if err := n.associate(); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	return route.OK
+}
+
+func (n *networkAcl) updateEntries() error {
+	entries, err := n.entries()
+	if err != nil {
+		return err
+	}
+	deployed := n.deployedEntries()
+	for _, e := range entries {
+		key := aclEntryKey(e)
+		d := deployed[key]
+		delete(deployed, key)
+		if d != nil && aclEntryString(d) == aclEntryString(e) {
+			continue
+		}
+		if d == nil {
+			_, err = n.ec2.CreateNetworkAclEntry(&ec2.CreateNetworkAclEntryInput{
+				NetworkAclId:  aws.String(n.id()),
+				CidrBlock:     e.CidrBlock,
+				Ipv6CidrBlock: e.Ipv6CidrBlock,
+				Egress:        e.Egress,
+				IcmpTypeCode:  e.IcmpTypeCode,
+				PortRange:     e.PortRange,
+				Protocol:      e.Protocol,
+				RuleAction:    e.RuleAction,
+				RuleNumber:    e.RuleNumber,
+			})
+		} else {
+			_, err = n.ec2.ReplaceNetworkAclEntry(&ec2.ReplaceNetworkAclEntryInput{
+				NetworkAclId:  aws.String(n.id()),
+				CidrBlock:     e.CidrBlock,
+				Ipv6CidrBlock: e.Ipv6CidrBlock,
+				Egress:        e.Egress,
+				IcmpTypeCode:  e.IcmpTypeCode,
+				PortRange:     e.PortRange,
+				Protocol:      e.Protocol,
+				RuleAction:    e.RuleAction,
+				RuleNumber:    e.RuleNumber,
+			})
+		}
+		if err != nil {
+			return err
+		}
+		msg.Detail("Entry %d: %s", aws.Int64Value(e.RuleNumber), aclEntryString(e))
+	}
+	for _, e := range deployed {
+		params := &ec2.DeleteNetworkAclEntryInput{
+			NetworkAclId: aws.String(n.id()),
+			Egress:       e.Egress,
+			RuleNumber:   e.RuleNumber,
+		}
+		if _, err := n.ec2.DeleteNetworkAclEntry(params); err != nil {
+			return err
+		}
+		msg.Detail("Deleted entry %d: %s", aws.Int64Value(e.RuleNumber), aclEntryString(e))
+	}
+	aaa.Accounting("NetworkAcl entries updated: %s", n.id())
+	return n.Load()
+}
+
+// associate moves the subnet groups' subnets to the network acl. Subnets are
+// always associated with a network acl, so the current association is replaced.
+func (n *networkAcl) associate() error {
+	for _, s := range n.subnets {
+		if s.Id() == "" || n.associated(s.Id()) {
+			continue
+		}
+		params := &ec2.DescribeNetworkAclsInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("association.subnet-id"),
+					Values: []*string{aws.String(s.Id())},
+				},
+			},
+		}
+		resp, err := n.ec2.DescribeNetworkAcls(params)
+		if err != nil {
+			return err
+		}
+		for _, acl := range resp.NetworkAcls {
+			for _, a := range acl.Associations {
+				if aws.StringValue(a.SubnetId) != s.Id() {
+					continue
+				}
+				if err := n.replaceAssociation(a, n.id()); err != nil {
+					return err
+				}
+				msg.Detail("Associated subnet %s, %s", s.Name(), s.Id())
+			}
+		}
+	}
+	return n.Load()
+}
+
+func (n *networkAcl) replaceAssociation(a *ec2.NetworkAclAssociation, aclId string) error {
+	params := &ec2.ReplaceNetworkAclAssociationInput{
+		AssociationId: a.NetworkAclAssociationId,
+		NetworkAclId:  aws.String(aclId),
+	}
+	if _, err := n.ec2.ReplaceNetworkAclAssociation(params); err != nil {
+		return err
+	}
+	aaa.Accounting("NetworkAcl association: subnet %s to %s", aws.StringValue(a.SubnetId), aclId)
+	return nil
+}
+
+func (n *networkAcl) destroy(req *route.Request) route.Response {
+	msg.Info("NetworkAcl Destruction: %s", n.Name())
+	if n.Destroyed() {
+		msg.Detail("NetworkAcl does not exist, skipping...")
+		return route.OK
+	}
+
+	// Return the subnets to the vpc's default network acl.
+	if len(n.acl.Associations) > 0 {
+		params := &ec2.DescribeNetworkAclsInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("vpc-id"),
+					Values: []*string{aws.String(n.network.vpc.id())},
+				},
+				{
+					Name:   aws.String("default"),
+					Values: []*string{aws.String("true")},
+				},
+			},
+		}
+		resp, err := n.ec2.DescribeNetworkAcls(params)
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		if len(resp.NetworkAcls) == 0 {
+			msg.Error("Unable to find the default network acl of %s", n.network.vpc.id())
+			return route.FAIL
+		}
+		for _, a := range n.acl.Associations {
+			if err := n.replaceAssociation(a, aws.StringValue(resp.NetworkAcls[0].NetworkAclId)); err != nil {
+				msg.Error(err.Error())
+				return route.FAIL
+			}
+		}
+	}
+
+	params := &ec2.DeleteNetworkAclInput{
+		NetworkAclId: aws.String(n.id()),
+	}
+	if _, err := n.ec2.DeleteNetworkAcl(params); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	msg.Detail("Destroyed: %s", n.id())
+	aaa.Accounting("NetworkAcl destroyed: %s", n.id())
+	n.clear()
+	return route.OK
+}
+
+// Audit compares the deployed entries and subnet associations with the configuration.
+func (n *networkAcl) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil {
+		return fmt.Errorf("Audit Object does not exist")
+	}
+	if n.Destroyed() {
+		a.Audit(aaa.Configured, "NetworkAcl %s", n.Name())
+		return nil
+	}
+
+	entries, err := n.entries()
+	if err != nil {
+		return err
+	}
+	deployed := n.deployedEntries()
+	for _, e := range entries {
+		key := aclEntryKey(e)
+		d := deployed[key]
+		delete(deployed, key)
+		if d == nil {
+			a.Audit(aaa.Mismatched, "NetworkAcl %s, %s | %s entry %d: Configured %s - Deployed none", n.Name(), n.id(), direction(e), aws.Int64Value(e.RuleNumber), aclEntryString(e))
+		} else if aclEntryString(d) != aclEntryString(e) {
+			a.Audit(aaa.Mismatched, "NetworkAcl %s, %s | %s entry %d: Configured %s - Deployed %s", n.Name(), n.id(), direction(e), aws.Int64Value(e.RuleNumber), aclEntryString(e), aclEntryString(d))
+		}
+	}
+	for _, d := range deployed {
+		a.Audit(aaa.Mismatched, "NetworkAcl %s, %s | %s entry %d: Configured none - Deployed %s", n.Name(), n.id(), direction(d), aws.Int64Value(d.RuleNumber), aclEntryString(d))
+	}
+	for _, s := range n.subnets {
+		if s.Id() != "" && !n.associated(s.Id()) {
+			a.Audit(aaa.Mismatched, "NetworkAcl %s, %s | Subnet %s, %s isn't associated", n.Name(), n.id(), s.Name(), s.Id())
+		}
+	}
+	return nil
+}
+
+func direction(e *ec2.NetworkAclEntry) string {
+	if aws.BoolValue(e.Egress) {
+		return "egress"
+	}
+	return "ingress"
+}
+
+func (n *networkAcl) help() {
+	commands := []help.Command{
+		{Name: route.Create.String(), Desc: fmt.Sprintf("create %s network acl", n.Name())},
+		{Name: route.Provision.String(), Desc: fmt.Sprintf("update the entries and associations of %s network acl", n.Name())},
+		{Name: route.Destroy.String(), Desc: fmt.Sprintf("destroy %s network acl", n.Name())},
+		{Name: route.Audit.String(), Desc: fmt.Sprintf("audit %s network acl", n.Name())},
+		{Name: route.Info.String(), Desc: fmt.Sprintf("show information about allocated %s network acl", n.Name())},
+		{Name: route.Help.String(), Desc: "show this help"},
+	}
+	help.Print(fmt.Sprintf("network acl %s", n.Name()), commands)
+}
+
+func (n *networkAcl) info() {
+	if n.Destroyed() {
+		return
+	}
+	msg.Info("NetworkAcl")
+	msg.Detail("%-20s\t%s", "name", n.Name())
+	msg.Detail("%-20s\t%s", "id", n.id())
+
+	entries := []*ec2.NetworkAclEntry{}
+	for _, e := range n.deployedEntries() {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if aws.BoolValue(entries[i].Egress) != aws.BoolValue(entries[j].Egress) {
+			return !aws.BoolValue(entries[i].Egress)
+		}
+		return aws.Int64Value(entries[i].RuleNumber) < aws.Int64Value(entries[j].RuleNumber)
+	})
+	msg.IndentInc()
+	for _, e := range entries {
+		msg.Detail("%-20s\t%s", fmt.Sprintf("%s %d", direction(e), aws.Int64Value(e.RuleNumber)), aclEntryString(e))
+	}
+	msg.IndentDec()
+
+	msg.IndentInc()
+	msg.Info("Associations")
+	for _, a := range n.acl.Associations {
+		msg.Detail("%-20s\t%s", "subnet", aws.StringValue(a.SubnetId))
+	}
+	msg.IndentDec()
+	printTags(n.acl.Tags)
+}
@@ -0,0 +1,187 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+type networkAcls struct {
+	*resource.Resources
+	network     *network
+	ec2         *ec2.EC2
+	networkAcls map[string]*networkAcl
+}
+
+func newNetworkAcls(c *ec2.EC2, net resource.Network) (*networkAcls, error) {
+	if len(net.NetworkAcls()) == 0 {
+		log.Debug("AWS NetworkAcls not needed. No network acls configured.")
+		return nil, nil
+	}
+	log.Debug("Initializing AWS NetworkAcls")
+
+	n, ok := net.ProviderNetwork().(*network)
+	if !ok {
+		return nil, fmt.Errorf("AWS newNetworkAcls: Unable to obtain provider network")
+	}
+
+	acls := &networkAcls{
+		Resources:   resource.NewResources(),
+		network:     n,
+		ec2:         c,
+		networkAcls: map[string]*networkAcl{},
+	}
+	for _, cfg := range net.NetworkAcls() {
+		acl, err := newNetworkAcl(c, net, cfg)
+		if err != nil {
+			return nil, err
+		}
+		acls.networkAcls[cfg.Name()] = acl
+		acls.Append(acl)
+	}
+	return acls, nil
+}
+
+func (n *networkAcls) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS NetworkAcls")
+
+	if acl := n.find(req.Top()); acl != nil {
+		return acl.Route(req.Pop())
+	}
+	if req.Top() != "" {
+		msg.Error("Unknown network acl %q.", req.Top())
+		return route.FAIL
+	}
+
+	if req.TestFlag() {
+		msg.Detail("Test. Skipping...")
+		return route.OK
+	}
+
+	switch req.Command() {
+	case route.Load, route.Create, route.Provision:
+		return n.RouteInOrder(req)
+	case route.Destroy:
+		return n.RouteReverseOrder(req)
+	case route.Audit:
+		if err := n.Audit("NetworkAcl"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Help:
+		n.help()
+		return route.OK
+	case route.Info:
+		n.info(req)
+		return route.OK
+	}
+	return route.FAIL
+}
+
+func (n *networkAcls) find(s string) *networkAcl {
+	return n.networkAcls[s]
+}
+
+// Audit checks the configured network acls and identifies any network acls
+// in the datacenter's vpc that aren't configured.
+func (n *networkAcls) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	if err := aaa.NewAudit(flags[0]); err != nil {
+		return err
+	}
+	configured := map[string]bool{}
+	for _, acl := range n.networkAcls {
+		if err := acl.Audit(flags...); err != nil {
+			return err
+		}
+		configured[acl.id()] = true
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil || n.network.vpc.id() == "" {
+		return nil
+	}
+
+	params := &ec2.DescribeNetworkAclsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{aws.String(n.network.vpc.id())},
+			},
+			{
+				Name:   aws.String("default"),
+				Values: []*string{aws.String("false")},
+			},
+		},
+	}
+	resp, err := n.ec2.DescribeNetworkAcls(params)
+	if err != nil {
+		return err
+	}
+	for _, acl := range resp.NetworkAcls {
+		if configured[aws.StringValue(acl.NetworkAclId)] {
+			continue
+		}
+		a.Audit(aaa.Deployed, "NetworkAcl %s", aws.StringValue(acl.NetworkAclId))
+	}
+	return nil
+}
+
+func (n *networkAcls) help() {
+	commands := []help.Command{
+		{Name: "'name'", Desc: "manage named network acl"},
+		{Name: route.Create.String(), Desc: "create all network acls"},
+		{Name: route.Provision.String(), Desc: "update the entries and associations of all network acls"},
+		{Name: route.Destroy.String(), Desc: "destroy all network acls"},
+		{Name: route.Audit.String(), Desc: "audit all network acls"},
+		{Name: route.Info.String(), Desc: "show information about all allocated network acls"},
+		{Name: route.Help.String(), Desc: "show this help"},
+	}
+	help.Print("network acl", commands)
+}
+
+func (n *networkAcls) info(req *route.Request) {
+	if n.Destroyed() {
+		return
+	}
+	msg.Info("NetworkAcls")
+	msg.IndentInc()
+	n.RouteInOrder(req)
+	msg.IndentDec()
+}
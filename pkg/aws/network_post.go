@@ -27,8 +27,6 @@
 package aws
 
 import (
-	"github.com/aws/aws-sdk-go/service/ec2"
-
 	"github.com/cisco/arc/pkg/config"
 	"github.com/cisco/arc/pkg/help"
 	"github.com/cisco/arc/pkg/log"
@@ -41,18 +39,22 @@ import (
 type networkPost struct {
 	*resource.Resources
 	*config.Network
-	natGateways *natGateways
+	natGateways  *natGateways
+	peerings     *peerings
+	networkAcls  *networkAcls
+	flowLogs     *flowLogs
+	staticRoutes *staticRoutes
 }
 
 // newNetwork constructs the aws network.
-func newNetworkPost(net resource.Network, cfg *config.Network, c *ec2.EC2) (*networkPost, error) {
+func newNetworkPost(net resource.Network, cfg *config.Network, p *dataCenterProvider) (*networkPost, error) {
 	log.Debug("Initializing AWS Network Post")
 	np := &networkPost{
 		Resources: resource.NewResources(),
 		Network:   cfg,
 	}
 
-	natGateways, err := newNatGateways(c, net)
+	natGateways, err := newNatGateways(p.ec2, net)
 	if err != nil {
 		return nil, err
 	}
@@ -61,6 +63,42 @@ func newNetworkPost(net resource.Network, cfg *config.Network, c *ec2.EC2) (*net
 		np.Append(natGateways)
 	}
 
+	peerings, err := newPeerings(p, net)
+	if err != nil {
+		return nil, err
+	}
+	if peerings != nil {
+		np.peerings = peerings
+		np.Append(peerings)
+	}
+
+	networkAcls, err := newNetworkAcls(p.ec2, net)
+	if err != nil {
+		return nil, err
+	}
+	if networkAcls != nil {
+		np.networkAcls = networkAcls
+		np.Append(networkAcls)
+	}
+
+	flowLogs, err := newFlowLogs(p.ec2, net, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if flowLogs != nil {
+		np.flowLogs = flowLogs
+		np.Append(flowLogs)
+	}
+
+	staticRoutes, err := newStaticRoutes(net, np.peerings)
+	if err != nil {
+		return nil, err
+	}
+	if staticRoutes != nil {
+		np.staticRoutes = staticRoutes
+		np.Append(staticRoutes)
+	}
+
 	return np, nil
 }
 
@@ -71,6 +109,30 @@ func (n *networkPost) Route(req *route.Request) route.Response {
 	switch req.Top() {
 	case "natgateways", "natgateway", "nat", "ngw":
 		return n.natGateways.Route(req.Pop())
+	case "peerings", "peering", "pcx":
+		if n.peerings == nil {
+			msg.Error("No peerings configured")
+			return route.FAIL
+		}
+		return n.peerings.Route(req.Pop())
+	case "acls", "acl", "nacl":
+		if n.networkAcls == nil {
+			msg.Error("No network acls configured")
+			return route.FAIL
+		}
+		return n.networkAcls.Route(req.Pop())
+	case "flowlogs", "flowlog", "fl":
+		if n.flowLogs == nil {
+			msg.Error("No flow logs configured")
+			return route.FAIL
+		}
+		return n.flowLogs.Route(req.Pop())
+	case "routes", "staticroutes":
+		if n.staticRoutes == nil {
+			msg.Error("No static routes configured")
+			return route.FAIL
+		}
+		return n.staticRoutes.Route(req.Pop())
 	}
 
 	// Handle commands
@@ -78,6 +140,39 @@ func (n *networkPost) Route(req *route.Request) route.Response {
 	case route.Load, route.Create, route.Info:
 		return n.RouteInOrder(req)
 	case route.Audit:
+		if n.peerings != nil {
+			if resp := n.peerings.Route(req); resp != route.OK {
+				return resp
+			}
+		}
+		if n.networkAcls != nil {
+			if resp := n.networkAcls.Route(req); resp != route.OK {
+				return resp
+			}
+		}
+		if n.flowLogs != nil {
+			if resp := n.flowLogs.Route(req); resp != route.OK {
+				return resp
+			}
+		}
+		if n.staticRoutes != nil {
+			return n.staticRoutes.Route(req)
+		}
+		return route.OK
+	case route.Provision:
+		if n.natGateways != nil {
+			if resp := n.natGateways.Route(req); resp != route.OK {
+				return resp
+			}
+		}
+		if n.networkAcls != nil {
+			if resp := n.networkAcls.Route(req); resp != route.OK {
+				return resp
+			}
+		}
+		if n.staticRoutes != nil {
+			return n.staticRoutes.Route(req)
+		}
 		return route.OK
 	case route.Destroy:
 		return n.RouteReverseOrder(req)
@@ -89,7 +184,8 @@ func (n *networkPost) Route(req *route.Request) route.Response {
 
 func (n *networkPost) CanRoute(req *route.Request) bool {
 	switch req.Top() {
-	case "natgateways", "natgateway", "nat", "ngw":
+	case "natgateways", "natgateway", "nat", "ngw", "peerings", "peering", "pcx", "acls", "acl", "nacl", "flowlogs", "flowlog", "fl",
+		"routes", "staticroutes":
 		return true
 	}
 	return false
@@ -99,5 +195,12 @@ func (n *networkPost) HelpCommands() []help.Command {
 	return []help.Command{
 		{Name: "natgateways", Desc: "manage aws nat gateways"},
 		{Name: "natgateway [name]", Desc: "manage named aws natgateway"},
+		{Name: "peerings", Desc: "manage aws vpc peerings"},
+		{Name: "peering [name]", Desc: "manage named aws vpc peering"},
+		{Name: "acls", Desc: "manage aws network acls"},
+		{Name: "acl [name]", Desc: "manage named aws network acl"},
+		{Name: "flowlogs", Desc: "manage aws flow logs"},
+		{Name: "flowlog [name]", Desc: "manage named aws flow log"},
+		{Name: "routes", Desc: "manage the static routes of the subnet groups"},
 	}
 }
@@ -0,0 +1,411 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// peeringStates are the states of peering connections that are in use.
+var peeringStates = []string{"initiating-request", "pending-acceptance", "provisioning", "active"}
+
+type peering struct {
+	*config.Peering
+	network  *network
+	provider *dataCenterProvider
+	ec2      *ec2.EC2
+
+	pcx *ec2.VpcPeeringConnection
+	id_ string
+}
+
+func newPeering(p *dataCenterProvider, net resource.Network, cfg *config.Peering) (*peering, error) {
+	log.Debug("Initializing AWS Peering %q", cfg.Name())
+
+	n, ok := net.ProviderNetwork().(*network)
+	if !ok {
+		return nil, fmt.Errorf("AWS newPeering: Unable to obtain provider network")
+	}
+	for _, name := range cfg.RouteTables() {
+		if n.routeTables.find(name) == nil {
+			return nil, fmt.Errorf("Peering %q: Unknown route table %q", cfg.Name(), name)
+		}
+	}
+
+	pc := &peering{
+		Peering:  cfg,
+		network:  n,
+		provider: p,
+		ec2:      p.ec2,
+	}
+	return pc, nil
+}
+
+func (p *peering) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS Peering %q", p.Name())
+
+	if req.Top() != "" {
+		p.help()
+		return route.FAIL
+	}
+
+	if req.TestFlag() {
+		msg.Detail("Test. Skipping...")
+		return route.OK
+	}
+
+	switch req.Command() {
+	case route.Load:
+		if err := p.Load(); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Create:
+		return p.create(req)
+	case route.Destroy:
+		return p.destroy(req)
+	case route.Audit:
+		if err := aaa.NewAudit("Peering"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		if err := p.Audit("Peering"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Help:
+		p.help()
+		return route.OK
+	case route.Info:
+		p.info()
+		return route.OK
+	}
+	msg.Error("Unknown peering command %q.", req.Command())
+	return route.FAIL
+}
+
+func (p *peering) Created() bool {
+	return p != nil && p.pcx != nil
+}
+
+func (p *peering) Destroyed() bool {
+	return !p.Created()
+}
+
+func (p *peering) id() string {
+	return p.id_
+}
+
+func (p *peering) status() string {
+	if p.pcx == nil || p.pcx.Status == nil {
+		return ""
+	}
+	return aws.StringValue(p.pcx.Status.Code)
+}
+
+func (p *peering) set(pc *ec2.VpcPeeringConnection) {
+	if pc == nil || pc.VpcPeeringConnectionId == nil {
+		return
+	}
+	p.pcx = pc
+	p.id_ = *pc.VpcPeeringConnectionId
+}
+
+func (p *peering) clear() {
+	p.pcx = nil
+	p.id_ = ""
+}
+
+func (p *peering) Load() error {
+	p.pcx = nil
+	if p.network.vpc.id() == "" {
+		return nil
+	}
+
+	params := &ec2.DescribeVpcPeeringConnectionsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("requester-vpc-info.vpc-id"),
+				Values: []*string{aws.String(p.network.vpc.id())},
+			},
+			{
+				Name:   aws.String("accepter-vpc-info.vpc-id"),
+				Values: []*string{aws.String(p.VpcId())},
+			},
+			{
+				Name:   aws.String("status-code"),
+				Values: aws.StringSlice(peeringStates),
+			},
+		},
+	}
+	if p.id() != "" {
+		params.VpcPeeringConnectionIds = []*string{aws.String(p.id())}
+	}
+	resp, err := p.ec2.DescribeVpcPeeringConnections(params)
+	if err != nil {
+		return err
+	}
+	for _, pc := range resp.VpcPeeringConnections {
+		p.set(pc)
+		return nil
+	}
+	return nil
+}
+
+func (p *peering) reload(state string) bool {
+	return msg.Wait(
+		fmt.Sprintf("Waiting for Peering %s, %s to become %s", p.Name(), p.id(), state), // title
+		fmt.Sprintf("Peering %s, %s never became %s", p.Name(), p.id(), state),          // err
+		120, // duration
+		func() bool { return p.status() == state }, // test()
+		func() bool { // load()
+			switch p.status() {
+			case "failed", "rejected", "expired", "deleted":
+				msg.Error("Peering %s, %s failed to become %s, status: %s", p.Name(), p.id(), state, p.status())
+				return false
+			}
+			if err := p.Load(); err != nil {
+				msg.Error(err.Error())
+				return false
+			}
+			return true
+		},
+	)
+}
+
+// accepter returns the ec2 client able to accept the peering request, or nil
+// if the request has to be accepted outside of arc. A peer in the datacenter's
+// account but another region is accepted with a session for the peer's region.
+func (p *peering) accepter() (*ec2.EC2, error) {
+	if p.AcceptWith() != "" {
+		t, err := p.provider.Target(p.AcceptWith())
+		if err != nil {
+			return nil, err
+		}
+		return t.(*dataCenterProvider).ec2, nil
+	}
+	if p.Account() != "" && p.Account() != p.provider.number {
+		return nil, nil
+	}
+	if p.Region() == "" || p.Region() == p.provider.region {
+		return p.ec2, nil
+	}
+	sess, err := newSession(p.provider.name, p.Region(), p.provider.cfg.Data["role"])
+	if err != nil {
+		return nil, err
+	}
+	return ec2.New(sess), nil
+}
+
+func (p *peering) create(req *route.Request) route.Response {
+	msg.Info("Peering Creation: %s", p.Name())
+	if p.Created() {
+		msg.Detail("Peering exists, skipping...")
+	} else {
+		params := &ec2.CreateVpcPeeringConnectionInput{
+			VpcId:     aws.String(p.network.vpc.id()),
+			PeerVpcId: aws.String(p.VpcId()),
+		}
+		if p.Account() != "" {
+			params.PeerOwnerId = aws.String(p.Account())
+		}
+		if p.Region() != "" {
+			params.PeerRegion = aws.String(p.Region())
+		}
+		resp, err := p.ec2.CreateVpcPeeringConnection(params)
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		p.set(resp.VpcPeeringConnection)
+		if !p.reload("pending-acceptance") {
+			return route.FAIL
+		}
+		if err := createTags(p.ec2, p.Name(), p.id(), req); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		msg.Detail("Created: %s", p.id())
+		aaa.Accounting("Peering created: %s", p.id())
+	}
+
+	if p.status() == "pending-acceptance" {
+		c, err := p.accepter()
+		if err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		if c == nil {
+			msg.Warn("Peering %s, %s must be accepted by account %s", p.Name(), p.id(), p.Account())
+			return route.OK
+		}
+		params := &ec2.AcceptVpcPeeringConnectionInput{
+			VpcPeeringConnectionId: aws.String(p.id()),
+		}
+		if _, err := c.AcceptVpcPeeringConnection(params); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		msg.Detail("Accepted: %s", p.id())
+		aaa.Accounting("Peering accepted: %s", p.id())
+	}
+	if !p.reload("active") {
+		return route.FAIL
+	}
+
+	for name, routeTable := range p.network.routeTables.routeTables {
+		if !p.RoutesTo(name) {
+			continue
+		}
+		for _, cidr := range p.Cidrs() {
+			if resp := routeTable.createRoute(req, cidr, pcx, p); resp != route.OK {
+				return resp
+			}
+		}
+	}
+	return route.OK
+}
+
+func (p *peering) destroy(req *route.Request) route.Response {
+	if p.Destroyed() {
+		msg.Info("Peering Destruction: %s", p.Name())
+		msg.Detail("Peering does not exist, skipping...")
+		return route.OK
+	}
+
+	for name, routeTable := range p.network.routeTables.routeTables {
+		if !p.RoutesTo(name) {
+			continue
+		}
+		for _, cidr := range p.Cidrs() {
+			if resp := routeTable.deleteRoute(req, cidr); resp != route.OK {
+				return resp
+			}
+		}
+	}
+
+	msg.Info("Peering Destruction: %s", p.Name())
+	params := &ec2.DeleteVpcPeeringConnectionInput{
+		VpcPeeringConnectionId: aws.String(p.id()),
+	}
+	if _, err := p.ec2.DeleteVpcPeeringConnection(params); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	msg.Detail("Destroyed: %s", p.id())
+	aaa.Accounting("Peering destroyed: %s", p.id())
+	p.clear()
+	return route.OK
+}
+
+// Audit checks the peering is active, routes to the configured cidrs in the
+// expected route tables and that the cidrs belong to the peer vpc.
+func (p *peering) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil {
+		return fmt.Errorf("Audit Object does not exist")
+	}
+	if p.Destroyed() {
+		a.Audit(aaa.Configured, "Peering %s to %s", p.Name(), p.VpcId())
+		return nil
+	}
+	if p.status() != "active" {
+		a.Audit(aaa.Mismatched, "Peering %s, %s is %s", p.Name(), p.id(), p.status())
+	}
+
+	peerCidrs := map[string]bool{}
+	if info := p.pcx.AccepterVpcInfo; info != nil {
+		peerCidrs[aws.StringValue(info.CidrBlock)] = true
+		for _, c := range info.CidrBlockSet {
+			peerCidrs[aws.StringValue(c.CidrBlock)] = true
+		}
+	}
+	for _, cidr := range p.Cidrs() {
+		if len(peerCidrs) > 0 && !peerCidrs[cidr] {
+			a.Audit(aaa.Mismatched, "Peering %s, %s | cidr %s isn't a cidr of %s", p.Name(), p.id(), cidr, p.VpcId())
+		}
+	}
+
+	for name, routeTable := range p.network.routeTables.routeTables {
+		if !p.RoutesTo(name) || routeTable.Destroyed() {
+			continue
+		}
+		for _, cidr := range p.Cidrs() {
+			if !routeTable.routesTo(cidr, p.id()) {
+				a.Audit(aaa.Mismatched, "Peering %s, %s | RouteTable %s has no route for %s", p.Name(), p.id(), name, cidr)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *peering) help() {
+	commands := []help.Command{
+		{Name: route.Create.String(), Desc: fmt.Sprintf("create %s peering", p.Name())},
+		{Name: route.Destroy.String(), Desc: fmt.Sprintf("destroy %s peering", p.Name())},
+		{Name: route.Audit.String(), Desc: fmt.Sprintf("audit %s peering", p.Name())},
+		{Name: route.Info.String(), Desc: fmt.Sprintf("show information about allocated %s peering", p.Name())},
+		{Name: route.Help.String(), Desc: "show this help"},
+	}
+	help.Print(fmt.Sprintf("network peering %s", p.Name()), commands)
+}
+
+func (p *peering) info() {
+	if p.Destroyed() {
+		return
+	}
+	msg.Info("Peering")
+	msg.Detail("%-20s\t%s", "name", p.Name())
+	msg.Detail("%-20s\t%s", "id", p.id())
+	msg.Detail("%-20s\t%s", "status", p.status())
+	msg.Detail("%-20s\t%s", "peer vpc id", p.VpcId())
+	if info := p.pcx.AccepterVpcInfo; info != nil {
+		msg.Detail("%-20s\t%s", "peer account", aws.StringValue(info.OwnerId))
+		if info.Region != nil {
+			msg.Detail("%-20s\t%s", "peer region", aws.StringValue(info.Region))
+		}
+	}
+	msg.Detail("%-20s\t%s", "cidrs", strings.Join(p.Cidrs(), ", "))
+	printTags(p.pcx.Tags)
+}
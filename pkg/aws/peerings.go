@@ -0,0 +1,187 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+type peerings struct {
+	*resource.Resources
+	network  *network
+	ec2      *ec2.EC2
+	peerings map[string]*peering
+}
+
+func newPeerings(p *dataCenterProvider, n resource.Network) (*peerings, error) {
+	if len(n.Peerings()) == 0 {
+		log.Debug("AWS Peerings not needed. No peerings configured.")
+		return nil, nil
+	}
+	log.Debug("Initializing AWS Peerings")
+
+	pcs := &peerings{
+		Resources: resource.NewResources(),
+		ec2:       p.ec2,
+		peerings:  map[string]*peering{},
+	}
+	for _, cfg := range n.Peerings() {
+		pc, err := newPeering(p, n, cfg)
+		if err != nil {
+			return nil, err
+		}
+		pcs.network = pc.network
+		pcs.peerings[cfg.Name()] = pc
+		pcs.Append(pc)
+	}
+	return pcs, nil
+}
+
+func (p *peerings) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS Peerings")
+
+	if peering := p.find(req.Top()); peering != nil {
+		return peering.Route(req.Pop())
+	}
+	if req.Top() != "" {
+		msg.Error("Unknown peering %q.", req.Top())
+		return route.FAIL
+	}
+
+	if req.TestFlag() {
+		msg.Detail("Test. Skipping...")
+		return route.OK
+	}
+
+	switch req.Command() {
+	case route.Load, route.Create:
+		return p.RouteInOrder(req)
+	case route.Destroy:
+		return p.RouteReverseOrder(req)
+	case route.Audit:
+		if err := p.Audit("Peering"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Help:
+		p.help()
+		return route.OK
+	case route.Info:
+		p.info(req)
+		return route.OK
+	}
+	return route.FAIL
+}
+
+func (p *peerings) find(s string) *peering {
+	return p.peerings[s]
+}
+
+// Audit checks the configured peerings and identifies any peerings of the
+// datacenter's vpc that aren't configured.
+func (p *peerings) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	if err := aaa.NewAudit(flags[0]); err != nil {
+		return err
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	for _, peering := range p.peerings {
+		if err := peering.Audit(flags...); err != nil {
+			return err
+		}
+	}
+	if a == nil || p.network.vpc.id() == "" {
+		return nil
+	}
+
+	configured := map[string]bool{}
+	for _, peering := range p.peerings {
+		configured[peering.id()] = true
+	}
+	for _, side := range []string{"requester-vpc-info.vpc-id", "accepter-vpc-info.vpc-id"} {
+		params := &ec2.DescribeVpcPeeringConnectionsInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String(side),
+					Values: []*string{aws.String(p.network.vpc.id())},
+				},
+				{
+					Name:   aws.String("status-code"),
+					Values: aws.StringSlice(peeringStates),
+				},
+			},
+		}
+		resp, err := p.ec2.DescribeVpcPeeringConnections(params)
+		if err != nil {
+			return err
+		}
+		for _, pc := range resp.VpcPeeringConnections {
+			id := aws.StringValue(pc.VpcPeeringConnectionId)
+			if configured[id] {
+				continue
+			}
+			a.Audit(aaa.Deployed, "Peering %s between %s and %s", id,
+				aws.StringValue(pc.RequesterVpcInfo.VpcId), aws.StringValue(pc.AccepterVpcInfo.VpcId))
+		}
+	}
+	return nil
+}
+
+func (p *peerings) help() {
+	commands := []help.Command{
+		{Name: "'name'", Desc: "manage named peering"},
+		{Name: route.Create.String(), Desc: "create all peerings"},
+		{Name: route.Destroy.String(), Desc: "destroy all peerings"},
+		{Name: route.Audit.String(), Desc: "audit all peerings"},
+		{Name: route.Info.String(), Desc: "show information about all allocated peerings"},
+		{Name: route.Help.String(), Desc: "show this help"},
+	}
+	help.Print("network peering", commands)
+}
+
+func (p *peerings) info(req *route.Request) {
+	if p.Destroyed() {
+		return
+	}
+	msg.Info("Peerings")
+	msg.IndentInc()
+	p.RouteInOrder(req)
+	msg.IndentDec()
+}
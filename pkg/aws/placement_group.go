@@ -0,0 +1,206 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// placementGroup implements the resource.ProviderPlacementGroup interface.
+// The group is named after the datacenter, so pods sharing a group name
+// share the placement group.
+type placementGroup struct {
+	*config.Placement
+	name  string
+	ec2   *ec2.EC2
+	group *ec2.PlacementGroup
+}
+
+// placementGroupName returns the name of the placement group in the given datacenter.
+func placementGroupName(dc string, p *config.Placement) string {
+	return dc + "-" + p.Group()
+}
+
+// newPlacementGroup constructs the aws placement group.
+func newPlacementGroup(pod resource.Pod, cfg *config.Pod, p *dataCenterProvider) (*placementGroup, error) {
+	log.Debug("Initializing AWS Placement Group %q", cfg.Placement().Group())
+	return &placementGroup{
+		Placement: cfg.Placement(),
+		name:      placementGroupName(pod.Cluster().Compute().Name(), cfg.Placement()),
+		ec2:       p.ec2,
+	}, nil
+}
+
+func (g *placementGroup) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS Placement Group %q", g.name)
+
+	switch req.Command() {
+	case route.Load:
+		if err := g.load(); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Info:
+		g.info()
+		return route.OK
+	case route.Create:
+		return g.create(req)
+	case route.Destroy:
+		return g.destroy(req)
+	}
+	return route.OK
+}
+
+func (g *placementGroup) Created() bool {
+	return g.group != nil
+}
+
+func (g *placementGroup) Destroyed() bool {
+	return g.group == nil
+}
+
+func (g *placementGroup) Id() string {
+	if g.group == nil {
+		return ""
+	}
+	return aws.StringValue(g.group.GroupName)
+}
+
+func (g *placementGroup) load() error {
+	resp, err := g.ec2.DescribePlacementGroups(&ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("group-name"),
+				Values: []*string{aws.String(g.name)},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	g.group = nil
+	for _, group := range resp.PlacementGroups {
+		if aws.StringValue(group.State) == "available" {
+			g.group = group
+		}
+	}
+	return nil
+}
+
+func (g *placementGroup) create(req *route.Request) route.Response {
+	if g.Created() {
+		return route.OK
+	}
+	msg.Info("Placement Group Create: %s", g.name)
+	if _, err := g.ec2.CreatePlacementGroup(&ec2.CreatePlacementGroupInput{
+		GroupName: aws.String(g.name),
+		Strategy:  aws.String(g.Strategy()),
+	}); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if !msg.Wait(
+		fmt.Sprintf("Waiting for Placement Group %s to become available", g.name), // title
+		fmt.Sprintf("Placement Group %s failed to become available", g.name),      // err
+		60,                                 // duration
+		func() bool { return g.Created() }, // test()
+		func() bool {
+			if err := g.load(); err != nil {
+				msg.Error(err.Error())
+				return false
+			}
+			return true
+		},
+	) {
+		return route.FAIL
+	}
+	msg.Detail("Created: %s", g.name)
+	aaa.Accounting("Placement group created: %s", g.name)
+	return route.OK
+}
+
+func (g *placementGroup) destroy(req *route.Request) route.Response {
+	if g.Destroyed() {
+		return route.OK
+	}
+	msg.Info("Placement Group Destroy: %s", g.name)
+	if _, err := g.ec2.DeletePlacementGroup(&ec2.DeletePlacementGroupInput{
+		GroupName: aws.String(g.name),
+	}); err != nil {
+		// The group is shared with the instances of another pod.
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "InvalidPlacementGroup.InUse" {
+			msg.Detail("Placement group %s is in use, skipping...", g.name)
+			return route.OK
+		}
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	g.group = nil
+	msg.Detail("Destroyed: %s", g.name)
+	aaa.Accounting("Placement group destroyed: %s", g.name)
+	return route.OK
+}
+
+func (g *placementGroup) info() {
+	if g.Destroyed() {
+		return
+	}
+	msg.Info("Placement Group")
+	msg.Detail("%-20s\t%s", "name", g.name)
+	msg.Detail("%-20s\t%s", "strategy", aws.StringValue(g.group.Strategy))
+	msg.Detail("%-20s\t%s", "state", aws.StringValue(g.group.State))
+}
+
+func (g *placementGroup) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil {
+		return fmt.Errorf("Audit Object does not exist")
+	}
+	if g.Destroyed() {
+		a.Audit(aaa.Configured, "Placement Group %s", g.name)
+		return nil
+	}
+	if s := aws.StringValue(g.group.Strategy); s != g.Strategy() {
+		a.Audit(aaa.Mismatched, "Placement Group %q | Configured Strategy: %q - Deployed Strategy: %q", g.name, g.Strategy(), s)
+	}
+	return nil
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/cisco/arc/pkg/help"
 	"github.com/cisco/arc/pkg/log"
 	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/net"
 	"github.com/cisco/arc/pkg/resource"
 	"github.com/cisco/arc/pkg/route"
 )
@@ -296,12 +297,24 @@ func (r *routeTable) info() {
 		if route.DestinationCidrBlock != nil {
 			msg.Detail("%-20s\t%s", "destination", *route.DestinationCidrBlock)
 		}
+		if route.DestinationIpv6CidrBlock != nil {
+			msg.Detail("%-20s\t%s", "destination", *route.DestinationIpv6CidrBlock)
+		}
 		if route.GatewayId != nil {
 			msg.Detail("%-20s\t%s", "target", *route.GatewayId)
 		}
 		if route.NatGatewayId != nil {
 			msg.Detail("%-20s\t%s", "target", *route.NatGatewayId)
 		}
+		if route.VpcPeeringConnectionId != nil {
+			msg.Detail("%-20s\t%s", "target", *route.VpcPeeringConnectionId)
+		}
+		if route.EgressOnlyInternetGatewayId != nil {
+			msg.Detail("%-20s\t%s", "target", *route.EgressOnlyInternetGatewayId)
+		}
+		if route.InstanceId != nil {
+			msg.Detail("%-20s\t%s", "target", *route.InstanceId)
+		}
 	}
 	msg.IndentDec()
 
@@ -323,6 +336,26 @@ func (r *routeTable) routeCreated(cidrBlock string) bool {
 				return true
 			}
 		}
+		if route.DestinationIpv6CidrBlock != nil {
+			if *route.DestinationIpv6CidrBlock == cidrBlock {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// routesTo returns true if the route table routes the cidr block to the target.
+func (r *routeTable) routesTo(cidrBlock, target string) bool {
+	for _, route := range r.routeTable.Routes {
+		if aws.StringValue(route.DestinationCidrBlock) != cidrBlock && aws.StringValue(route.DestinationIpv6CidrBlock) != cidrBlock {
+			continue
+		}
+		switch target {
+		case aws.StringValue(route.GatewayId), aws.StringValue(route.NatGatewayId), aws.StringValue(route.VpcPeeringConnectionId),
+			aws.StringValue(route.EgressOnlyInternetGatewayId), aws.StringValue(route.InstanceId):
+			return true
+		}
 	}
 	return false
 }
@@ -332,45 +365,101 @@ type gateway int
 const (
 	igw gateway = iota
 	ngw
+	pcx
+	eigw
+	inst
 )
 
+// gatewayTarget returns the kind, name and id of the route's target.
+func gatewayTarget(gw gateway, s resource.Resource) (gwPrefix, gwName, gwId string) {
+	switch gw {
+	case igw:
+		return "InternetGateway", s.(*internetGateway).name(), s.(*internetGateway).id()
+	case ngw:
+		return "NatGateway", s.(*natGateway).name(), s.(*natGateway).id()
+	case pcx:
+		return "PeeringGateway", s.(*peering).Name(), s.(*peering).id()
+	case eigw:
+		return "EgressOnlyInternetGateway", s.(*egressOnlyGateway).name(), s.(*egressOnlyGateway).id()
+	case inst:
+		return "Instance", s.(resource.Instance).Name(), s.(resource.Instance).Id()
+	}
+	return "", "", ""
+}
+
+// routeParams returns the parameters creating the route for the cidr block to the target.
+func (r *routeTable) routeParams(cidrBlock string, gw gateway, gwId string) *ec2.CreateRouteInput {
+	params := &ec2.CreateRouteInput{
+		RouteTableId: aws.String(r.id()),
+	}
+	if net.IsIpv6(cidrBlock) {
+		params.DestinationIpv6CidrBlock = aws.String(cidrBlock)
+	} else {
+		params.DestinationCidrBlock = aws.String(cidrBlock)
+	}
+
+	switch gw {
+	case igw:
+		params.GatewayId = aws.String(gwId)
+	case ngw:
+		params.NatGatewayId = aws.String(gwId)
+	case pcx:
+		params.VpcPeeringConnectionId = aws.String(gwId)
+	case eigw:
+		params.EgressOnlyInternetGatewayId = aws.String(gwId)
+	case inst:
+		params.InstanceId = aws.String(gwId)
+	}
+	return params
+}
+
 func (r *routeTable) createRoute(req *route.Request, cidrBlock string, gw gateway, s resource.Resource) route.Response {
 	if r.Destroyed() || s.Destroyed() {
 		return route.OK
 	}
 
-	gwPrefix := ""
-	gwName := ""
-	gwId := ""
-
-	if gw == igw {
-		gwPrefix = "Internet"
-		gwName = s.(*internetGateway).name()
-		gwId = s.(*internetGateway).id()
-	} else if gw == ngw {
-		gwPrefix = "Nat"
-		gwName = s.(*natGateway).name()
-		gwId = s.(*natGateway).id()
-	}
+	gwPrefix, gwName, gwId := gatewayTarget(gw, s)
 
-	msg.Info("Creating Route in RouteTable %s for %s to %sGateway %s", r.name(), cidrBlock, gwPrefix, gwName)
+	msg.Info("Creating Route in RouteTable %s for %s to %s %s", r.name(), cidrBlock, gwPrefix, gwName)
 	if r.routeCreated(cidrBlock) {
 		msg.Detail("Route exists, skipping...")
 		return route.OK
 	}
 
-	params := &ec2.CreateRouteInput{
-		DestinationCidrBlock: aws.String(cidrBlock),
-		RouteTableId:         aws.String(r.id()),
+	if _, err := r.ec2.CreateRoute(r.routeParams(cidrBlock, gw, gwId)); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if err := r.Load(); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
 	}
+	aaa.Accounting("Creating Route in RouteTable %s for %s to %s %s", r.id(), cidrBlock, gwName, gwId)
+	return route.OK
+}
 
-	if gw == igw {
-		params.GatewayId = aws.String(gwId)
-	} else if gw == ngw {
-		params.NatGatewayId = aws.String(gwId)
+// replaceRoute points the existing route for the cidr block to a new target. The
+// route is replaced in place so traffic keeps flowing during the change.
+func (r *routeTable) replaceRoute(req *route.Request, cidrBlock string, gw gateway, s resource.Resource) route.Response {
+	if r.Destroyed() || s.Destroyed() {
+		return route.OK
 	}
 
-	if _, err := r.ec2.CreateRoute(params); err != nil {
+	gwPrefix, gwName, gwId := gatewayTarget(gw, s)
+
+	msg.Info("Replacing Route in RouteTable %s for %s to %s %s", r.name(), cidrBlock, gwPrefix, gwName)
+	p := r.routeParams(cidrBlock, gw, gwId)
+	params := &ec2.ReplaceRouteInput{
+		RouteTableId:                p.RouteTableId,
+		DestinationCidrBlock:        p.DestinationCidrBlock,
+		DestinationIpv6CidrBlock:    p.DestinationIpv6CidrBlock,
+		GatewayId:                   p.GatewayId,
+		NatGatewayId:                p.NatGatewayId,
+		VpcPeeringConnectionId:      p.VpcPeeringConnectionId,
+		EgressOnlyInternetGatewayId: p.EgressOnlyInternetGatewayId,
+		InstanceId:                  p.InstanceId,
+	}
+	if _, err := r.ec2.ReplaceRoute(params); err != nil {
 		msg.Error(err.Error())
 		return route.FAIL
 	}
@@ -378,10 +467,25 @@ func (r *routeTable) createRoute(req *route.Request, cidrBlock string, gw gatewa
 		msg.Error(err.Error())
 		return route.FAIL
 	}
-	aaa.Accounting("Creating Route in RouteTable %s for %s to %sGateway %s", r.id(), cidrBlock, gwName, gwId)
+	aaa.Accounting("Replacing Route in RouteTable %s for %s to %s %s", r.id(), cidrBlock, gwName, gwId)
 	return route.OK
 }
 
+// provisionRoute routes the cidr block to the target, creating the route when
+// it doesn't exist and replacing it when it routes to another target.
+func (r *routeTable) provisionRoute(req *route.Request, cidrBlock string, gw gateway, s resource.Resource) route.Response {
+	if r.Destroyed() || s.Destroyed() {
+		return route.OK
+	}
+	if !r.routeCreated(cidrBlock) {
+		return r.createRoute(req, cidrBlock, gw, s)
+	}
+	if _, _, gwId := gatewayTarget(gw, s); r.routesTo(cidrBlock, gwId) {
+		return route.OK
+	}
+	return r.replaceRoute(req, cidrBlock, gw, s)
+}
+
 func (r *routeTable) deleteRoute(req *route.Request, cidrBlock string) route.Response {
 	if r.Destroyed() {
 		return route.OK
@@ -393,8 +497,12 @@ func (r *routeTable) deleteRoute(req *route.Request, cidrBlock string) route.Res
 	}
 
 	params := &ec2.DeleteRouteInput{
-		DestinationCidrBlock: aws.String(cidrBlock),
-		RouteTableId:         aws.String(r.id()),
+		RouteTableId: aws.String(r.id()),
+	}
+	if net.IsIpv6(cidrBlock) {
+		params.DestinationIpv6CidrBlock = aws.String(cidrBlock)
+	} else {
+		params.DestinationCidrBlock = aws.String(cidrBlock)
 	}
 	if _, err := r.ec2.DeleteRoute(params); err != nil {
 		msg.Error(err.Error())
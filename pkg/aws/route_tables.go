@@ -68,6 +68,9 @@ func newRouteTables(c *ec2.EC2, n *network) (*routeTables, error) {
 
 	// Create routetables for subnets that have "manage_route" set to true.
 	for _, subnetGroup := range *n.SubnetGroups {
+		if !n.owns(subnetGroup.Target()) {
+			continue
+		}
 		if subnetGroup.ManageRoutes() == true {
 			switch subnetGroup.Access() {
 			case "public", "public_elastic", "local":
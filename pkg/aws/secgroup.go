@@ -28,6 +28,7 @@ package aws
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -49,10 +50,18 @@ type securityGroup struct {
 	secgroup *ec2.SecurityGroup
 	id       string
 	secrules *securityRules
+	loaded   bool
+
+	// The provider network the security group lives in, and the copies of
+	// the security group in the networks of the provider targets.
+	net     *network
+	targets map[string]*securityGroup
 }
 
-// newSecurityGroup constructs the aws security group.
-func newSecurityGroup(net resource.Network, cfg *config.SecurityGroup, c *ec2.EC2) (*securityGroup, error) {
+// newSecurityGroup constructs the aws security group. The security group is
+// copied into the network of each provider target so the instances pinned to
+// a target can use it.
+func newSecurityGroup(net resource.Network, cfg *config.SecurityGroup, p *dataCenterProvider) (*securityGroup, error) {
 	log.Debug("Initializing AWS Security Group %q", cfg.Name())
 
 	n, ok := net.ProviderNetwork().(*network)
@@ -62,18 +71,69 @@ func newSecurityGroup(net resource.Network, cfg *config.SecurityGroup, c *ec2.EC
 
 	s := &securityGroup{
 		SecurityGroup: cfg,
-		ec2:           c,
+		ec2:           p.ec2,
 		network:       net,
+		net:           n,
+		targets:       map[string]*securityGroup{},
 	}
 	s.secrules = newSecurityRules(s)
-	s.set(n.secgroupCache.find(s))
 
+	for _, t := range n.all()[1:] {
+		c := &securityGroup{
+			SecurityGroup: cfg,
+			ec2:           t.ec2,
+			network:       net,
+			net:           t,
+		}
+		c.secrules = newSecurityRules(c)
+		s.targets[t.key] = c
+	}
 	return s, nil
 }
 
+// forNetwork returns the copy of the security group in the given network.
+func (s *securityGroup) forNetwork(n *network) *securityGroup {
+	if t := s.targets[n.key]; t != nil {
+		return t
+	}
+	return s
+}
+
+// all returns the security group along with its copies in the networks of
+// the provider targets.
+func (s *securityGroup) all() []*securityGroup {
+	secgroups := []*securityGroup{s}
+	keys := []string{}
+	for k := range s.targets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		secgroups = append(secgroups, s.targets[k])
+	}
+	return secgroups
+}
+
 func (s *securityGroup) Route(req *route.Request) route.Response {
 	log.Route(req, "AWS Security Group %q", s.Name())
 
+	for _, t := range s.all()[1:] {
+		if req.Command() == route.Info {
+			msg.Info("Security Group Target %s", t.net.key)
+			msg.IndentInc()
+		}
+		resp := t.route(req)
+		if req.Command() == route.Info {
+			msg.IndentDec()
+		}
+		if resp != route.OK {
+			return resp
+		}
+	}
+	return s.route(req)
+}
+
+func (s *securityGroup) route(req *route.Request) route.Response {
 	switch req.Command() {
 	case route.Create:
 		return s.create(req)
@@ -127,19 +187,39 @@ func (s *securityGroup) clear() {
 }
 
 func (s *securityGroup) Load() error {
+	for _, t := range s.all() {
+		if err := t.load(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *securityGroup) load() error {
 
 	// Use a cached value if it exists.
 	if s.secgroup != nil {
 		log.Debug("Skipping security group load, cached...")
 		return nil
 	}
+	// Use the security group cache on the first load. Later loads need the current value from AWS.
+	if !s.loaded {
+		s.loaded = true
+		if err := s.net.loadCaches(); err != nil {
+			return err
+		}
+		s.set(s.net.secgroupCache.find(s))
+		if s.secgroup != nil {
+			return nil
+		}
+	}
 
 	params := &ec2.DescribeSecurityGroupsInput{
 		Filters: []*ec2.Filter{
 			{
 				Name: aws.String("vpc-id"),
 				Values: []*string{
-					aws.String(s.network.Id()),
+					aws.String(s.net.vpc.id()),
 				},
 			},
 			{
@@ -193,7 +273,7 @@ func (s *securityGroup) reload(test func() bool) bool {
 		60,   // duration
 		test, // test()
 		func() bool { // load()
-			if err := s.Load(); err != nil {
+			if err := s.load(); err != nil {
 				msg.Error(err.Error())
 				return false
 			}
@@ -216,7 +296,7 @@ func (s *securityGroup) create(req *route.Request) route.Response {
 	params := &ec2.CreateSecurityGroupInput{
 		Description: aws.String(s.Name()),
 		GroupName:   aws.String(s.Name()),
-		VpcId:       aws.String(s.network.Id()),
+		VpcId:       aws.String(s.net.vpc.id()),
 	}
 	resp, err := s.ec2.CreateSecurityGroup(params)
 	if err != nil {
@@ -320,8 +400,7 @@ func (s *securityGroup) destroy(req *route.Request) route.Response {
 	msg.Detail("Destroyed: %s", s.Id())
 	aaa.Accounting("SecurityGroup destroyed: %s", s.Id())
 	s.clear()
-	n := s.network.ProviderNetwork().(*network)
-	n.secgroupCache.remove(s.Name())
+	s.net.secgroupCache.remove(s.Name())
 	return route.OK
 }
 
@@ -358,7 +437,7 @@ func (s *securityGroup) update(req *route.Request) route.Response {
 		msg.Error(err.Error())
 		return route.FAIL
 	}
-	if err := s.Load(); err != nil {
+	if err := s.load(); err != nil {
 		return route.FAIL
 	}
 	// Update tags
@@ -370,6 +449,15 @@ func (s *securityGroup) update(req *route.Request) route.Response {
 }
 
 func (s *securityGroup) Audit(flags ...string) error {
+	for _, t := range s.all() {
+		if err := t.audit(flags...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *securityGroup) audit(flags ...string) error {
 	if len(flags) == 0 || flags[0] == "" {
 		return fmt.Errorf("No flag set to find audit object")
 	}
@@ -43,16 +43,22 @@ type securityGroupCacheEntry struct {
 }
 
 type securityGroupCache struct {
+	network *network
 	cache   map[string]*securityGroupCacheEntry
 	unnamed []*ec2.SecurityGroup
 }
 
-func newSecurityGroupCache(n *network) (*securityGroupCache, error) {
+func newSecurityGroupCache(n *network) *securityGroupCache {
 	log.Debug("Initializing AWS SecurityGroup Cache")
-
-	c := &securityGroupCache{
-		cache: map[string]*securityGroupCacheEntry{},
+	return &securityGroupCache{
+		network: n,
+		cache:   map[string]*securityGroupCacheEntry{},
 	}
+}
+
+func (c *securityGroupCache) load() error {
+	log.Debug("Loading AWS SecurityGroup Cache")
+	n := c.network
 
 	params := &ec2.DescribeSecurityGroupsInput{
 		Filters: []*ec2.Filter{
@@ -66,7 +72,7 @@ func newSecurityGroupCache(n *network) (*securityGroupCache, error) {
 	}
 	resp, err := n.ec2.DescribeSecurityGroups(params)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	log.Debug("Load Security Groups: %d", len(resp.SecurityGroups))
 
@@ -103,7 +109,7 @@ func newSecurityGroupCache(n *network) (*securityGroupCache, error) {
 		log.Debug("Caching %s", name)
 		c.cache[name] = &securityGroupCacheEntry{deployed: s}
 	}
-	return c, nil
+	return nil
 }
 
 func (c *securityGroupCache) find(s *securityGroup) *ec2.SecurityGroup {
@@ -0,0 +1,220 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/route"
+)
+
+// ImportSecgroup satisfies the resource.DynamicNetwork interface. It converts
+// the permissions of a deployed security group, given by id, name tag or group
+// name, into a security group configuration. Cidrs are mapped back to the
+// network's cidr aliases and subnet groups where they match.
+func (n *network) ImportSecgroup(group string) (*config.SecurityGroup, error) {
+	if err := n.loadCaches(); err != nil {
+		return nil, err
+	}
+	s := n.secgroupCache.lookup(group)
+	if s == nil {
+		return nil, fmt.Errorf("Unknown security group %q", group)
+	}
+	name := aws.StringValue(s.GroupName)
+	for _, t := range s.Tags {
+		if aws.StringValue(t.Key) == "Name" && aws.StringValue(t.Value) != "" {
+			name = aws.StringValue(t.Value)
+		}
+	}
+	rules := config.SecurityRules{}
+	for _, p := range s.IpPermissions {
+		rules = append(rules, n.importRule(s, "ingress", p))
+	}
+	for _, p := range s.IpPermissionsEgress {
+		rules = append(rules, n.importRule(s, "egress", p))
+	}
+	return &config.SecurityGroup{Name_: name, SecurityRules: &rules}, nil
+}
+
+// AdoptSecgroup satisfies the resource.DynamicNetwork interface. It tags the
+// deployed security group with the given name so that it is managed as the
+// configured security group of that name.
+func (n *network) AdoptSecgroup(req *route.Request, group, name string) error {
+	if err := n.loadCaches(); err != nil {
+		return err
+	}
+	s := n.secgroupCache.lookup(group)
+	if s == nil {
+		return fmt.Errorf("Unknown security group %q", group)
+	}
+	if e := n.secgroupCache.cache[name]; e != nil && e.deployed != s {
+		return fmt.Errorf("Security group %s, %s is already named %q", aws.StringValue(e.deployed.GroupId), name, name)
+	}
+	id := aws.StringValue(s.GroupId)
+	msg.Info("SecurityGroup Adoption: %s", name)
+	if err := createTags(n.ec2, name, id, req); err != nil {
+		return err
+	}
+	msg.Detail("Adopted: %s, %s", name, id)
+	aaa.Accounting("SecurityGroup adopted: %s, %s", name, id)
+	return nil
+}
+
+func (n *network) importRule(s *ec2.SecurityGroup, direction string, p *ec2.IpPermission) *config.SecurityRule {
+	description := ""
+	cidrs := []string{}
+	for _, r := range p.IpRanges {
+		cidrs = append(cidrs, aws.StringValue(r.CidrIp))
+		if description == "" {
+			description = aws.StringValue(r.Description)
+		}
+	}
+	for _, r := range p.Ipv6Ranges {
+		cidrs = append(cidrs, aws.StringValue(r.CidrIpv6))
+		if description == "" {
+			description = aws.StringValue(r.Description)
+		}
+	}
+	remotes := n.importCidrs(cidrs)
+	for _, pair := range p.UserIdGroupPairs {
+		if description == "" {
+			description = aws.StringValue(pair.Description)
+		}
+		id := aws.StringValue(pair.GroupId)
+		remote := n.secgroupCache.nameOf(id)
+		if remote == "" {
+			log.Warn("Security group %s refers to the unnamed security group %s", aws.StringValue(s.GroupId), id)
+			remote = id
+		}
+		remotes = append(remotes, "security_group:"+remote)
+	}
+	if description == "" {
+		description = fmt.Sprintf("Imported from %s", aws.StringValue(s.GroupId))
+	}
+
+	protocol := aws.StringValue(p.IpProtocol)
+	port := "-1"
+	if p.FromPort != nil && aws.Int64Value(p.FromPort) != -1 {
+		from, to := aws.Int64Value(p.FromPort), aws.Int64Value(p.ToPort)
+		port = fmt.Sprintf("%d", from)
+		// The icmp "ports" are the icmp type and code, which are kept even when they are equal.
+		if from != to || protocol == "icmp" {
+			port = fmt.Sprintf("%d:%d", from, to)
+		}
+	}
+	return &config.SecurityRule{
+		Description_: description,
+		Directions_:  []string{direction},
+		Remotes_:     remotes,
+		Protocols_:   []string{protocol},
+		Ports_:       []string{port},
+	}
+}
+
+// importCidrs converts cidrs into remotes. All the cidrs of a subnet group
+// become a subnet_group remote, cidrs with an alias become an aliased cidr
+// remote and the rest are kept as is.
+func (n *network) importCidrs(cidrs []string) []string {
+	remotes := []string{}
+	left := map[string]bool{}
+	for _, c := range cidrs {
+		left[c] = true
+	}
+	if n.SubnetGroups != nil {
+		for _, g := range *n.SubnetGroups {
+			groupCidrs, err := n.SubnetGroupCidrs(g.Name())
+			if err != nil || len(groupCidrs) == 0 {
+				continue
+			}
+			all := true
+			for _, c := range groupCidrs {
+				all = all && left[c]
+			}
+			if !all {
+				continue
+			}
+			for _, c := range groupCidrs {
+				delete(left, c)
+			}
+			remotes = append(remotes, "subnet_group:"+g.Name())
+		}
+	}
+	aliases := map[string]string{}
+	for alias, cidr := range n.CidrAliases() {
+		if a, ok := aliases[cidr]; !ok || alias < a {
+			aliases[cidr] = alias
+		}
+	}
+	rest := []string{}
+	for _, c := range cidrs {
+		if !left[c] {
+			continue
+		}
+		delete(left, c)
+		if alias, ok := aliases[c]; ok {
+			c = alias
+		}
+		rest = append(rest, "cidr:"+c)
+	}
+	sort.Strings(rest)
+	return append(remotes, rest...)
+}
+
+// lookup finds a deployed security group by id, name tag or group name.
+func (c *securityGroupCache) lookup(group string) *ec2.SecurityGroup {
+	if e := c.cache[group]; e != nil {
+		return e.deployed
+	}
+	all := append([]*ec2.SecurityGroup{}, c.unnamed...)
+	for _, e := range c.cache {
+		all = append(all, e.deployed)
+	}
+	for _, s := range all {
+		if aws.StringValue(s.GroupId) == group || aws.StringValue(s.GroupName) == group {
+			return s
+		}
+	}
+	return nil
+}
+
+// nameOf returns the name tag of the deployed security group with the given id.
+func (c *securityGroupCache) nameOf(id string) string {
+	for name, e := range c.cache {
+		if aws.StringValue(e.deployed.GroupId) == id {
+			return name
+		}
+	}
+	return ""
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/cisco/arc/pkg/aaa"
 	"github.com/cisco/arc/pkg/log"
 	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/net"
 	"github.com/cisco/arc/pkg/resource"
 	"github.com/cisco/arc/pkg/route"
 )
@@ -66,7 +67,7 @@ func (r *securityRules) populate(s *securityGroup) error {
 						}
 
 						// If we have multiple availability zones, a single remote can generate multiple ipRanges.
-						ipRanges, userIdGroupPairs, err := parseRemote(s.network, remote)
+						ipRanges, ipv6Ranges, userIdGroupPairs, err := parseRemote(s.network, s.net, remote)
 						if err != nil {
 							return err
 						}
@@ -95,6 +96,9 @@ func (r *securityRules) populate(s *securityGroup) error {
 									existingRule.IpRanges = append(existingRule.IpRanges, ipRange)
 								}
 							}
+							for _, ipv6Range := range ipv6Ranges {
+								existingRule.Ipv6Ranges = append(existingRule.Ipv6Ranges, ipv6Range)
+							}
 							if userIdGroupPairs != nil {
 								for _, userIdGroupPair := range userIdGroupPairs {
 									existingRule.UserIdGroupPairs = append(existingRule.UserIdGroupPairs, userIdGroupPair)
@@ -107,6 +111,9 @@ func (r *securityRules) populate(s *securityGroup) error {
 						if ipRanges != nil {
 							newRule.IpRanges = ipRanges
 						}
+						if len(ipv6Ranges) > 0 {
+							newRule.Ipv6Ranges = ipv6Ranges
+						}
 						if userIdGroupPairs != nil {
 							newRule.UserIdGroupPairs = userIdGroupPairs
 						}
@@ -281,6 +288,11 @@ func (r *securityRules) infoIngress() {
 				msg.Detail("%-20s\t%s", "cidr", *ipRange.CidrIp)
 			}
 		}
+		for _, ipv6Range := range ingressRule.Ipv6Ranges {
+			if ipv6Range.CidrIpv6 != nil {
+				msg.Detail("%-20s\t%s", "cidr", *ipv6Range.CidrIpv6)
+			}
+		}
 		if ingressRule.ToPort != nil {
 			msg.Detail("%-20s\t%d", "to", *ingressRule.ToPort)
 		}
@@ -304,6 +316,11 @@ func (r *securityRules) infoEgress() {
 				msg.Detail("%-20s\t%s", "cidr", *ipRange.CidrIp)
 			}
 		}
+		for _, ipv6Range := range egressRule.Ipv6Ranges {
+			if ipv6Range.CidrIpv6 != nil {
+				msg.Detail("%-20s\t%s", "cidr", *ipv6Range.CidrIpv6)
+			}
+		}
 		if egressRule.ToPort != nil {
 			msg.Detail("%-20s\t%d", "to", *egressRule.ToPort)
 		}
@@ -334,13 +351,25 @@ func parsePort(port string) (toPort, fromPort int64, err error) {
 	return
 }
 
-func parseRemote(network resource.Network, remote string) ([]*ec2.IpRange, []*ec2.UserIdGroupPair, error) {
+// parseRemote expands the remote into the ipv4 and ipv6 ranges or the security groups
+// it refers to. Cidrs may be either ipv4 or ipv6, e.g. cidr:2001:db8::/32. Security
+// groups resolve to their copy in the given provider network.
+func parseRemote(network resource.Network, pn *network, remote string) ([]*ec2.IpRange, []*ec2.Ipv6Range, []*ec2.UserIdGroupPair, error) {
 	ipRanges := []*ec2.IpRange{}
+	ipv6Ranges := []*ec2.Ipv6Range{}
 	userIdGroupPair := []*ec2.UserIdGroupPair{}
 
-	s := strings.Split(remote, ":")
+	addCidr := func(cidr string) {
+		if net.IsIpv6(cidr) {
+			ipv6Ranges = append(ipv6Ranges, &ec2.Ipv6Range{CidrIpv6: aws.String(cidr)})
+			return
+		}
+		ipRanges = append(ipRanges, &ec2.IpRange{CidrIp: aws.String(cidr)})
+	}
+
+	s := strings.SplitN(remote, ":", 2)
 	if len(s) != 2 {
-		return nil, nil, fmt.Errorf("Malformed remote: %s", remote)
+		return nil, nil, nil, fmt.Errorf("Malformed remote: %s", remote)
 	}
 	protocol := s[0]
 	dest := s[1]
@@ -349,41 +378,63 @@ func parseRemote(network resource.Network, remote string) ([]*ec2.IpRange, []*ec
 	case "subnet_group":
 		subnetGroup := network.SubnetGroups().Find(dest)
 		if subnetGroup == nil {
-			return nil, nil, fmt.Errorf("Unknown subnet_group: %s", dest)
-		}
-		for _, subnet := range subnetGroup.Subnets() {
-			ipRanges = append(ipRanges, &ec2.IpRange{CidrIp: aws.String(subnet.CidrBlock())})
+			return nil, nil, nil, fmt.Errorf("Unknown subnet_group: %s", dest)
+		}
+		for _, sub := range subnetGroup.Subnets() {
+			addCidr(sub.CidrBlock())
+			if ps, ok := sub.ProviderSubnet().(*subnet); ok {
+				ipv6CidrBlock, err := ps.configuredIpv6CidrBlock()
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				if ipv6CidrBlock != "" {
+					addCidr(ipv6CidrBlock)
+				}
+			}
 		}
-		return ipRanges, nil, nil
+		return ipRanges, ipv6Ranges, nil, nil
 	case "cidr":
 		ip := network.CidrAlias(dest)
 		if ip != "" {
 			dest = ip
 		}
-		ipRanges = append(ipRanges, &ec2.IpRange{CidrIp: aws.String(dest)})
-		return ipRanges, nil, nil
+		addCidr(dest)
+		return ipRanges, ipv6Ranges, nil, nil
 	case "cidr_group":
 		group := network.CidrGroup(dest)
 		if group == nil {
-			return nil, nil, fmt.Errorf("Cidr group %s does not exist", dest)
+			return nil, nil, nil, fmt.Errorf("Cidr group %s does not exist", dest)
 		}
 		for _, v := range group {
 			ip := v
 			if ip != "" {
 				dest = ip
 			}
-			ipRanges = append(ipRanges, &ec2.IpRange{CidrIp: aws.String(dest)})
+			addCidr(dest)
 		}
-		return ipRanges, nil, nil
+		return ipRanges, ipv6Ranges, nil, nil
+	case "peer":
+		peering := network.Peerings().Find(dest)
+		if peering == nil {
+			return nil, nil, nil, fmt.Errorf("Unknown peer: %s", dest)
+		}
+		for _, cidr := range peering.Cidrs() {
+			addCidr(cidr)
+		}
+		return ipRanges, ipv6Ranges, nil, nil
 	case "security_group":
-		securityGroup := network.SecurityGroups().Find(dest)
-		if securityGroup == nil {
-			return nil, nil, fmt.Errorf("Unknown security_group: %s", dest)
+		group := network.SecurityGroups().Find(dest)
+		if group == nil {
+			return nil, nil, nil, fmt.Errorf("Unknown security_group: %s", dest)
+		}
+		id := group.Id()
+		if sg, ok := group.ProviderSecurityGroup().(*securityGroup); ok {
+			id = sg.forNetwork(pn).Id()
 		}
-		userIdGroupPair = append(userIdGroupPair, &ec2.UserIdGroupPair{GroupId: aws.String(securityGroup.Id())})
-		return nil, userIdGroupPair, nil
+		userIdGroupPair = append(userIdGroupPair, &ec2.UserIdGroupPair{GroupId: aws.String(id)})
+		return nil, nil, userIdGroupPair, nil
 	}
-	return nil, nil, fmt.Errorf("Unknown remote: %s", protocol)
+	return nil, nil, nil, fmt.Errorf("Unknown remote: %s", protocol)
 }
 
 func rulesContain(rules []*ec2.IpPermission, rule *ec2.IpPermission, checkIpRange bool) *ec2.IpPermission {
@@ -449,6 +500,24 @@ func ipRangesEqual(r []*ec2.IpRange, l []*ec2.IpRange) bool {
 	return true
 }
 
+func ipv6RangesEqual(r []*ec2.Ipv6Range, l []*ec2.Ipv6Range) bool {
+	if len(r) != len(l) {
+		return false
+	}
+	for _, rIp := range r {
+		match := false
+		for _, lIp := range l {
+			if aws.StringValue(rIp.CidrIpv6) == aws.StringValue(lIp.CidrIpv6) {
+				match = true
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
 func ruleEqual(r *ec2.IpPermission, l *ec2.IpPermission, checkIpRanges bool) bool {
 	if !protocolEqual(r.IpProtocol, l.IpProtocol) {
 		return false
@@ -465,6 +534,9 @@ func ruleEqual(r *ec2.IpPermission, l *ec2.IpPermission, checkIpRanges bool) boo
 	if !ipRangesEqual(r.IpRanges, l.IpRanges) {
 		return false
 	}
+	if !ipv6RangesEqual(r.Ipv6Ranges, l.Ipv6Ranges) {
+		return false
+	}
 	return true
 }
 
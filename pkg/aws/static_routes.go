@@ -0,0 +1,370 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/help"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
+)
+
+type staticRoutes struct {
+	*resource.Resources
+	staticRoutes []*staticRoute
+}
+
+// newStaticRoutes creates the static routes of the subnet groups. It returns
+// nil if no static routes are configured.
+func newStaticRoutes(n resource.Network, peerings *peerings) (*staticRoutes, error) {
+	net, ok := n.ProviderNetwork().(*network)
+	if !ok {
+		return nil, fmt.Errorf("AWS newStaticRoutes: Unable to obtain provider network")
+	}
+	s := &staticRoutes{
+		Resources: resource.NewResources(),
+	}
+	for _, subnetGroup := range n.SubnetGroups().Get() {
+		for _, cfg := range subnetGroup.Routes() {
+			r, err := newStaticRoute(n, net, peerings, subnetGroup, cfg)
+			if err != nil {
+				return nil, err
+			}
+			s.staticRoutes = append(s.staticRoutes, r)
+			s.Append(r)
+		}
+	}
+	if len(s.staticRoutes) == 0 {
+		log.Debug("AWS StaticRoutes not needed. No static routes configured.")
+		return nil, nil
+	}
+	log.Debug("Initializing AWS StaticRoutes")
+	return s, nil
+}
+
+func (s *staticRoutes) Route(req *route.Request) route.Response {
+	log.Route(req, "AWS StaticRoutes")
+
+	if req.Top() != "" {
+		s.help()
+		return route.FAIL
+	}
+
+	if req.TestFlag() {
+		msg.Detail("Test. Skipping...")
+		return route.OK
+	}
+
+	switch req.Command() {
+	case route.Load:
+		return route.OK
+	case route.Create, route.Provision:
+		return s.RouteInOrder(req)
+	case route.Destroy:
+		return s.RouteReverseOrder(req)
+	case route.Audit:
+		if err := s.Audit("StaticRoute"); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		return route.OK
+	case route.Help:
+		s.help()
+		return route.OK
+	case route.Info:
+		s.info(req)
+		return route.OK
+	}
+	return route.FAIL
+}
+
+// Audit checks the configured static routes.
+func (s *staticRoutes) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	if err := aaa.NewAudit(flags[0]); err != nil {
+		return err
+	}
+	for _, r := range s.staticRoutes {
+		if err := r.Audit(flags...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *staticRoutes) help() {
+	commands := []help.Command{
+		{Name: route.Create.String(), Desc: "create all static routes"},
+		{Name: route.Provision.String(), Desc: "create or replace all static routes"},
+		{Name: route.Destroy.String(), Desc: "destroy all static routes"},
+		{Name: route.Audit.String(), Desc: "audit all static routes"},
+		{Name: route.Info.String(), Desc: "show information about all static routes"},
+		{Name: route.Help.String(), Desc: "show this help"},
+	}
+	help.Print("network routes", commands)
+}
+
+func (s *staticRoutes) info(req *route.Request) {
+	if s.Destroyed() {
+		return
+	}
+	msg.Info("StaticRoutes")
+	msg.IndentInc()
+	s.RouteInOrder(req)
+	msg.IndentDec()
+}
+
+// staticRoute routes a destination of a subnet group's route tables to a
+// peering or to an instance such as a vpn appliance.
+type staticRoute struct {
+	*config.StaticRoute
+	ec2         *ec2.EC2
+	net         resource.Network
+	peerings    *peerings
+	subnetGroup string
+	routeTables []*routeTable
+}
+
+func newStaticRoute(n resource.Network, net *network, peerings *peerings, subnetGroup resource.SubnetGroup, cfg *config.StaticRoute) (*staticRoute, error) {
+	r := &staticRoute{
+		StaticRoute: cfg,
+		ec2:         net.ec2,
+		net:         n,
+		peerings:    peerings,
+		subnetGroup: subnetGroup.Name(),
+	}
+
+	// Public and local subnet groups share one route table, private subnet groups
+	// have a route table per availability zone.
+	names := []string{subnetGroup.Name()}
+	if subnetGroup.Access() == "private" {
+		names = []string{}
+		for _, availabilityZone := range n.AvailabilityZones() {
+			names = append(names, subnetGroup.Name()+"-"+availabilityZone)
+		}
+	}
+	for _, name := range names {
+		routeTable := net.routeTables.find(name)
+		if routeTable == nil {
+			return nil, fmt.Errorf("Cannot find route table %s for the static routes of subnet group %s", name, subnetGroup.Name())
+		}
+		r.routeTables = append(r.routeTables, routeTable)
+	}
+	return r, nil
+}
+
+func (r *staticRoute) Route(req *route.Request) route.Response {
+	switch req.Command() {
+	case route.Create, route.Provision:
+		return r.provision(req)
+	case route.Destroy:
+		return r.destroy(req)
+	case route.Info:
+		r.info()
+		return route.OK
+	}
+	return route.FAIL
+}
+
+// target returns the gateway kind and the resource the route sends traffic to.
+// It returns nil when the target doesn't exist yet.
+func (r *staticRoute) target() (gateway, resource.Resource) {
+	if r.Peering() != "" {
+		if p := r.peerings.find(r.Peering()); p != nil && p.Created() {
+			return pcx, p
+		}
+		return pcx, nil
+	}
+	if c := r.net.DataCenter().Compute(); c != nil {
+		if i := c.FindInstance(r.Instance()); i != nil && i.Created() {
+			return inst, i
+		}
+	}
+	return inst, nil
+}
+
+func (r *staticRoute) targetName() string {
+	if r.Peering() != "" {
+		return "peering " + r.Peering()
+	}
+	return "instance " + r.Instance()
+}
+
+func (r *staticRoute) Created() bool {
+	gw, s := r.target()
+	if s == nil {
+		return false
+	}
+	_, _, gwId := gatewayTarget(gw, s)
+	for _, routeTable := range r.routeTables {
+		if !routeTable.routesTo(r.Destination(), gwId) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *staticRoute) Destroyed() bool {
+	gw, s := r.target()
+	if s == nil {
+		return true
+	}
+	_, _, gwId := gatewayTarget(gw, s)
+	for _, routeTable := range r.routeTables {
+		if routeTable.routesTo(r.Destination(), gwId) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *staticRoute) provision(req *route.Request) route.Response {
+	gw, s := r.target()
+	if s == nil {
+		msg.Warn("Static route %s of subnet group %s: %s does not exist, run 'provision network' once it is created", r.Destination(), r.subnetGroup, r.targetName())
+		return route.OK
+	}
+	if gw == inst {
+		if resp := r.disableSourceDestCheck(req, s.(resource.Instance)); resp != route.OK {
+			return resp
+		}
+	}
+	for _, routeTable := range r.routeTables {
+		if resp := routeTable.provisionRoute(req, r.Destination(), gw, s); resp != route.OK {
+			return resp
+		}
+	}
+	return route.OK
+}
+
+// sourceDestCheck returns whether the instance drops traffic that isn't addressed to it.
+func (r *staticRoute) sourceDestCheck(i resource.Instance) (bool, error) {
+	params := &ec2.DescribeInstanceAttributeInput{
+		Attribute:  aws.String(ec2.InstanceAttributeNameSourceDestCheck),
+		InstanceId: aws.String(i.Id()),
+	}
+	resp, err := r.ec2.DescribeInstanceAttribute(params)
+	if err != nil {
+		return false, err
+	}
+	return resp.SourceDestCheck == nil || aws.BoolValue(resp.SourceDestCheck.Value), nil
+}
+
+// disableSourceDestCheck lets the instance target forward the routed traffic.
+func (r *staticRoute) disableSourceDestCheck(req *route.Request, i resource.Instance) route.Response {
+	enabled, err := r.sourceDestCheck(i)
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if !enabled {
+		return route.OK
+	}
+	msg.Info("Disabling the source/destination check of instance %s for static route %s", i.Name(), r.Destination())
+	params := &ec2.ModifyInstanceAttributeInput{
+		InstanceId: aws.String(i.Id()),
+		SourceDestCheck: &ec2.AttributeBooleanValue{
+			Value: aws.Bool(false),
+		},
+	}
+	if _, err := r.ec2.ModifyInstanceAttribute(params); err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	aaa.Accounting("Disabled the source/destination check of instance %s", i.Id())
+	return route.OK
+}
+
+func (r *staticRoute) destroy(req *route.Request) route.Response {
+	gw, s := r.target()
+	if s == nil {
+		return route.OK
+	}
+	_, _, gwId := gatewayTarget(gw, s)
+	for _, routeTable := range r.routeTables {
+		if !routeTable.routesTo(r.Destination(), gwId) {
+			continue
+		}
+		if resp := routeTable.deleteRoute(req, r.Destination()); resp != route.OK {
+			return resp
+		}
+	}
+	return route.OK
+}
+
+// Audit checks the static route is in place in each of the subnet group's route tables.
+func (r *staticRoute) Audit(flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil {
+		return fmt.Errorf("Audit Object does not exist")
+	}
+	gw, s := r.target()
+	if s == nil {
+		a.Audit(aaa.Configured, "StaticRoute %s for %s | %s does not exist", r.Destination(), r.subnetGroup, r.targetName())
+		return nil
+	}
+	if gw == inst {
+		enabled, err := r.sourceDestCheck(s.(resource.Instance))
+		if err != nil {
+			return err
+		}
+		if enabled {
+			a.Audit(aaa.Mismatched, "StaticRoute %s for %s | instance %s has its source/destination check enabled", r.Destination(), r.subnetGroup, r.Instance())
+		}
+	}
+	_, _, gwId := gatewayTarget(gw, s)
+	for _, routeTable := range r.routeTables {
+		switch {
+		case !routeTable.routeCreated(r.Destination()):
+			a.Audit(aaa.Configured, "StaticRoute %s in RouteTable %s", r.Destination(), routeTable.name())
+		case !routeTable.routesTo(r.Destination(), gwId):
+			a.Audit(aaa.Mismatched, "StaticRoute %s in RouteTable %s | does not route to %s, %s", r.Destination(), routeTable.name(), r.targetName(), gwId)
+		}
+	}
+	return nil
+}
+
+func (r *staticRoute) info() {
+	msg.Info("StaticRoute")
+	msg.Detail("%-20s\t%s", "subnet group", r.subnetGroup)
+	msg.Detail("%-20s\t%s", "destination", r.Destination())
+	msg.Detail("%-20s\t%s", "target", r.targetName())
+	msg.Detail("%-20s\t%t", "created", r.Created())
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/cisco/arc/pkg/config"
 	"github.com/cisco/arc/pkg/log"
 	"github.com/cisco/arc/pkg/msg"
+	"github.com/cisco/arc/pkg/net"
 	"github.com/cisco/arc/pkg/resource"
 	"github.com/cisco/arc/pkg/route"
 )
@@ -48,24 +49,28 @@ type subnet struct {
 
 	id_    string
 	subnet *ec2.Subnet
+	loaded bool
 }
 
-// newSubnet constructs the aws subnet.
-func newSubnet(net resource.Network, cfg *config.Subnet, c *ec2.EC2) (*subnet, error) {
+// newSubnet constructs the aws subnet. The subnet lives in the network of the
+// provider target its subnet group is pinned to.
+func newSubnet(net resource.Network, cfg *config.Subnet, p *dataCenterProvider) (*subnet, error) {
 	log.Debug("Initializing AWS Subnet %q", cfg.Name())
 
 	n, ok := net.ProviderNetwork().(*network)
 	if !ok {
 		return nil, fmt.Errorf("AWS newSubnet: Unable to obtain network")
 	}
+	n, err := n.forProvider(p)
+	if err != nil {
+		return nil, err
+	}
 
 	s := &subnet{
 		Subnet:  cfg,
-		ec2:     c,
+		ec2:     p.ec2,
 		network: n,
 	}
-	s.set(n.subnetCache.find(s))
-
 	return s, nil
 }
 
@@ -131,6 +136,38 @@ func (s *subnet) mapPublicIpOnLaunch() bool {
 	return *s.subnet.MapPublicIpOnLaunch
 }
 
+// ipv6CidrBlock returns the deployed ipv6 cidr block of a dual stack subnet.
+func (s *subnet) ipv6CidrBlock() string {
+	if s.subnet == nil {
+		return ""
+	}
+	for _, a := range s.subnet.Ipv6CidrBlockAssociationSet {
+		if a.Ipv6CidrBlockState != nil && aws.StringValue(a.Ipv6CidrBlockState.State) == "associated" {
+			return aws.StringValue(a.Ipv6CidrBlock)
+		}
+	}
+	return ""
+}
+
+// configuredIpv6CidrBlock returns the /64 identified by the subnet's ipv6 subnet id
+// within the vpc's ipv6 cidr block.
+func (s *subnet) configuredIpv6CidrBlock() (string, error) {
+	if s.Ipv6Subnet() == "" {
+		return "", nil
+	}
+	if s.network.vpc.ipv6CidrBlock() == "" {
+		return "", fmt.Errorf("Subnet %s is dual stack but vpc %s has no ipv6 cidr block", s.Name(), s.network.vpc.id())
+	}
+	return net.Ipv6SubnetBlock(s.network.vpc.ipv6CidrBlock(), s.Ipv6Subnet())
+}
+
+func (s *subnet) assignIpv6AddressOnCreation() bool {
+	if s.subnet == nil || s.subnet.AssignIpv6AddressOnCreation == nil {
+		return false
+	}
+	return *s.subnet.AssignIpv6AddressOnCreation
+}
+
 func (s *subnet) set(subnet *ec2.Subnet) {
 	if subnet == nil || subnet.SubnetId == nil {
 		return
@@ -151,6 +188,17 @@ func (s *subnet) Load() error {
 		log.Debug("Skipping subnet load, cached...")
 		return nil
 	}
+	// Use the subnet cache on the first load. Later loads need the current value from AWS.
+	if !s.loaded {
+		s.loaded = true
+		if err := s.network.loadCaches(); err != nil {
+			return err
+		}
+		s.set(s.network.subnetCache.find(s))
+		if s.subnet != nil {
+			return nil
+		}
+	}
 
 	params := &ec2.DescribeSubnetsInput{
 		Filters: []*ec2.Filter{
@@ -242,14 +290,22 @@ func (s *subnet) create(req *route.Request) route.Response {
 	msg.Info("Subnet Creation: %s %s", s.Name(), s.CidrBlock())
 	if s.Created() {
 		msg.Detail("Subnet exists, skipping...")
-		return route.OK
+		return s.associateIpv6CidrBlock()
 	}
 
+	ipv6CidrBlock, err := s.configuredIpv6CidrBlock()
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
 	params := &ec2.CreateSubnetInput{
 		CidrBlock:        aws.String(s.CidrBlock()),
 		VpcId:            aws.String(s.network.vpc.id()),
 		AvailabilityZone: aws.String(s.AvailabilityZone()),
 	}
+	if ipv6CidrBlock != "" {
+		params.Ipv6CidrBlock = aws.String(ipv6CidrBlock)
+	}
 
 	resp, err := s.ec2.CreateSubnet(params)
 	if err != nil {
@@ -294,6 +350,48 @@ func (s *subnet) create(req *route.Request) route.Response {
 			return route.FAIL
 		}
 	}
+	return s.associateIpv6CidrBlock()
+}
+
+// associateIpv6CidrBlock associates the configured ipv6 cidr block with the subnet,
+// when the subnet predates ipv6 being enabled, and has instances launched in the
+// subnet assigned an ipv6 address.
+func (s *subnet) associateIpv6CidrBlock() route.Response {
+	ipv6CidrBlock, err := s.configuredIpv6CidrBlock()
+	if err != nil {
+		msg.Error(err.Error())
+		return route.FAIL
+	}
+	if ipv6CidrBlock == "" {
+		return route.OK
+	}
+	if s.ipv6CidrBlock() == "" {
+		params := &ec2.AssociateSubnetCidrBlockInput{
+			Ipv6CidrBlock: aws.String(ipv6CidrBlock),
+			SubnetId:      aws.String(s.Id()),
+		}
+		if _, err := s.ec2.AssociateSubnetCidrBlock(params); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+		msg.Detail("Associated ipv6 cidr block %s", ipv6CidrBlock)
+		aaa.Accounting("Subnet %s ipv6 cidr block associated: %s", s.Id(), ipv6CidrBlock)
+	}
+	if !s.assignIpv6AddressOnCreation() {
+		params := &ec2.ModifySubnetAttributeInput{
+			SubnetId: aws.String(s.Id()),
+			AssignIpv6AddressOnCreation: &ec2.AttributeBooleanValue{
+				Value: aws.Bool(true),
+			},
+		}
+		if _, err := s.ec2.ModifySubnetAttribute(params); err != nil {
+			msg.Error(err.Error())
+			return route.FAIL
+		}
+	}
+	if !s.reload() {
+		return route.FAIL
+	}
 	return route.OK
 }
 
@@ -340,6 +438,9 @@ func (s *subnet) info() {
 	msg.Info("Subnet")
 	msg.Detail("%-20s\t%s", "name", s.Name())
 	msg.Detail("%-20s\t%s", "cidr", s.CidrBlock())
+	if s.ipv6CidrBlock() != "" {
+		msg.Detail("%-20s\t%s", "ipv6 cidr", s.ipv6CidrBlock())
+	}
 	msg.Detail("%-20s\t%s", "access", s.Access())
 	msg.Detail("%-20s\t%s", "availability zone", s.AvailabilityZone())
 	msg.Detail("%-20s\t%s", "id", s.Id())
@@ -363,6 +464,9 @@ func (s *subnet) Audit(flags ...string) error {
 	if s.CidrBlock() != *s.subnet.CidrBlock {
 		a.Audit(aaa.Mismatched, "%s: cidr block mismatch - configured: %s, deployed: %s", s.Name(), s.CidrBlock(), *s.subnet.CidrBlock)
 	}
+	if ipv6CidrBlock, err := s.configuredIpv6CidrBlock(); err == nil && ipv6CidrBlock != s.ipv6CidrBlock() {
+		a.Audit(aaa.Mismatched, "%s: ipv6 cidr block mismatch - configured: %s, deployed: %s", s.Name(), ipv6CidrBlock, s.ipv6CidrBlock())
+	}
 	if s.AvailabilityZone() != *s.subnet.AvailabilityZone {
 		a.Audit(aaa.Mismatched, "%s: az mismatch - configured: %s, deployed: %s", s.Name(), s.AvailabilityZone(), *s.subnet.AvailabilityZone)
 	}
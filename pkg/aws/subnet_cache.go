@@ -43,16 +43,22 @@ type subnetCacheEntry struct {
 }
 
 type subnetCache struct {
+	network *network
 	cache   map[string]*subnetCacheEntry
 	unnamed []*ec2.Subnet
 }
 
-func newSubnetCache(n *network) (*subnetCache, error) {
+func newSubnetCache(n *network) *subnetCache {
 	log.Debug("Initializing AWS Subnet Cache")
-
-	c := &subnetCache{
-		cache: map[string]*subnetCacheEntry{},
+	return &subnetCache{
+		network: n,
+		cache:   map[string]*subnetCacheEntry{},
 	}
+}
+
+func (c *subnetCache) load() error {
+	log.Debug("Loading AWS Subnet Cache")
+	n := c.network
 
 	params := &ec2.DescribeSubnetsInput{
 		Filters: []*ec2.Filter{
@@ -66,7 +72,7 @@ func newSubnetCache(n *network) (*subnetCache, error) {
 	}
 	resp, err := n.ec2.DescribeSubnets(params)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	log.Debug("Load Subnets: %d", len(resp.Subnets))
 
@@ -102,7 +108,7 @@ func newSubnetCache(n *network) (*subnetCache, error) {
 		log.Debug("Caching %s", name)
 		c.cache[name] = &subnetCacheEntry{deployed: s}
 	}
-	return c, nil
+	return nil
 }
 
 func (c *subnetCache) find(s *subnet) *ec2.Subnet {
@@ -0,0 +1,310 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cisco/arc/pkg/aaa"
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/log"
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// The number of resource ids given to a single describe call.
+const describeBatch = 200
+
+// batchEnd returns the end of the batch of ids beginning at start.
+func batchEnd(start, n int) int {
+	if start+describeBatch < n {
+		return start + describeBatch
+	}
+	return n
+}
+
+// taggedResource is a resource of the datacenter that carries tags. The owner
+// is the id of the instance a volume or elastic ip belongs to.
+type taggedResource struct {
+	id    string
+	kind  string
+	owner string
+	tags  map[string]string
+}
+
+// name returns the name tag of the resource, or its id when it isn't named.
+func (r *taggedResource) name() string {
+	if n := r.tags["Name"]; n != "" {
+		return n + ", " + r.id
+	}
+	return r.id
+}
+
+// newTaggedResource returns the tagged resource of the given id and kind.
+func newTaggedResource(id, kind string, tags []*ec2.Tag) *taggedResource {
+	r := &taggedResource{id: id, kind: kind, tags: map[string]string{}}
+	for _, t := range tags {
+		r.tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+	return r
+}
+
+// sortTaggedResources orders the resources by kind and id, with instances
+// first, so they can be fixed before the resources that inherit their tags.
+func sortTaggedResources(byId map[string]*taggedResource) []*taggedResource {
+	resources := []*taggedResource{}
+	for _, r := range byId {
+		resources = append(resources, r)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if (a.kind == ec2.ResourceTypeInstance) != (b.kind == ec2.ResourceTypeInstance) {
+			return a.kind == ec2.ResourceTypeInstance
+		}
+		if a.kind != b.kind {
+			return a.kind < b.kind
+		}
+		return a.id < b.id
+	})
+	return resources
+}
+
+// addTaggedInstance adds the instance, unless it is being terminated.
+func addTaggedInstance(byId map[string]*taggedResource, inst *ec2.Instance) {
+	if inst == nil || inst.InstanceId == nil {
+		return
+	}
+	switch instanceState(inst) {
+	case "shutting-down", "terminated":
+		return
+	}
+	id := aws.StringValue(inst.InstanceId)
+	byId[id] = newTaggedResource(id, ec2.ResourceTypeInstance, inst.Tags)
+}
+
+// addTaggedVolume adds the volume, owned by the instance it is attached to.
+func addTaggedVolume(byId map[string]*taggedResource, v *ec2.Volume) {
+	if v == nil || v.VolumeId == nil {
+		return
+	}
+	id := aws.StringValue(v.VolumeId)
+	r := newTaggedResource(id, ec2.ResourceTypeVolume, v.Tags)
+	if len(v.Attachments) > 0 {
+		r.owner = aws.StringValue(v.Attachments[0].InstanceId)
+	}
+	byId[id] = r
+}
+
+// addTaggedAddresses adds the elastic ips associated with the instances.
+func addTaggedAddresses(c *ec2.EC2, byId map[string]*taggedResource, instances []*string) error {
+	for start := 0; start < len(instances); start += describeBatch {
+		resp, err := c.DescribeAddresses(&ec2.DescribeAddressesInput{
+			Filters: []*ec2.Filter{{Name: aws.String("instance-id"), Values: instances[start:batchEnd(start, len(instances))]}},
+		})
+		if err != nil {
+			return err
+		}
+		for _, a := range resp.Addresses {
+			r := newTaggedResource(aws.StringValue(a.AllocationId), "elastic-ip", a.Tags)
+			r.owner = aws.StringValue(a.InstanceId)
+			byId[r.id] = r
+		}
+	}
+	return nil
+}
+
+// taggedResources returns the instances and volumes of the datacenter, taken
+// from the compute's caches and its configured instances, along with the
+// elastic ips of the instances. Since the resources are found through arc
+// rather than by their DataCenter tag, resources missing the tag are included.
+func (c *compute) taggedResources() ([]*taggedResource, map[string]*taggedResource, error) {
+	byId := map[string]*taggedResource{}
+	for _, e := range c.instanceCache.cache {
+		addTaggedInstance(byId, e.deployed)
+	}
+	for _, e := range c.volumeCache.cache {
+		addTaggedVolume(byId, e.deployed)
+	}
+	for _, i := range c.instances {
+		addTaggedInstance(byId, i.instance)
+		for _, v := range i.volumes {
+			addTaggedVolume(byId, v.volume)
+		}
+	}
+	instances := []*string{}
+	for _, r := range byId {
+		if r.kind == ec2.ResourceTypeInstance {
+			instances = append(instances, aws.String(r.id))
+		}
+	}
+	if err := addTaggedAddresses(c.ec2, byId, instances); err != nil {
+		return nil, nil, err
+	}
+	resources := sortTaggedResources(byId)
+	log.Debug("Found %d tagged resources in %s", len(resources), c.Name())
+	return resources, byId, nil
+}
+
+// taggedResources returns the vpc of the network and the subnets and security
+// groups in its subnet and security group caches.
+func (n *network) taggedResources() ([]*taggedResource, map[string]*taggedResource, error) {
+	if err := n.loadCaches(); err != nil {
+		return nil, nil, err
+	}
+	byId := map[string]*taggedResource{}
+	if v := n.vpc.vpc; v != nil {
+		byId[n.vpc.id()] = newTaggedResource(n.vpc.id(), ec2.ResourceTypeVpc, v.Tags)
+	}
+	for _, e := range n.subnetCache.cache {
+		id := aws.StringValue(e.deployed.SubnetId)
+		byId[id] = newTaggedResource(id, ec2.ResourceTypeSubnet, e.deployed.Tags)
+	}
+	for _, e := range n.secgroupCache.cache {
+		id := aws.StringValue(e.deployed.GroupId)
+		byId[id] = newTaggedResource(id, ec2.ResourceTypeSecurityGroup, e.deployed.Tags)
+	}
+	resources := sortTaggedResources(byId)
+	log.Debug("Found %d tagged resources in %s", len(resources), n.key)
+	return resources, byId, nil
+}
+
+// auditTags reports the resources whose tags violate the tag policy.
+func auditTags(policy *config.TagPolicy, resources []*taggedResource, flags ...string) error {
+	if len(flags) == 0 || flags[0] == "" {
+		return fmt.Errorf("No flag set to find audit object")
+	}
+	a := aaa.AuditBuffer[flags[0]]
+	if a == nil {
+		return fmt.Errorf("Audit Object does not exist")
+	}
+	for _, r := range resources {
+		for _, p := range policy.Check(r.kind, r.tags) {
+			a.Audit(aaa.Mismatched, "%s %s | %s", r.kind, r.name(), p)
+		}
+	}
+	return nil
+}
+
+// fixTags sets the missing or invalid tags of the resources. Resources inherit
+// tags from their owner, such as the instance a volume is attached to.
+func fixTags(c *ec2.EC2, policy *config.TagPolicy, defaults map[string]string, resources []*taggedResource, byId map[string]*taggedResource) error {
+	for _, r := range resources {
+		inherited := map[string]string{}
+		if owner := byId[r.owner]; owner != nil {
+			inherited = owner.tags
+		}
+		fixed, problems := policy.Fix(r.kind, r.tags, inherited, defaults)
+		if len(fixed) > 0 {
+			msg.Detail("Set tags: %s %s", r.kind, r.name())
+			if err := setTags(c, fixed, r.id); err != nil {
+				return err
+			}
+			for k, v := range fixed {
+				r.tags[k] = v
+			}
+		}
+		for _, p := range problems {
+			msg.Warn("Unable to fix %s %s, %s", r.kind, r.name(), p)
+		}
+	}
+	return nil
+}
+
+// AuditTags satisfies the resource.DynamicCompute interface.
+func (c *compute) AuditTags(policy *config.TagPolicy, flags ...string) error {
+	for _, t := range c.all() {
+		resources, _, err := t.taggedResources()
+		if err != nil {
+			return err
+		}
+		if err := auditTags(policy, resources, flags...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FixTags satisfies the resource.DynamicCompute interface.
+func (c *compute) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	for _, t := range c.all() {
+		resources, byId, err := t.taggedResources()
+		if err != nil {
+			return err
+		}
+		if err := fixTags(t.ec2, policy, defaults, resources, byId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AuditTags satisfies the resource.DynamicNetwork interface.
+func (n *network) AuditTags(policy *config.TagPolicy, flags ...string) error {
+	for _, t := range n.all() {
+		resources, _, err := t.taggedResources()
+		if err != nil {
+			return err
+		}
+		if err := auditTags(policy, resources, flags...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FixTags satisfies the resource.DynamicNetwork interface.
+func (n *network) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	for _, t := range n.all() {
+		resources, byId, err := t.taggedResources()
+		if err != nil {
+			return err
+		}
+		if err := fixTags(t.ec2, policy, defaults, resources, byId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FixTags satisfies the resource.DynamicInstance interface.
+func (i *instance) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	byId := map[string]*taggedResource{}
+	addTaggedInstance(byId, i.instance)
+	if len(byId) == 0 {
+		return nil
+	}
+	for _, v := range i.volumes {
+		addTaggedVolume(byId, v.volume)
+	}
+	if err := addTaggedAddresses(i.ec2, byId, []*string{i.instance.InstanceId}); err != nil {
+		return err
+	}
+	return fixTags(i.ec2, policy, defaults, sortTaggedResources(byId), byId)
+}
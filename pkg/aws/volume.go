@@ -62,6 +62,7 @@ func newVolume(comp resource.Compute, cfg *config.Volume, p *dataCenterProvider)
 	if !ok {
 		return nil, fmt.Errorf("AWS newVolume: Unable to obtain compute")
 	}
+	c = c.forProvider(p)
 
 	// Build the parameters that will be used by the instance
 	params := &ec2.BlockDeviceMapping{
@@ -85,6 +86,21 @@ func newVolume(comp resource.Compute, cfg *config.Volume, p *dataCenterProvider)
 	return v, nil
 }
 
+// encrypt resolves the volume's encryption key and sets it in the block device
+// mapping used to create the volume.
+func (v *volume) encrypt() error {
+	if v.EncryptionKey() == "" || v.volumeParams.Ebs.KmsKeyId != nil {
+		return nil
+	}
+	arn, err := v.compute.keyArn(v.EncryptionKey())
+	if err != nil {
+		return err
+	}
+	v.volumeParams.Ebs.Encrypted = aws.Bool(true)
+	v.volumeParams.Ebs.KmsKeyId = aws.String(arn)
+	return nil
+}
+
 func (v *volume) Route(req *route.Request) route.Response {
 	return route.FAIL
 }
@@ -105,8 +121,15 @@ func (v *volume) Audit(flags ...string) error {
 	}
 	// Mismatches
 	// Encrypted Volumes?
-	if v.volume.Encrypted != nil && !*v.volume.Encrypted && !v.Boot() {
+	if v.volume.Encrypted != nil && !*v.volume.Encrypted && (!v.Boot() || v.EncryptionKey() != "") {
 		a.Audit(aaa.Mismatched, "Instance %q | Deployed Volume %q is not encrypted", v.instance.Name(), *v.volume.VolumeId)
+	} else if v.EncryptionKey() != "" {
+		arn, err := v.compute.keyArn(v.EncryptionKey())
+		if err != nil {
+			a.Audit(aaa.Mismatched, "Instance %q | Volume %q, %s", v.instance.Name(), *v.volume.VolumeId, err.Error())
+		} else if aws.StringValue(v.volume.KmsKeyId) != arn {
+			a.Audit(aaa.Mismatched, "Instance %q | Deployed Volume %q is encrypted with %s, configured encryption key %s", v.instance.Name(), *v.volume.VolumeId, aws.StringValue(v.volume.KmsKeyId), v.EncryptionKey())
+		}
 	}
 	// Correct Size
 	if v.volume.Size != nil && *v.volume.Size != v.Size() {
@@ -311,7 +334,131 @@ func (v *volume) Info() {
 	msg.Detail("%-20s\t%s", "State", v.State())
 	msg.Detail("%-20s\t%s", "Instance Id", v.instance.Id())
 	msg.Detail("%-20s\t%t", "Encrypted", *v.volume.Encrypted)
+	if v.volume.KmsKeyId != nil {
+		msg.Detail("%-20s\t%s", "Encryption Key", *v.volume.KmsKeyId)
+	}
 	msg.Detail("%-20s\t%d", "Size", *v.volume.Size)
 	msg.Detail("%-20s\t%s", "Type", *v.volume.VolumeType)
 	printTags(v.volume.Tags)
 }
+
+// Snapshot creates a snapshot of the volume with the given tags.
+func (v *volume) Snapshot(t map[string]string) (string, error) {
+	if v.Destroyed() {
+		return "", fmt.Errorf("Volume %s isn't deployed", v.Device())
+	}
+	params := &ec2.CreateSnapshotInput{
+		VolumeId:    aws.String(v.Id()),
+		Description: aws.String(t["Name"]),
+	}
+	resp, err := v.ec2.CreateSnapshot(params)
+	if err != nil {
+		return "", err
+	}
+	id := aws.StringValue(resp.SnapshotId)
+	if err := setTags(v.ec2, t, id); err != nil {
+		return id, err
+	}
+	aaa.Accounting("Snapshot created: %s, %s, %s", v.Device(), v.Id(), id)
+	return id, nil
+}
+
+// Snapshots returns the snapshots having all of the given tags.
+func (v *volume) Snapshots(t map[string]string) ([]*resource.Snapshot, error) {
+	params := &ec2.DescribeSnapshotsInput{
+		OwnerIds: []*string{aws.String("self")},
+	}
+	for k, val := range t {
+		params.Filters = append(params.Filters, &ec2.Filter{
+			Name:   aws.String("tag:" + k),
+			Values: []*string{aws.String(val)},
+		})
+	}
+	snapshots := []*resource.Snapshot{}
+	err := v.ec2.DescribeSnapshotsPages(params, func(resp *ec2.DescribeSnapshotsOutput, lastPage bool) bool {
+		for _, s := range resp.Snapshots {
+			snapshot := &resource.Snapshot{
+				Id:    aws.StringValue(s.SnapshotId),
+				State: aws.StringValue(s.State),
+				Time:  aws.TimeValue(s.StartTime),
+				Tags:  map[string]string{},
+			}
+			for _, tag := range s.Tags {
+				snapshot.Tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+		return true
+	})
+	return snapshots, err
+}
+
+// DeleteSnapshot deletes the snapshot with the given id.
+func (v *volume) DeleteSnapshot(id string) error {
+	params := &ec2.DeleteSnapshotInput{
+		SnapshotId: aws.String(id),
+	}
+	if _, err := v.ec2.DeleteSnapshot(params); err != nil {
+		return err
+	}
+	aaa.Accounting("Snapshot deleted: %s, %s", v.Device(), id)
+	return nil
+}
+
+// Restore replaces the deployed volume with a new volume created from the
+// snapshot, in the same availability zone and with the same type and tags.
+// The instance should be stopped so the volume can be detached.
+func (v *volume) Restore(id string) (string, error) {
+	if v.Destroyed() {
+		return "", fmt.Errorf("Volume %s isn't deployed", v.Device())
+	}
+	old := v.volume
+	params := &ec2.CreateVolumeInput{
+		AvailabilityZone: old.AvailabilityZone,
+		SnapshotId:       aws.String(id),
+		VolumeType:       old.VolumeType,
+	}
+	if v.EncryptionKey() != "" {
+		arn, err := v.compute.keyArn(v.EncryptionKey())
+		if err != nil {
+			return "", err
+		}
+		params.Encrypted = aws.Bool(true)
+		params.KmsKeyId = aws.String(arn)
+	}
+	if aws.StringValue(old.VolumeType) == "io1" {
+		params.Iops = old.Iops
+	}
+	resp, err := v.ec2.CreateVolume(params)
+	if err != nil {
+		return "", err
+	}
+	if err := v.Detach(); err != nil {
+		return "", err
+	}
+	v.compute.volumeCache.remove(v)
+	v.id = aws.StringValue(resp.VolumeId)
+	v.set(resp)
+	if !v.reload(v.Detached, "become available") {
+		return "", fmt.Errorf("Volume %q, restored from %s, isn't available", v.Id(), id)
+	}
+	if err := v.Attach(); err != nil {
+		return "", err
+	}
+	if len(old.Tags) > 0 {
+		t := map[string]string{}
+		for _, tag := range old.Tags {
+			t[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		if err := v.SetTags(t); err != nil {
+			msg.Warn("Failed to set tags for %s\n\t%s", v.Device(), err.Error())
+		}
+	}
+	aaa.Accounting("Volume restored: %s, %s from %s, replacing %s", v.Device(), v.Id(), id, aws.StringValue(old.VolumeId))
+	return aws.StringValue(old.VolumeId), nil
+}
+
+// UseSnapshot sets the snapshot the volume is created from when the instance is created.
+func (v *volume) UseSnapshot(id string) {
+	v.volumeParams.Ebs.SnapshotId = aws.String(id)
+}
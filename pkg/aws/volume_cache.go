@@ -42,14 +42,35 @@ type volumeCacheEntry struct {
 }
 
 type volumeCache struct {
-	cache map[string]*volumeCacheEntry
+	compute *compute
+	loaded  bool
+	cache   map[string]*volumeCacheEntry
 }
 
-func newVolumeCache(c *compute) (*volumeCache, error) {
+func newVolumeCache(c *compute) *volumeCache {
 	log.Debug("Initializing AWS Volume Cache")
+	return &volumeCache{
+		compute: c,
+		cache:   map[string]*volumeCacheEntry{},
+	}
+}
+
+// load populates the volume cache with the volumes in the datacenter. When
+// readOnly is set a recent copy of the cache saved to disk may be used.
+func (c *volumeCache) load(readOnly bool) error {
+	if c.loaded {
+		log.Debug("Skipping volume cache load, loaded...")
+		return nil
+	}
+	log.Debug("Loading AWS Volume Cache")
 
-	v := &volumeCache{
-		cache: map[string]*volumeCacheEntry{},
+	diskCache := c.compute.cacheName("volumes")
+	if !readOnly {
+		removeDiskCache(diskCache)
+	}
+	if readOnly && c.readDiskCache(diskCache) {
+		c.loaded = true
+		return nil
 	}
 
 	var token *string
@@ -60,9 +81,9 @@ func newVolumeCache(c *compute) (*volumeCache, error) {
 			MaxResults: aws.Int64(500),
 			NextToken:  token,
 		}
-		resp, err := c.ec2.DescribeVolumes(params)
+		resp, err := c.compute.ec2.DescribeVolumes(params)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		log.Debug("Load Volumes: %d", len(resp.Volumes))
 
@@ -88,13 +109,13 @@ func newVolumeCache(c *compute) (*volumeCache, error) {
 				}
 			}
 
-			if dc == "" || dc != c.Compute.Name() {
+			if dc == "" || dc != c.compute.Name() {
 				continue
 			}
 
 			id := *volume.VolumeId
 			log.Debug("Caching volume %s", id)
-			v.cache[id] = &volumeCacheEntry{deployed: volume}
+			c.cache[id] = &volumeCacheEntry{deployed: volume}
 		}
 
 		if resp.NextToken != nil {
@@ -103,8 +124,34 @@ func newVolumeCache(c *compute) (*volumeCache, error) {
 			done = true
 		}
 	}
+	c.loaded = true
 
-	return v, nil
+	if readOnly {
+		c.writeDiskCache(diskCache)
+	}
+	return nil
+}
+
+func (c *volumeCache) readDiskCache(name string) bool {
+	volumes := []*ec2.Volume{}
+	if !readDiskCache(name, &volumes) {
+		return false
+	}
+	for _, v := range volumes {
+		if v == nil || v.VolumeId == nil {
+			continue
+		}
+		c.cache[*v.VolumeId] = &volumeCacheEntry{deployed: v}
+	}
+	return true
+}
+
+func (c *volumeCache) writeDiskCache(name string) {
+	volumes := []*ec2.Volume{}
+	for _, v := range c.cache {
+		volumes = append(volumes, v.deployed)
+	}
+	writeDiskCache(name, volumes)
 }
 
 func (c *volumeCache) find(v *volume) *ec2.Volume {
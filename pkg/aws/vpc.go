@@ -120,6 +120,19 @@ func (v *vpc) cidrBlock() string {
 	return *v.vpc.CidrBlock
 }
 
+// ipv6CidrBlock returns the amazon provided ipv6 cidr block once it has been associated.
+func (v *vpc) ipv6CidrBlock() string {
+	if v.vpc == nil {
+		return ""
+	}
+	for _, a := range v.vpc.Ipv6CidrBlockAssociationSet {
+		if a.Ipv6CidrBlockState != nil && aws.StringValue(a.Ipv6CidrBlockState.State) == "associated" {
+			return aws.StringValue(a.Ipv6CidrBlock)
+		}
+	}
+	return ""
+}
+
 func (v *vpc) set(vpc *ec2.Vpc) {
 	if vpc == nil || vpc.VpcId == nil {
 		return
@@ -201,12 +214,15 @@ func (v *vpc) create(req *route.Request) route.Response {
 	msg.Info("Vpc Creation: %s", v.name())
 	if v.Created() {
 		msg.Detail("Vpc exists, skipping...")
-		return route.OK
+		return v.associateIpv6CidrBlock()
 	}
 
 	params := &ec2.CreateVpcInput{
 		CidrBlock: aws.String(v.network.CidrBlock()),
 	}
+	if v.network.Ipv6() {
+		params.AmazonProvidedIpv6CidrBlock = aws.Bool(true)
+	}
 	resp, err := v.ec2.CreateVpc(params)
 	if err != nil {
 		msg.Error(err.Error())
@@ -225,6 +241,52 @@ func (v *vpc) create(req *route.Request) route.Response {
 
 	msg.Detail("Created %s", v.id())
 	aaa.Accounting("Vpc created: %s", v.id())
+	return v.associateIpv6CidrBlock()
+}
+
+// associateIpv6CidrBlock requests an amazon provided ipv6 cidr block for a dual
+// stack network, either when the vpc is created or when ipv6 is enabled for an
+// existing vpc.
+func (v *vpc) associateIpv6CidrBlock() route.Response {
+	if !v.network.Ipv6() {
+		return route.OK
+	}
+	if v.ipv6CidrBlock() == "" {
+		pending := false
+		for _, a := range v.vpc.Ipv6CidrBlockAssociationSet {
+			if a.Ipv6CidrBlockState != nil && aws.StringValue(a.Ipv6CidrBlockState.State) == "associating" {
+				pending = true
+			}
+		}
+		if !pending {
+			params := &ec2.AssociateVpcCidrBlockInput{
+				AmazonProvidedIpv6CidrBlock: aws.Bool(true),
+				VpcId:                       aws.String(v.id()),
+			}
+			if _, err := v.ec2.AssociateVpcCidrBlock(params); err != nil {
+				msg.Error(err.Error())
+				return route.FAIL
+			}
+		}
+		ok := msg.Wait(
+			fmt.Sprintf("Waiting for Vpc %s ipv6 cidr block to be associated", v.id()), // title
+			fmt.Sprintf("Vpc %s ipv6 cidr block was never associated", v.id()),         // err
+			300, // duration
+			func() bool { return v.ipv6CidrBlock() != "" }, // test()
+			func() bool { // load()
+				if err := v.Load(); err != nil {
+					msg.Error(err.Error())
+					return false
+				}
+				return true
+			},
+		)
+		if !ok {
+			return route.FAIL
+		}
+		msg.Detail("Associated ipv6 cidr block %s", v.ipv6CidrBlock())
+		aaa.Accounting("Vpc %s ipv6 cidr block associated: %s", v.id(), v.ipv6CidrBlock())
+	}
 	return route.OK
 }
 
@@ -270,5 +332,8 @@ func (v *vpc) info() {
 	msg.Detail("%-20s\t%s", "id", v.id())
 	msg.Detail("%-20s\t%s", "state", v.state())
 	msg.Detail("%-20s\t%s", "cidr", v.cidrBlock())
+	if v.ipv6CidrBlock() != "" {
+		msg.Detail("%-20s\t%s", "ipv6 cidr", v.ipv6CidrBlock())
+	}
 	printTags(v.vpc.Tags)
 }
@@ -0,0 +1,125 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/cisco/arc/pkg/env"
+)
+
+const scriptEOF = "ARC_SCRIPT_EOF"
+
+// Script renders a set of commands as a shell script that performs the same
+// work on the instance itself, such as when passed to cloud-init as user-data.
+// Copy and remote commands embed the source file in the script, sudo and
+// remote commands are run as root with SUDO_USER set to the given user,
+// and messages are echoed to the console log. Local commands aren't supported.
+// The progress of the script is recorded in the given status file as
+// "running", "done" or "failed".
+func Script(c []Command, user, status string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "#!/bin/bash\n")
+	fmt.Fprintf(&b, "mkdir -p %s\n", quote(filepath.Dir(status)))
+	fmt.Fprintf(&b, "echo running > %s\n", quote(status))
+	fmt.Fprintf(&b, "trap 'echo failed > %s' ERR\n", status)
+	fmt.Fprintf(&b, "set -eE\n")
+	fmt.Fprintf(&b, "export SUDO_USER=%s\n", quote(user))
+
+	for _, command := range c {
+		switch command.Type {
+		case Remote:
+			if err := command.embed(&b); err != nil {
+				return nil, err
+			}
+			d := command
+			if d.Dest != "" {
+				d.Src = d.Dest
+			}
+			d.run(&b)
+		case Copy:
+			if err := command.embed(&b); err != nil {
+				return nil, err
+			}
+		case Sudo:
+			command.run(&b)
+		case Message:
+			fmt.Fprintf(&b, "echo %s\n", quote(command.Desc))
+		default:
+			return nil, fmt.Errorf("Command %q can't be run from a script", command.Desc)
+		}
+	}
+	fmt.Fprintf(&b, "echo done > %s\n", quote(status))
+	return b.Bytes(), nil
+}
+
+func (c Command) embed(b *bytes.Buffer) error {
+	src := ""
+	if c.arcSrc() {
+		src += env.Lookup("ROOT")
+	}
+	src += c.Src
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if bytes.Contains(data, []byte(scriptEOF)) {
+		return fmt.Errorf("Unable to embed %s in script", c.Src)
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	dest := c.Dest
+	if dest == "" {
+		dest = c.Src
+	}
+	fmt.Fprintf(b, "echo %s\n", quote("Installing "+c.Desc))
+	fmt.Fprintf(b, "mkdir -p %s\n", quote(filepath.Dir(dest)))
+	fmt.Fprintf(b, "cat > %s <<'%s'\n", quote(dest), scriptEOF)
+	b.Write(data)
+	fmt.Fprintf(b, "%s\n", scriptEOF)
+	fmt.Fprintf(b, "chmod 755 %s\n", quote(dest))
+	return nil
+}
+
+func (c Command) run(b *bytes.Buffer) {
+	fmt.Fprintf(b, "echo %s\n", quote("Running "+c.Desc))
+	args := []string{c.Src}
+	for _, a := range c.Args {
+		args = append(args, quote(a))
+	}
+	fmt.Fprintf(b, "%s\n", strings.Join(args, " "))
+}
+
+// quote quotes s for use as a single shell word.
+func quote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
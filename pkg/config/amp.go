@@ -41,6 +41,7 @@ type Amp struct {
 	Notifications      *Notifications      `json:"notifications"`
 	Provider           *Provider           `json:"provider"`
 	SecurityTags_      SecurityTags        `json:"security_tags"`
+	TagPolicy_         *TagPolicy          `json:"tag_policy"`
 	IdentityManagement *IdentityManagement `json:"identity_management"`
 	Storage            *Storage            `json:"storage"`
 	KeyManagement      *KeyManagement      `json:"key_management"`
@@ -68,6 +69,12 @@ func (a *Amp) SecurityTags() SecurityTags {
 	return a.SecurityTags_
 }
 
+// TagPolicy returns the rules the tags of the buckets must follow. It is nil
+// when amp doesn't have a tag policy.
+func (a *Amp) TagPolicy() *TagPolicy {
+	return a.TagPolicy_
+}
+
 func (a *Amp) PrintLocal() {
 	msg.Detail("%-20s\t%s", "name", a.Name())
 	if a.Provider != nil {
@@ -76,6 +83,9 @@ func (a *Amp) PrintLocal() {
 	if a.SecurityTags_ != nil {
 		a.SecurityTags_.Print()
 	}
+	if a.TagPolicy_ != nil {
+		a.TagPolicy_.Print()
+	}
 }
 
 func (a *Amp) Print() {
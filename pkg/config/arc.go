@@ -71,6 +71,20 @@ func (a *Arc) Title() string {
 	return a.Title_
 }
 
+// Target returns the configuration of the named provider target. Targets are
+// declared by the datacenter provider, or by the arc provider when the
+// datacenter doesn't have one.
+func (a *Arc) Target(name string) (*Provider, error) {
+	p := a.Provider
+	if a.DataCenter != nil && a.DataCenter.Provider != nil {
+		p = a.DataCenter.Provider
+	}
+	if p == nil {
+		return nil, fmt.Errorf("No provider available for target %q", name)
+	}
+	return p.Target(name)
+}
+
 // PrintLocal provides a user friendly way to view the configuration local to the arc object.
 // This is a shallow print.
 func (a *Arc) PrintLocal() {
@@ -0,0 +1,144 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// The configuration of the autoscaling object. When present the instances of
+// a pod are managed by the provider's autoscaling group. It has the minimum,
+// maximum and desired number of instances, and a list of scaling policies.
+type AutoScaling struct {
+	Min_      int              `json:"min"`
+	Max_      int              `json:"max"`
+	Desired_  int              `json:"desired"`
+	Policies_ []*ScalingPolicy `json:"policies"`
+}
+
+// Min is the minimum number of instances in the group.
+func (a *AutoScaling) Min() int {
+	return a.Min_
+}
+
+// Max is the maximum number of instances in the group.
+func (a *AutoScaling) Max() int {
+	return a.Max_
+}
+
+// Desired is the number of instances the group starts with.
+func (a *AutoScaling) Desired() int {
+	return a.Desired_
+}
+
+// Policies are the scaling policies that adjust the number of instances.
+func (a *AutoScaling) Policies() []*ScalingPolicy {
+	return a.Policies_
+}
+
+// Validate checks the autoscaling counts and policies are consistent.
+func (a *AutoScaling) Validate() error {
+	if a.Max() < 1 {
+		return fmt.Errorf("The autoscaling max must be at least 1")
+	}
+	if a.Min() < 0 || a.Min() > a.Desired() || a.Desired() > a.Max() {
+		return fmt.Errorf("The autoscaling counts must satisfy 0 <= min <= desired <= max")
+	}
+	for _, p := range a.Policies() {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the autoscaling configuration.
+func (a *AutoScaling) Print() {
+	msg.Info("AutoScaling Config")
+	msg.Detail("%-20s\t%d", "min", a.Min())
+	msg.Detail("%-20s\t%d", "max", a.Max())
+	msg.Detail("%-20s\t%d", "desired", a.Desired())
+	msg.IndentInc()
+	for _, p := range a.Policies() {
+		p.Print()
+	}
+	msg.IndentDec()
+}
+
+// The configuration of the scaling policy object. The policy keeps the
+// average of the metric across the group's instances near the target value.
+type ScalingPolicy struct {
+	Name_   string  `json:"policy"`
+	Metric_ string  `json:"metric"`
+	Target_ float64 `json:"target"`
+}
+
+// scalingMetrics are the metrics a scaling policy can track.
+var scalingMetrics = map[string]bool{
+	"cpu":         true,
+	"network_in":  true,
+	"network_out": true,
+}
+
+// Name is the name of the scaling policy.
+func (p *ScalingPolicy) Name() string {
+	return p.Name_
+}
+
+// Metric is the metric being tracked, one of cpu, network_in or network_out.
+func (p *ScalingPolicy) Metric() string {
+	return p.Metric_
+}
+
+// Target is the value of the metric the policy tracks.
+func (p *ScalingPolicy) Target() float64 {
+	return p.Target_
+}
+
+// Validate checks the scaling policy is complete.
+func (p *ScalingPolicy) Validate() error {
+	if p.Name() == "" {
+		return fmt.Errorf("A scaling policy requires a name")
+	}
+	if !scalingMetrics[p.Metric()] {
+		return fmt.Errorf("Scaling policy %q has an unknown metric %q", p.Name(), p.Metric())
+	}
+	if p.Target() <= 0 {
+		return fmt.Errorf("Scaling policy %q requires a positive target", p.Name())
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the scaling policy configuration.
+func (p *ScalingPolicy) Print() {
+	msg.Info("Scaling Policy Config")
+	msg.Detail("%-20s\t%s", "name", p.Name())
+	msg.Detail("%-20s\t%s", "metric", p.Metric())
+	msg.Detail("%-20s\t%g", "target", p.Target())
+}
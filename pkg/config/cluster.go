@@ -48,6 +48,7 @@ type Cluster struct {
 	Pods          *Pods        `json:"pods"`
 	SecurityTags_ SecurityTags `json:"security_tags"`
 	AuditIgnore_  bool         `json:"audit_ignore"`
+	Target_       string       `json:"target"`
 }
 
 // Name satisfies the resource.StaticCluster interface.
@@ -63,10 +64,19 @@ func (c *Cluster) AuditIgnore() bool {
 	return c.AuditIgnore_
 }
 
+// Target is the name of the provider target the cluster is pinned to.
+// An empty target refers to the datacenter provider.
+func (c *Cluster) Target() string {
+	return c.Target_
+}
+
 // Print provides a user friendly way to view the cluster configuration.
 func (c *Cluster) Print() {
 	msg.Info("Cluster Config")
 	msg.Detail("%-20s\t%s", "name", c.Name())
+	if c.Target() != "" {
+		msg.Detail("%-20s\t%s", "target", c.Target())
+	}
 	msg.IndentInc()
 	if c.Pods != nil {
 		c.Pods.Print()
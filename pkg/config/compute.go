@@ -36,10 +36,11 @@ import "github.com/cisco/arc/pkg/msg"
 // configuration file. It is set by the application at run time.
 type Compute struct {
 	Name_             string
-	BootstrapVersion_ int `json:"bootstrap_version"`
-	DeployVersion_    int `json:"deploy_version"`
-	SecretsVersion_   int `json:"secrets_version"`
-	AideVersion_      int `json:"aide_version"`
+	BootstrapVersion_ int    `json:"bootstrap_version"`
+	DeployVersion_    int    `json:"deploy_version"`
+	SecretsVersion_   int    `json:"secrets_version"`
+	AideVersion_      int    `json:"aide_version"`
+	Naming_           string `json:"naming"`
 	KeyPair           *KeyPair
 	Clusters          *Clusters `json:"clusters"`
 }
@@ -74,12 +75,25 @@ func (c *Compute) AideVersion() int {
 	return c.AideVersion_
 }
 
+// Naming satisfies the resource.StaticCompute interface. It is the template
+// instance names are expanded from, unless the pod has its own. Templates
+// that expand to the same name in different pods aren't detected here, they
+// fail the construction of the datacenter, which precedes every command
+// including config.
+func (c *Compute) Naming() string {
+	if c.Naming_ == "" {
+		return DefaultNaming
+	}
+	return c.Naming_
+}
+
 // PrintLocal provides a user friendly way to view the configuration local to the network object.
 func (c *Compute) PrintLocal() {
 	msg.Info("Compute Config")
 	msg.Detail("%-20s\t%d", "bootstrap version", c.BootstrapVersion())
 	msg.Detail("%-20s\t%d", "deploy version", c.DeployVersion())
 	msg.Detail("%-20s\t%d", "secrets version", c.SecretsVersion())
+	msg.Detail("%-20s\t%s", "naming", c.Naming())
 }
 
 // Print provides a user friendly way to view the compute configuration.
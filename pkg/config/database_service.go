@@ -31,14 +31,24 @@ import "github.com/cisco/arc/pkg/msg"
 // The configuration of the database_service object.
 type DatabaseService struct {
 	Provider  *Provider   `json:"provider"`
+	Target_   string      `json:"target"`
 	Databases []*Database `json:"databases"`
 }
 
+// Target is the name of the datacenter provider target the database service
+// is pinned to. It is ignored when the database service has its own provider.
+func (dbs *DatabaseService) Target() string {
+	return dbs.Target_
+}
+
 // Print provides a user friendly way to view the configuration of the database service.
 // This is a deep print.
 func (dbs *DatabaseService) Print() {
 	msg.Info("Database Service Config")
 	msg.IndentInc()
+	if dbs.Target() != "" {
+		msg.Detail("%-20s\t%s", "target", dbs.Target())
+	}
 	if dbs.Provider != nil {
 		dbs.Provider.Print()
 	}
@@ -35,12 +35,19 @@ type DataCenter struct {
 	Network       *Network     `json:"network"`
 	Compute       *Compute     `json:"compute"`
 	SecurityTags_ SecurityTags `json:"security_tags"`
+	TagPolicy_    *TagPolicy   `json:"tag_policy"`
 }
 
 func (d *DataCenter) SecurityTags() SecurityTags {
 	return d.SecurityTags_
 }
 
+// TagPolicy returns the rules the tags of the datacenter's resources must
+// follow. It is nil when the datacenter doesn't have a tag policy.
+func (d *DataCenter) TagPolicy() *TagPolicy {
+	return d.TagPolicy_
+}
+
 // Print provides a user friendly way to view the entire datacenter configuration.
 // This is a deep print.
 func (d *DataCenter) Print() {
@@ -52,6 +59,9 @@ func (d *DataCenter) Print() {
 	if d.SecurityTags_ != nil {
 		d.SecurityTags_.Print()
 	}
+	if d.TagPolicy_ != nil {
+		d.TagPolicy_.Print()
+	}
 	if d.Network != nil {
 		d.Network.Print()
 	}
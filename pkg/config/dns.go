@@ -29,13 +29,21 @@ package config
 import "github.com/cisco/arc/pkg/msg"
 
 // Dns configuration contains a domain name, a subdomain, a provider record
-// a list of a records, and a list of cname records.
+// a list of a records, a list of aaaa records, a list of cname records and
+// lists of txt, mx, srv, caa and alias records.
 type Dns struct {
 	DomainName_  string      `json:"domain_name"`
 	Subdomain_   string      `json:"subdomain"`
 	Provider     *Provider   `json:"provider"`
+	Target_      string      `json:"target"`
 	ARecords     *DnsRecords `json:"a_records"`
+	AAAARecords  *DnsRecords `json:"aaaa_records"`
 	CNameRecords *DnsRecords `json:"cname_records"`
+	TXTRecords   *DnsRecords `json:"txt_records"`
+	MXRecords    *DnsRecords `json:"mx_records"`
+	SRVRecords   *DnsRecords `json:"srv_records"`
+	CAARecords   *DnsRecords `json:"caa_records"`
+	AliasRecords *DnsRecords `json:"alias_records"`
 	CacheIgnore  []string    `json:"cache_ignore"`
 }
 
@@ -60,12 +68,21 @@ func (d *Dns) Domain() string {
 	return d.DomainName()
 }
 
+// Target is the name of the datacenter provider target the dns zone is
+// pinned to. It is ignored when the dns has its own provider.
+func (d *Dns) Target() string {
+	return d.Target_
+}
+
 // PrintLocal provides a user friendly way to view the configuration local to the dns object.
 func (d *Dns) PrintLocal() {
 	msg.Info("DNS Config")
 	msg.Detail("%-20s\t%s", "domain_name", d.DomainName())
 	msg.Detail("%-20s\t%s", "subdomain", d.Subdomain())
 	msg.Detail("%-20s\t%s", "domain", d.Domain())
+	if d.Target() != "" {
+		msg.Detail("%-20s\t%s", "target", d.Target())
+	}
 }
 
 // Print provides a user friendly way to view the datacenter configuration.
@@ -78,8 +95,26 @@ func (d *Dns) Print() {
 	if d.ARecords != nil {
 		d.ARecords.Print("A")
 	}
+	if d.AAAARecords != nil {
+		d.AAAARecords.Print("AAAA")
+	}
 	if d.CNameRecords != nil {
 		d.CNameRecords.Print("CNAME")
 	}
+	if d.TXTRecords != nil {
+		d.TXTRecords.Print("TXT")
+	}
+	if d.MXRecords != nil {
+		d.MXRecords.Print("MX")
+	}
+	if d.SRVRecords != nil {
+		d.SRVRecords.Print("SRV")
+	}
+	if d.CAARecords != nil {
+		d.CAARecords.Print("CAA")
+	}
+	if d.AliasRecords != nil {
+		d.AliasRecords.Print("ALIAS")
+	}
 	msg.IndentDec()
 }
@@ -26,7 +26,13 @@
 
 package config
 
-import "github.com/cisco/arc/pkg/msg"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cisco/arc/pkg/msg"
+)
 
 // DnsRecords is a collection of DnsRecord objects.
 type DnsRecords []*DnsRecord
@@ -45,13 +51,29 @@ func (d *DnsRecords) Print(s string) {
 // and an optional set of values. For cname records that are associated with a pod
 // the name, ttl and pod values are mandatory. If this cname needs to be associated with
 // the public ip address of an instance in the pod, the access field need to be set
-// to "public". For a records the name, ttl and values are required.
+// to "public". If it needs to be associated with the pod's load balancer, the access
+// field needs to be set to "load_balancer". For a records the name, ttl and values
+// are required.
+//
+// Txt, mx and caa records require values, in the format used by the zone file
+// ("10 mail.example.com" for mx, "0 issue \"amazon.com\"" for caa). Srv records
+// either have values, "priority weight port target", or a pod, port, and
+// optionally a priority and weight, in which case a value is derived for each of
+// the pod's instances. Alias records point at a pod's load balancer, at the website
+// endpoint of a bucket, named the same as the record, in the given region, or at
+// an explicit alias target. A record named "@" is at the apex of the domain.
 type DnsRecord struct {
-	Name_   string   `json:"name"`
-	Ttl_    int      `json:"ttl"`
-	Pod_    string   `json:"pod"`
-	Access_ string   `json:"access"`
-	Values_ []string `json:"values"`
+	Name_        string       `json:"name"`
+	Ttl_         int          `json:"ttl"`
+	Pod_         string       `json:"pod"`
+	Access_      string       `json:"access"`
+	Values_      []string     `json:"values"`
+	Priority_    int          `json:"priority"`
+	Weight_      int          `json:"weight"`
+	Port_        int          `json:"port"`
+	Bucket_      string       `json:"bucket"`
+	Region_      string       `json:"region"`
+	AliasTarget_ *AliasTarget `json:"alias_target"`
 }
 
 // Name satisfies the resource.StaticDnsRecord interface.
@@ -83,11 +105,144 @@ func (d *DnsRecord) Values() []string {
 	return d.Values_
 }
 
+// Priority is the priority of the srv records derived from a pod.
+func (d *DnsRecord) Priority() int {
+	return d.Priority_
+}
+
+// Weight is the weight of the srv records derived from a pod.
+func (d *DnsRecord) Weight() int {
+	return d.Weight_
+}
+
+// Port is the port of the srv records derived from a pod.
+func (d *DnsRecord) Port() int {
+	return d.Port_
+}
+
+// Bucket is the name of the bucket an alias record points at.
+func (d *DnsRecord) Bucket() string {
+	return d.Bucket_
+}
+
+// Region is the region of the bucket an alias record points at. It defaults to
+// the region of the dns provider.
+func (d *DnsRecord) Region() string {
+	return d.Region_
+}
+
+// AliasTarget is the target of an alias record.
+func (d *DnsRecord) AliasTarget() *AliasTarget {
+	return d.AliasTarget_
+}
+
+// SetAliasTarget will set the target of an alias record. It is intended for those alias
+// records whose target is created dynamically.
+func (d *DnsRecord) SetAliasTarget(t *AliasTarget) {
+	d.AliasTarget_ = t
+}
+
 // SetValues will set the values of the record. It is intended for those dns records that are created dynamically.
 func (d *DnsRecord) SetValues(v []string) {
 	d.Values_ = v
 }
 
+// Validate checks the record against the requirements of its type, "A", "AAAA",
+// "CNAME", "TXT", "MX", "SRV", "CAA" or "ALIAS".
+func (d *DnsRecord) Validate(t string) error {
+	switch t {
+	case "A", "AAAA", "CNAME":
+		return nil
+	case "TXT", "MX", "CAA":
+		if d.Pod() != "" {
+			return fmt.Errorf("Dns %s record %q cannot be associated with a pod", t, d.Name())
+		}
+		if len(d.Values()) < 1 {
+			return fmt.Errorf("Dns %s record %q requires values", t, d.Name())
+		}
+	case "SRV":
+		if d.Pod() == "" && len(d.Values()) < 1 {
+			return fmt.Errorf("Dns SRV record %q requires either values or a pod", d.Name())
+		}
+		if d.Pod() != "" && len(d.Values()) > 0 {
+			return fmt.Errorf("Dns SRV record %q cannot have both values and a pod", d.Name())
+		}
+		if d.Pod() != "" {
+			if d.Port() < 1 || d.Port() > 65535 {
+				return fmt.Errorf("Dns SRV record %q: The port must be between 1 and 65535", d.Name())
+			}
+			if d.Priority() < 0 || d.Priority() > 65535 || d.Weight() < 0 || d.Weight() > 65535 {
+				return fmt.Errorf("Dns SRV record %q: The priority and weight must be between 0 and 65535", d.Name())
+			}
+			switch d.Access() {
+			case "private", "public", "public_elastic":
+			default:
+				return fmt.Errorf("Dns SRV record %q: Unknown access %q", d.Name(), d.Access())
+			}
+		}
+	case "ALIAS":
+		targets := 0
+		for _, target := range []bool{d.Pod() != "", d.Bucket() != "", d.AliasTarget() != nil} {
+			if target {
+				targets++
+			}
+		}
+		if targets != 1 {
+			return fmt.Errorf("Dns ALIAS record %q requires exactly one of a pod, a bucket or an alias target", d.Name())
+		}
+		if len(d.Values()) > 0 {
+			return fmt.Errorf("Dns ALIAS record %q cannot have values", d.Name())
+		}
+		if d.AliasTarget() != nil && (d.AliasTarget().HostedZoneId() == "" || d.AliasTarget().DnsName() == "") {
+			return fmt.Errorf("Dns ALIAS record %q: The alias target requires a hosted zone id and a dns name", d.Name())
+		}
+		return nil
+	default:
+		return fmt.Errorf("Unknown dns record type %s", t)
+	}
+	for _, v := range d.Values() {
+		if err := validateDnsValue(t, v); err != nil {
+			return fmt.Errorf("Dns %s record %q: %s", t, d.Name(), err)
+		}
+	}
+	return nil
+}
+
+// validateDnsValue checks the format of the value of a txt, mx, srv or caa record.
+func validateDnsValue(t, v string) error {
+	fields := strings.Fields(v)
+	switch t {
+	case "TXT":
+		if len(v) > 255 {
+			return fmt.Errorf("The value is longer than 255 characters")
+		}
+		return nil
+	case "MX":
+		if len(fields) != 2 || !dnsUint(fields[0], 65535) {
+			return fmt.Errorf("The value %q isn't of the form \"priority host\"", v)
+		}
+	case "SRV":
+		if len(fields) != 4 || !dnsUint(fields[0], 65535) || !dnsUint(fields[1], 65535) || !dnsUint(fields[2], 65535) {
+			return fmt.Errorf("The value %q isn't of the form \"priority weight port target\"", v)
+		}
+	case "CAA":
+		if len(fields) < 3 || !dnsUint(fields[0], 255) {
+			return fmt.Errorf("The value %q isn't of the form \"flags tag value\"", v)
+		}
+		switch fields[1] {
+		case "issue", "issuewild", "iodef":
+		default:
+			return fmt.Errorf("Unknown caa tag %q", fields[1])
+		}
+	}
+	return nil
+}
+
+func dnsUint(s string, max int) bool {
+	i, err := strconv.Atoi(s)
+	return err == nil && i >= 0 && i <= max
+}
+
 // PrintLocal provides a user friendly way to view the configuration local of the dns record.
 // This is a shallow print.
 func (d *DnsRecord) PrintLocal(s string) {
@@ -98,6 +253,21 @@ func (d *DnsRecord) PrintLocal(s string) {
 		msg.Detail("%-20s\t%s", "pod", d.Pod())
 		msg.Detail("%-20s\t%s", "access", d.Access())
 	}
+	if d.Port() != 0 {
+		msg.Detail("%-20s\t%d", "priority", d.Priority())
+		msg.Detail("%-20s\t%d", "weight", d.Weight())
+		msg.Detail("%-20s\t%d", "port", d.Port())
+	}
+	if d.Bucket() != "" {
+		msg.Detail("%-20s\t%s", "bucket", d.Bucket())
+	}
+	if d.Region() != "" {
+		msg.Detail("%-20s\t%s", "region", d.Region())
+	}
+	if d.AliasTarget() != nil {
+		msg.Detail("%-20s\t%s", "alias hosted zone", d.AliasTarget().HostedZoneId())
+		msg.Detail("%-20s\t%s", "alias dns name", d.AliasTarget().DnsName())
+	}
 	values, sep := "", ""
 	for _, value := range d.Values() {
 		values += sep + value
@@ -113,3 +283,20 @@ func (d *DnsRecord) PrintLocal(s string) {
 func (d *DnsRecord) Print(s string) {
 	d.PrintLocal(s)
 }
+
+// AliasTarget configuration is the hosted zone id and dns name of the
+// resource an alias record points at.
+type AliasTarget struct {
+	HostedZoneId_ string `json:"hosted_zone_id"`
+	DnsName_      string `json:"dns_name"`
+}
+
+// HostedZoneId is the id of the hosted zone of the alias target.
+func (a *AliasTarget) HostedZoneId() string {
+	return a.HostedZoneId_
+}
+
+// DnsName is the dns name of the alias target.
+func (a *AliasTarget) DnsName() string {
+	return a.DnsName_
+}
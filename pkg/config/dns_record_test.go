@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import "testing"
+
+func TestDnsRecordValidate(t *testing.T) {
+	type test struct {
+		recordType string
+		record     *DnsRecord
+	}
+
+	valid := []test{
+		{"A", &DnsRecord{Name_: "www", Ttl_: 300, Values_: []string{"10.0.0.1"}}},
+		{"TXT", &DnsRecord{Name_: "@", Values_: []string{"v=spf1 include:_spf.example.com ~all"}}},
+		{"MX", &DnsRecord{Name_: "mail", Values_: []string{"10 mx1.example.com", "20 mx2.example.com"}}},
+		{"SRV", &DnsRecord{Name_: "_ldap._tcp", Values_: []string{"0 5 389 ldap.example.com"}}},
+		{"SRV", &DnsRecord{Name_: "_http._tcp.web", Pod_: "web", Port_: 8080, Priority_: 10, Weight_: 5}},
+		{"CAA", &DnsRecord{Name_: "@", Values_: []string{"0 issue \"amazon.com\"", "0 iodef \"mailto:ops@example.com\""}}},
+		{"ALIAS", &DnsRecord{Name_: "api", Pod_: "web"}},
+		{"ALIAS", &DnsRecord{Name_: "static", Bucket_: "static.example.com", Region_: "us-west-2"}},
+		{"ALIAS", &DnsRecord{Name_: "cdn", AliasTarget_: &AliasTarget{HostedZoneId_: "Z2FDTNDATAQYW2", DnsName_: "d111111abcdef8.cloudfront.net"}}},
+	}
+	for _, v := range valid {
+		if err := v.record.Validate(v.recordType); err != nil {
+			t.Errorf("Expected dns %s record %+v to be valid: %s", v.recordType, *v.record, err)
+		}
+	}
+
+	invalid := []test{
+		{"NS", &DnsRecord{Name_: "www", Values_: []string{"ns1.example.com"}}},
+		{"TXT", &DnsRecord{Name_: "@"}},
+		{"TXT", &DnsRecord{Name_: "web", Pod_: "web", Values_: []string{"text"}}},
+		{"MX", &DnsRecord{Name_: "mail", Values_: []string{"mx1.example.com"}}},
+		{"MX", &DnsRecord{Name_: "mail", Values_: []string{"70000 mx1.example.com"}}},
+		{"SRV", &DnsRecord{Name_: "_ldap._tcp"}},
+		{"SRV", &DnsRecord{Name_: "_ldap._tcp", Values_: []string{"0 5 ldap.example.com"}}},
+		{"SRV", &DnsRecord{Name_: "_http._tcp.web", Pod_: "web"}},
+		{"SRV", &DnsRecord{Name_: "_http._tcp.web", Pod_: "web", Port_: 8080, Values_: []string{"0 5 8080 web.example.com"}}},
+		{"SRV", &DnsRecord{Name_: "_http._tcp.web", Pod_: "web", Port_: 8080, Access_: "load_balancer"}},
+		{"CAA", &DnsRecord{Name_: "@", Values_: []string{"0 issue"}}},
+		{"CAA", &DnsRecord{Name_: "@", Values_: []string{"0 allow \"amazon.com\""}}},
+		{"CAA", &DnsRecord{Name_: "@", Values_: []string{"256 issue \"amazon.com\""}}},
+		{"ALIAS", &DnsRecord{Name_: "api"}},
+		{"ALIAS", &DnsRecord{Name_: "api", Pod_: "web", Bucket_: "api.example.com"}},
+		{"ALIAS", &DnsRecord{Name_: "api", Pod_: "web", Values_: []string{"web.example.com"}}},
+		{"ALIAS", &DnsRecord{Name_: "cdn", AliasTarget_: &AliasTarget{DnsName_: "d111111abcdef8.cloudfront.net"}}},
+	}
+	for _, v := range invalid {
+		if err := v.record.Validate(v.recordType); err == nil {
+			t.Errorf("Expected dns %s record %+v to be invalid", v.recordType, *v.record)
+		}
+	}
+}
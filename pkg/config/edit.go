@@ -0,0 +1,310 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/cisco/arc/pkg/env"
+)
+
+// UpdateArc sets the value at path in the config file of the given datacenter.
+// See SetValue for the form of the path.
+func UpdateArc(dc string, path []string, value interface{}) error {
+	file := fmt.Sprintf(env.Lookup("ROOT")+"/etc/arc/%s.json", dc)
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	data, err = SetValue(data, path, value)
+	if err != nil {
+		return fmt.Errorf("Unable to update %s: %s", file, err)
+	}
+	return ioutil.WriteFile(file, data, info.Mode())
+}
+
+// SetValue sets the value at path in the json document data and returns the
+// updated document. Only the text of the value is replaced, so the rest of
+// the document, including its formatting, is left untouched. Each path element
+// names an object member, except elements of the form "key=value" which select
+// the array element whose key member has the given value. Object members
+// missing from the document are added.
+func SetValue(data []byte, path []string, value interface{}) ([]byte, error) {
+	data, err := setValue(data, 0, path, value)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("Invalid json document")
+	}
+	return data, nil
+}
+
+func setValue(data []byte, pos int, path []string, value interface{}) ([]byte, error) {
+	s := &jsonScanner{data: data, pos: pos}
+	if len(path) == 0 {
+		start, end, err := s.skip()
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return splice(data, start, end, string(v)), nil
+	}
+
+	elem := path[0]
+	if key, val, ok := selector(elem); ok {
+		elements, err := s.elements()
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range elements {
+			members, _, err := (&jsonScanner{data: data, pos: e}).members()
+			if err != nil {
+				continue
+			}
+			for _, m := range members {
+				if m.key == key && m.matches(data, val) {
+					return setValue(data, e, path[1:], value)
+				}
+			}
+		}
+		return nil, fmt.Errorf("No element matching %q", elem)
+	}
+
+	members, closing, err := s.members()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		if m.key == elem {
+			return setValue(data, m.start, path[1:], value)
+		}
+	}
+
+	// The member is missing, so add it along with any missing members below it.
+	v := value
+	for n := len(path) - 1; n > 0; n-- {
+		if _, _, ok := selector(path[n]); ok {
+			return nil, fmt.Errorf("No array found for %q", path[n])
+		}
+		v = map[string]interface{}{path[n]: v}
+	}
+	k, err := json.Marshal(elem)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	member := string(k) + ": " + string(b)
+	if len(members) == 0 {
+		return splice(data, closing, closing, " "+member+" "), nil
+	}
+	last := members[len(members)-1]
+	lineStart := strings.LastIndexByte(string(data[:last.keyStart]), '\n') + 1
+	if lineStart <= pos {
+		return splice(data, last.end, last.end, ", "+member), nil
+	}
+	indent := data[lineStart:last.keyStart]
+	return splice(data, last.end, last.end, ",\n"+string(indent)+member), nil
+}
+
+// selector splits an array element selector of the form "key=value".
+func selector(elem string) (string, string, bool) {
+	n := strings.Index(elem, "=")
+	if n < 0 {
+		return "", "", false
+	}
+	return elem[:n], elem[n+1:], true
+}
+
+func splice(data []byte, start, end int, s string) []byte {
+	d := make([]byte, 0, len(data)-(end-start)+len(s))
+	d = append(d, data[:start]...)
+	d = append(d, s...)
+	return append(d, data[end:]...)
+}
+
+// jsonMember is an object member, with the offsets of its key and the span of its value.
+type jsonMember struct {
+	key      string
+	keyStart int
+	start    int
+	end      int
+}
+
+func (m jsonMember) matches(data []byte, val string) bool {
+	var v interface{}
+	if err := json.Unmarshal(data[m.start:m.end], &v); err != nil {
+		return false
+	}
+	return fmt.Sprint(v) == val
+}
+
+// jsonScanner finds the offsets of values in a json document.
+type jsonScanner struct {
+	data []byte
+	pos  int
+}
+
+func (s *jsonScanner) space() {
+	for s.pos < len(s.data) && strings.IndexByte(" \t\r\n", s.data[s.pos]) >= 0 {
+		s.pos++
+	}
+}
+
+func (s *jsonScanner) next(c byte) bool {
+	s.space()
+	if s.pos < len(s.data) && s.data[s.pos] == c {
+		s.pos++
+		return true
+	}
+	return false
+}
+
+// skip moves past the value at the current position and returns its span.
+func (s *jsonScanner) skip() (int, int, error) {
+	s.space()
+	start := s.pos
+	if s.pos >= len(s.data) {
+		return 0, 0, fmt.Errorf("Unexpected end of json document")
+	}
+	switch s.data[s.pos] {
+	case '"':
+		for s.pos++; s.pos < len(s.data); s.pos++ {
+			switch s.data[s.pos] {
+			case '\\':
+				s.pos++
+			case '"':
+				s.pos++
+				return start, s.pos, nil
+			}
+		}
+		return 0, 0, fmt.Errorf("Unterminated string at offset %d", start)
+	case '{', '[':
+		depth := 0
+		for s.pos < len(s.data) {
+			switch s.data[s.pos] {
+			case '"':
+				if _, _, err := s.skip(); err != nil {
+					return 0, 0, err
+				}
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					s.pos++
+					return start, s.pos, nil
+				}
+			}
+			s.pos++
+		}
+		return 0, 0, fmt.Errorf("Unterminated value at offset %d", start)
+	}
+	for s.pos < len(s.data) && strings.IndexByte(",:}] \t\r\n", s.data[s.pos]) < 0 {
+		s.pos++
+	}
+	if s.pos == start {
+		return 0, 0, fmt.Errorf("Unexpected %q at offset %d", s.data[s.pos], s.pos)
+	}
+	return start, s.pos, nil
+}
+
+// members returns the members of the object at the current position and the
+// offset of its closing brace.
+func (s *jsonScanner) members() ([]jsonMember, int, error) {
+	if !s.next('{') {
+		return nil, 0, fmt.Errorf("Expected an object at offset %d", s.pos)
+	}
+	members := []jsonMember{}
+	if s.next('}') {
+		return members, s.pos - 1, nil
+	}
+	for {
+		keyStart, keyEnd, err := s.skip()
+		if err != nil {
+			return nil, 0, err
+		}
+		m := jsonMember{keyStart: keyStart}
+		if err := json.Unmarshal(s.data[keyStart:keyEnd], &m.key); err != nil {
+			return nil, 0, fmt.Errorf("Expected a member name at offset %d", keyStart)
+		}
+		if !s.next(':') {
+			return nil, 0, fmt.Errorf("Expected ':' at offset %d", s.pos)
+		}
+		if m.start, m.end, err = s.skip(); err != nil {
+			return nil, 0, err
+		}
+		members = append(members, m)
+		if s.next(',') {
+			continue
+		}
+		if s.next('}') {
+			return members, s.pos - 1, nil
+		}
+		return nil, 0, fmt.Errorf("Expected ',' or '}' at offset %d", s.pos)
+	}
+}
+
+// elements returns the offsets of the elements of the array at the current position.
+func (s *jsonScanner) elements() ([]int, error) {
+	if !s.next('[') {
+		return nil, fmt.Errorf("Expected an array at offset %d", s.pos)
+	}
+	elements := []int{}
+	if s.next(']') {
+		return elements, nil
+	}
+	for {
+		start, _, err := s.skip()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, start)
+		if s.next(',') {
+			continue
+		}
+		if s.next(']') {
+			return elements, nil
+		}
+		return nil, fmt.Errorf("Expected ',' or ']' at offset %d", s.pos)
+	}
+}
@@ -0,0 +1,97 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+const editDoc = `{
+  "name": "dc",
+
+  "datacenter": {
+    "provider": { "vendor": "aws" },
+    "compute": {
+      "clusters": [
+        {
+          "cluster": "clusterA",
+          "pods": [
+            { "pod": "podA", "count": 3 },
+            { "pod": "podB", "count": 1 }
+          ]
+        }
+      ]
+    }
+  }
+}
+`
+
+func TestSetValueReplace(t *testing.T) {
+	path := []string{"datacenter", "compute", "clusters", "cluster=clusterA", "pods", "pod=podB", "count"}
+	data, err := SetValue([]byte(editDoc), path, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `            { "pod": "podB", "count": 4 }`
+	if !strings.Contains(string(data), expected) {
+		t.Errorf("Expected %q in\n%s", expected, data)
+	}
+	if !strings.Contains(string(data), `{ "pod": "podA", "count": 3 }`) {
+		t.Errorf("Expected podA to be unchanged in\n%s", data)
+	}
+}
+
+func TestSetValueAddInline(t *testing.T) {
+	path := []string{"datacenter", "provider", "images", "centos7-base-1"}
+	data, err := SetValue([]byte(editDoc), path, "ami-1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `"provider": { "vendor": "aws", "images": {"centos7-base-1":"ami-1234"} },`
+	if !strings.Contains(string(data), expected) {
+		t.Errorf("Expected %q in\n%s", expected, data)
+	}
+}
+
+func TestSetValueAddIndented(t *testing.T) {
+	data, err := SetValue([]byte(editDoc), []string{"title"}, "Test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "  },\n  \"title\": \"Test\"\n}"
+	if !strings.Contains(string(data), expected) {
+		t.Errorf("Expected %q in\n%s", expected, data)
+	}
+}
+
+func TestSetValueUnknownElement(t *testing.T) {
+	path := []string{"datacenter", "compute", "clusters", "cluster=clusterB", "pods"}
+	if _, err := SetValue([]byte(editDoc), path, 1); err == nil {
+		t.Error("Expected an error for an unknown cluster")
+	}
+}
@@ -0,0 +1,272 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// Endpoints is a collection of Endpoint objects.
+type Endpoints []*Endpoint
+
+// Validate checks the endpoints are uniquely named and well formed. Since
+// endpoints are identified by their service, a service can only have one endpoint.
+func (e Endpoints) Validate() error {
+	names := map[string]bool{}
+	services := map[string]bool{}
+	for _, endpoint := range e {
+		if err := endpoint.Validate(); err != nil {
+			return err
+		}
+		if names[endpoint.Name()] {
+			return fmt.Errorf("Endpoint %q is configured more than once", endpoint.Name())
+		}
+		if services[endpoint.Service()] {
+			return fmt.Errorf("Endpoint %q: The service %q already has an endpoint", endpoint.Name(), endpoint.Service())
+		}
+		names[endpoint.Name()] = true
+		services[endpoint.Service()] = true
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the endpoints configuration.
+func (e Endpoints) Print() {
+	msg.Info("Endpoints Config")
+	msg.IndentInc()
+	for _, endpoint := range e {
+		endpoint.Print()
+	}
+	msg.IndentDec()
+}
+
+// The configuration of a vpc endpoint. It has a name and the service being
+// reached, e.g. s3, dynamodb or ec2. The type is either gateway or interface,
+// defaulting to gateway for s3 and dynamodb and interface otherwise.
+//
+// Gateway endpoints are attached to the named route tables, or to all of the
+// network's route tables when none are named. Interface endpoints are placed
+// in the subnets of a subnet group and use a security group.
+//
+// The endpoint policy is either generated from a list of amp buckets, allowing
+// access to those buckets only, or given as a policy document. References of the
+// form "${bucket:name}" in the policy document are replaced by the arn of the
+// named bucket.
+type Endpoint struct {
+	Name_           string   `json:"name"`
+	Service_        string   `json:"service"`
+	Type_           string   `json:"type"`
+	RouteTables_    []string `json:"route_tables"`
+	SubnetGroup_    string   `json:"subnet_group"`
+	SecurityGroup_  string   `json:"security_group"`
+	PrivateDns_     bool     `json:"private_dns"`
+	Buckets_        []string `json:"buckets"`
+	PolicyDocument_ string   `json:"policy_document"`
+}
+
+// Name of the endpoint.
+func (e *Endpoint) Name() string {
+	return e.Name_
+}
+
+// Service is the short name of the service, e.g. s3.
+func (e *Endpoint) Service() string {
+	return e.Service_
+}
+
+// Type is either gateway or interface.
+func (e *Endpoint) Type() string {
+	if e.Type_ != "" {
+		return e.Type_
+	}
+	switch e.Service() {
+	case "s3", "dynamodb":
+		return "gateway"
+	}
+	return "interface"
+}
+
+// RouteTables are the names of the route tables a gateway endpoint is attached to.
+func (e *Endpoint) RouteTables() []string {
+	return e.RouteTables_
+}
+
+// AttachedTo returns true if a gateway endpoint is attached to the named route table.
+func (e *Endpoint) AttachedTo(routeTable string) bool {
+	if len(e.RouteTables()) == 0 {
+		return true
+	}
+	for _, r := range e.RouteTables() {
+		if r == routeTable {
+			return true
+		}
+	}
+	return false
+}
+
+// SubnetGroup is the subnet group an interface endpoint is placed in.
+func (e *Endpoint) SubnetGroup() string {
+	return e.SubnetGroup_
+}
+
+// SecurityGroup is the security group used by an interface endpoint.
+func (e *Endpoint) SecurityGroup() string {
+	return e.SecurityGroup_
+}
+
+// PrivateDns enables private dns names for an interface endpoint.
+func (e *Endpoint) PrivateDns() bool {
+	return e.PrivateDns_
+}
+
+// Buckets are the names of the amp buckets the endpoint policy allows access to.
+func (e *Endpoint) Buckets() []string {
+	return e.Buckets_
+}
+
+// BucketArn returns the arn of the named bucket.
+func BucketArn(name string) string {
+	return "arn:aws:s3:::" + name
+}
+
+// Policy returns the endpoint policy. It is empty when the endpoint uses the
+// provider's default policy, which allows full access.
+func (e *Endpoint) Policy() (string, error) {
+	if e.PolicyDocument_ != "" {
+		return expandBuckets(e.PolicyDocument_)
+	}
+	if len(e.Buckets()) == 0 {
+		return "", nil
+	}
+	resources := []string{}
+	for _, b := range e.Buckets() {
+		resources = append(resources, BucketArn(b), BucketArn(b)+"/*")
+	}
+	policy := map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []interface{}{
+			map[string]interface{}{
+				"Effect":    "Allow",
+				"Principal": "*",
+				"Action":    "s3:*",
+				"Resource":  resources,
+			},
+		},
+	}
+	b, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// expandBuckets replaces the "${bucket:name}" references in the policy document.
+func expandBuckets(policy string) (string, error) {
+	s := ""
+	for {
+		n := strings.Index(policy, "${bucket:")
+		if n < 0 {
+			break
+		}
+		end := strings.Index(policy[n:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("Policy document has an unterminated bucket reference")
+		}
+		name := policy[n+len("${bucket:") : n+end]
+		if name == "" {
+			return "", fmt.Errorf("Policy document has an empty bucket reference")
+		}
+		s += policy[:n] + BucketArn(name)
+		policy = policy[n+end+1:]
+	}
+	s += policy
+	if !json.Valid([]byte(s)) {
+		return "", fmt.Errorf("Policy document isn't valid json")
+	}
+	return s, nil
+}
+
+// Validate checks the endpoint's type and that it has the elements its type requires.
+func (e *Endpoint) Validate() error {
+	if e.Name() == "" {
+		return fmt.Errorf("Endpoint is missing a name")
+	}
+	if e.Service() == "" {
+		return fmt.Errorf("Endpoint %q is missing a service", e.Name())
+	}
+	switch e.Type() {
+	case "gateway":
+		if e.SubnetGroup() != "" || e.SecurityGroup() != "" {
+			return fmt.Errorf("Endpoint %q: Gateway endpoints don't use a subnet group or security group", e.Name())
+		}
+	case "interface":
+		if e.SubnetGroup() == "" || e.SecurityGroup() == "" {
+			return fmt.Errorf("Endpoint %q: Interface endpoints require a subnet group and a security group", e.Name())
+		}
+		if len(e.RouteTables()) > 0 {
+			return fmt.Errorf("Endpoint %q: Interface endpoints aren't attached to route tables", e.Name())
+		}
+	default:
+		return fmt.Errorf("Endpoint %q has an unknown type %q, expecting gateway or interface", e.Name(), e.Type())
+	}
+	if len(e.Buckets()) > 0 && e.PolicyDocument_ != "" {
+		return fmt.Errorf("Endpoint %q: Use either buckets or a policy document, not both", e.Name())
+	}
+	if len(e.Buckets()) > 0 && e.Service() != "s3" {
+		return fmt.Errorf("Endpoint %q: Buckets can only be used with the s3 service", e.Name())
+	}
+	if _, err := e.Policy(); err != nil {
+		return fmt.Errorf("Endpoint %q: %s", e.Name(), err.Error())
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the endpoint configuration.
+func (e *Endpoint) Print() {
+	msg.Info("Endpoint Config")
+	msg.Detail("%-20s\t%s", "name", e.Name())
+	msg.Detail("%-20s\t%s", "service", e.Service())
+	msg.Detail("%-20s\t%s", "type", e.Type())
+	if len(e.RouteTables()) > 0 {
+		msg.Detail("%-20s\t%s", "route tables", strings.Join(e.RouteTables(), ", "))
+	}
+	if e.SubnetGroup() != "" {
+		msg.Detail("%-20s\t%s", "subnet group", e.SubnetGroup())
+		msg.Detail("%-20s\t%s", "security group", e.SecurityGroup())
+		msg.Detail("%-20s\t%t", "private dns", e.PrivateDns())
+	}
+	if len(e.Buckets()) > 0 {
+		msg.Detail("%-20s\t%s", "buckets", strings.Join(e.Buckets(), ", "))
+	}
+	if e.PolicyDocument_ != "" {
+		msg.Detail("%-20s\t%s", "policy document", e.PolicyDocument_)
+	}
+}
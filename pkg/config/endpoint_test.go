@@ -0,0 +1,101 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import "testing"
+
+func TestEndpointType(t *testing.T) {
+	tests := map[string]*Endpoint{
+		"gateway":   {Service_: "s3"},
+		"interface": {Service_: "ec2"},
+	}
+	for expected, e := range tests {
+		if e.Type() != expected {
+			t.Errorf("Endpoint for %s: expected %s, got %s", e.Service(), expected, e.Type())
+		}
+	}
+	if e := (&Endpoint{Service_: "dynamodb", Type_: "interface"}); e.Type() != "interface" {
+		t.Errorf("Expected the configured type to override the default")
+	}
+}
+
+func TestEndpointPolicy(t *testing.T) {
+	e := &Endpoint{Name_: "s3", Service_: "s3", Buckets_: []string{"logs"}}
+	policy, err := e.Policy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"Statement":[{"Action":"s3:*","Effect":"Allow","Principal":"*","Resource":["arn:aws:s3:::logs","arn:aws:s3:::logs/*"]}],"Version":"2012-10-17"}`
+	if policy != expected {
+		t.Errorf("Expected %s, got %s", expected, policy)
+	}
+
+	e = &Endpoint{Name_: "s3", Service_: "s3", PolicyDocument_: `{"Resource": ["${bucket:logs}/*", "${bucket:data}"]}`}
+	if policy, err = e.Policy(); err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"Resource": ["arn:aws:s3:::logs/*", "arn:aws:s3:::data"]}`
+	if policy != expected {
+		t.Errorf("Expected %s, got %s", expected, policy)
+	}
+
+	if policy, err = (&Endpoint{Service_: "s3"}).Policy(); err != nil || policy != "" {
+		t.Errorf("Expected the default policy, got %q, %v", policy, err)
+	}
+	for _, doc := range []string{`{"Resource": "${bucket:logs"}`, `{"Resource": "${bucket:}"}`, `{"Resource": }`} {
+		if _, err := (&Endpoint{PolicyDocument_: doc}).Policy(); err == nil {
+			t.Errorf("Expected policy document %s to be invalid", doc)
+		}
+	}
+}
+
+func TestEndpointValidate(t *testing.T) {
+	valid := Endpoints{
+		{Name_: "s3", Service_: "s3", RouteTables_: []string{"public"}, Buckets_: []string{"logs"}},
+		{Name_: "ec2", Service_: "ec2", SubnetGroup_: "endpoints", SecurityGroup_: "endpoints"},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Error(err)
+	}
+	for _, e := range []*Endpoint{
+		{Service_: "s3"},
+		{Name_: "s3"},
+		{Name_: "s3", Service_: "s3", Type_: "transit"},
+		{Name_: "s3", Service_: "s3", SubnetGroup_: "endpoints"},
+		{Name_: "ec2", Service_: "ec2", SubnetGroup_: "endpoints"},
+		{Name_: "ec2", Service_: "ec2", SubnetGroup_: "endpoints", SecurityGroup_: "endpoints", RouteTables_: []string{"public"}},
+		{Name_: "dynamodb", Service_: "dynamodb", Buckets_: []string{"logs"}},
+		{Name_: "s3", Service_: "s3", Buckets_: []string{"logs"}, PolicyDocument_: "{}"},
+	} {
+		if err := e.Validate(); err == nil {
+			t.Errorf("Expected endpoint %+v to be invalid", *e)
+		}
+	}
+	if err := (Endpoints{valid[0], {Name_: "s3-2", Service_: "s3"}}).Validate(); err == nil {
+		t.Errorf("Expected two endpoints for the same service to be invalid")
+	}
+}
@@ -0,0 +1,92 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// The configuration of a flow log. Flow logs capture the traffic of the
+// network, or of a subnet group's subnets, and deliver it to an amp bucket
+// under an optional prefix. The traffic is either accept, reject or all,
+// defaulting to all. The bucket's policy must allow the delivery of the logs.
+type FlowLog struct {
+	Bucket_  string `json:"bucket"`
+	Prefix_  string `json:"prefix"`
+	Traffic_ string `json:"traffic"`
+}
+
+// Bucket is the name of the amp bucket receiving the flow logs.
+func (f *FlowLog) Bucket() string {
+	return f.Bucket_
+}
+
+// Prefix is the folder of the bucket receiving the flow logs.
+func (f *FlowLog) Prefix() string {
+	return strings.Trim(f.Prefix_, "/")
+}
+
+// Traffic is the traffic being captured, accept, reject or all.
+func (f *FlowLog) Traffic() string {
+	if f.Traffic_ == "" {
+		return "all"
+	}
+	return f.Traffic_
+}
+
+// Destination is the arn of the bucket folder receiving the flow logs.
+func (f *FlowLog) Destination() string {
+	if f.Prefix() == "" {
+		return BucketArn(f.Bucket())
+	}
+	return BucketArn(f.Bucket()) + "/" + f.Prefix() + "/"
+}
+
+// Validate checks the flow log has a bucket and a known traffic type.
+func (f *FlowLog) Validate() error {
+	if f.Bucket() == "" {
+		return fmt.Errorf("Flow log: The bucket is missing")
+	}
+	switch f.Traffic() {
+	case "accept", "reject", "all":
+		return nil
+	}
+	return fmt.Errorf("Flow log: Unknown traffic %q, must be accept, reject or all", f.Traffic())
+}
+
+// Print provides a user friendly way to view a flow log configuration.
+func (f *FlowLog) Print() {
+	msg.Info("FlowLog Config")
+	msg.Detail("%-20s\t%s", "bucket", f.Bucket())
+	if f.Prefix() != "" {
+		msg.Detail("%-20s\t%s", "prefix", f.Prefix())
+	}
+	msg.Detail("%-20s\t%s", "traffic", f.Traffic())
+}
@@ -0,0 +1,50 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import "testing"
+
+func TestFlowLog(t *testing.T) {
+	f := &FlowLog{Bucket_: "logs", Prefix_: "/flows/"}
+	if err := f.Validate(); err != nil {
+		t.Errorf("Flow log: %s", err)
+	}
+	if f.Traffic() != "all" {
+		t.Errorf("Expected traffic to default to all, got %q", f.Traffic())
+	}
+	if d := f.Destination(); d != "arn:aws:s3:::logs/flows/" {
+		t.Errorf("Unexpected destination %q", d)
+	}
+	if d := (&FlowLog{Bucket_: "logs"}).Destination(); d != "arn:aws:s3:::logs" {
+		t.Errorf("Unexpected destination %q", d)
+	}
+	for _, f := range []*FlowLog{{}, {Bucket_: "logs", Traffic_: "dropped"}} {
+		if err := f.Validate(); err == nil {
+			t.Errorf("Expected flow log %+v to be invalid", *f)
+		}
+	}
+}
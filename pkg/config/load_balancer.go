@@ -0,0 +1,344 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// The configuration of the load balancer object. When present the instances
+// of a pod are registered as the targets of the provider's load balancer. It has
+// a type, being application (the default) or network, a scheme, being internal
+// (the default) or internet-facing, the subnet group the load balancer is placed
+// in, defaulting to the pod's, the security groups of an application load balancer,
+// the listeners, the health check of the targets and the number of seconds in
+// flight connections are drained for when an instance is deregistered.
+type LoadBalancer struct {
+	Type_           string       `json:"type"`
+	Scheme_         string       `json:"scheme"`
+	SubnetGroup_    string       `json:"subnet_group"`
+	SecurityGroups_ []string     `json:"security_groups"`
+	Listeners_      []*Listener  `json:"listeners"`
+	HealthCheck_    *HealthCheck `json:"health_check"`
+	Draining_       int          `json:"draining"`
+}
+
+// Type is the type of the load balancer, application or network.
+func (l *LoadBalancer) Type() string {
+	if l.Type_ == "" {
+		return "application"
+	}
+	return l.Type_
+}
+
+// Scheme is internal when the load balancer is only reachable from the
+// network, or internet-facing.
+func (l *LoadBalancer) Scheme() string {
+	if l.Scheme_ == "" {
+		return "internal"
+	}
+	return l.Scheme_
+}
+
+// SubnetGroup is the subnet group the load balancer is placed in. It is empty
+// when the load balancer uses the pod's subnet group.
+func (l *LoadBalancer) SubnetGroup() string {
+	return l.SubnetGroup_
+}
+
+// SecurityGroups are the security groups of an application load balancer.
+func (l *LoadBalancer) SecurityGroups() []string {
+	return l.SecurityGroups_
+}
+
+// Listeners are the ports the load balancer accepts connections on.
+func (l *LoadBalancer) Listeners() []*Listener {
+	return l.Listeners_
+}
+
+// HealthCheck is how the load balancer checks the health of the pod's instances.
+func (l *LoadBalancer) HealthCheck() *HealthCheck {
+	if l.HealthCheck_ == nil {
+		return &HealthCheck{}
+	}
+	return l.HealthCheck_
+}
+
+// Draining is the number of seconds the in flight connections to a deregistered
+// instance are allowed to complete. It defaults to 300.
+func (l *LoadBalancer) Draining() int {
+	if l.Draining_ == 0 {
+		return 300
+	}
+	return l.Draining_
+}
+
+// TargetPorts returns the distinct ports of the pod's instances the listeners
+// forward to, in the order of the listeners.
+func (l *LoadBalancer) TargetPorts() []int {
+	ports, seen := []int{}, map[int]bool{}
+	for _, listener := range l.Listeners() {
+		if !seen[listener.TargetPort()] {
+			seen[listener.TargetPort()] = true
+			ports = append(ports, listener.TargetPort())
+		}
+	}
+	return ports
+}
+
+// Validate checks the load balancer configuration is complete and consistent.
+func (l *LoadBalancer) Validate() error {
+	switch l.Type() {
+	case "application":
+	case "network":
+		if len(l.SecurityGroups()) > 0 {
+			return fmt.Errorf("A network load balancer cannot have security groups")
+		}
+	default:
+		return fmt.Errorf("Unknown load balancer type %q", l.Type())
+	}
+	switch l.Scheme() {
+	case "internal", "internet-facing":
+	default:
+		return fmt.Errorf("Unknown load balancer scheme %q", l.Scheme())
+	}
+	if len(l.Listeners()) == 0 {
+		return fmt.Errorf("A load balancer requires at least one listener")
+	}
+	ports := map[int]bool{}
+	for _, listener := range l.Listeners() {
+		if err := listener.Validate(l.Type()); err != nil {
+			return err
+		}
+		if ports[listener.Port()] {
+			return fmt.Errorf("The load balancer listens on port %d more than once", listener.Port())
+		}
+		ports[listener.Port()] = true
+	}
+	if l.Draining() < 0 || l.Draining() > 3600 {
+		return fmt.Errorf("The load balancer draining must be between 0 and 3600 seconds")
+	}
+	return l.HealthCheck().Validate(l.Type())
+}
+
+// Print provides a user friendly way to view the load balancer configuration.
+func (l *LoadBalancer) Print() {
+	msg.Info("LoadBalancer Config")
+	msg.Detail("%-20s\t%s", "type", l.Type())
+	msg.Detail("%-20s\t%s", "scheme", l.Scheme())
+	if l.SubnetGroup() != "" {
+		msg.Detail("%-20s\t%s", "subnet_group", l.SubnetGroup())
+	}
+	if len(l.SecurityGroups()) > 0 {
+		msg.Detail("%-20s\t%s", "security_groups", strings.Join(l.SecurityGroups(), ", "))
+	}
+	msg.Detail("%-20s\t%d", "draining", l.Draining())
+	msg.IndentInc()
+	for _, listener := range l.Listeners() {
+		listener.Print()
+	}
+	l.HealthCheck().Print()
+	msg.IndentDec()
+}
+
+// The configuration of the listener object. It has the protocol and port the
+// load balancer accepts connections on, the port of the pod's instances the
+// connections are forwarded to, defaulting to the same port, and the arn of
+// the tls certificate of an HTTPS listener.
+type Listener struct {
+	Protocol_    string `json:"protocol"`
+	Port_        int    `json:"port"`
+	TargetPort_  int    `json:"target_port"`
+	Certificate_ string `json:"certificate"`
+}
+
+// Protocol is the protocol of the listener, HTTP or HTTPS for an application
+// load balancer and TCP for a network load balancer.
+func (l *Listener) Protocol() string {
+	return strings.ToUpper(l.Protocol_)
+}
+
+// Port is the port the load balancer listens on.
+func (l *Listener) Port() int {
+	return l.Port_
+}
+
+// TargetPort is the port of the pod's instances the connections are forwarded to.
+func (l *Listener) TargetPort() int {
+	if l.TargetPort_ == 0 {
+		return l.Port()
+	}
+	return l.TargetPort_
+}
+
+// Certificate is the arn of the tls certificate presented by an HTTPS listener.
+func (l *Listener) Certificate() string {
+	return l.Certificate_
+}
+
+// TargetProtocol is the protocol the connections are forwarded to the
+// instances with. TLS is terminated by the load balancer, so HTTPS
+// listeners forward HTTP.
+func (l *Listener) TargetProtocol() string {
+	if l.Protocol() == "HTTPS" {
+		return "HTTP"
+	}
+	return l.Protocol()
+}
+
+// Validate checks the listener is complete and its protocol is supported by the
+// type of load balancer.
+func (l *Listener) Validate(lbType string) error {
+	if l.Port() < 1 || l.Port() > 65535 || l.TargetPort() < 1 || l.TargetPort() > 65535 {
+		return fmt.Errorf("Listener %s %d: The ports must be between 1 and 65535", l.Protocol(), l.Port())
+	}
+	switch {
+	case lbType == "application" && (l.Protocol() == "HTTP" || l.Protocol() == "HTTPS"):
+	case lbType == "network" && l.Protocol() == "TCP":
+	default:
+		return fmt.Errorf("Listener %s %d: The protocol isn't supported by a %s load balancer", l.Protocol(), l.Port(), lbType)
+	}
+	if (l.Protocol() == "HTTPS") != (l.Certificate() != "") {
+		return fmt.Errorf("Listener %s %d: A certificate is required by, and only allowed with, HTTPS", l.Protocol(), l.Port())
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the listener configuration.
+func (l *Listener) Print() {
+	msg.Info("Listener Config")
+	msg.Detail("%-20s\t%s", "protocol", l.Protocol())
+	msg.Detail("%-20s\t%d", "port", l.Port())
+	msg.Detail("%-20s\t%d", "target_port", l.TargetPort())
+	if l.Certificate() != "" {
+		msg.Detail("%-20s\t%s", "certificate", l.Certificate())
+	}
+}
+
+// The configuration of the health check object. It has the protocol and port the
+// targets are checked with, defaulting to the listener's target protocol and port,
+// the path of HTTP checks, defaulting to "/", the number of seconds between
+// checks and the number of consecutive checks for a target to become healthy or
+// unhealthy.
+type HealthCheck struct {
+	Protocol_  string `json:"protocol"`
+	Port_      int    `json:"port"`
+	Path_      string `json:"path"`
+	Interval_  int    `json:"interval"`
+	Healthy_   int    `json:"healthy"`
+	Unhealthy_ int    `json:"unhealthy"`
+}
+
+// Protocol is the protocol of the health check. It is empty when the targets
+// are checked with the protocol they are forwarded.
+func (h *HealthCheck) Protocol() string {
+	return strings.ToUpper(h.Protocol_)
+}
+
+// Port is the port checked. It is zero when the targets are checked on the
+// port they are forwarded to.
+func (h *HealthCheck) Port() int {
+	return h.Port_
+}
+
+// Path is the path requested by HTTP and HTTPS health checks.
+func (h *HealthCheck) Path() string {
+	if h.Path_ == "" {
+		return "/"
+	}
+	return h.Path_
+}
+
+// Interval is the number of seconds between health checks. It defaults to 30.
+func (h *HealthCheck) Interval() int {
+	if h.Interval_ == 0 {
+		return 30
+	}
+	return h.Interval_
+}
+
+// Healthy is the number of consecutive successful checks for an unhealthy
+// target to become healthy. It defaults to 3.
+func (h *HealthCheck) Healthy() int {
+	if h.Healthy_ == 0 {
+		return 3
+	}
+	return h.Healthy_
+}
+
+// Unhealthy is the number of consecutive failed checks for a healthy target
+// to become unhealthy. It defaults to 3.
+func (h *HealthCheck) Unhealthy() int {
+	if h.Unhealthy_ == 0 {
+		return 3
+	}
+	return h.Unhealthy_
+}
+
+// Validate checks the health check is supported by the type of load balancer.
+func (h *HealthCheck) Validate(lbType string) error {
+	switch h.Protocol() {
+	case "", "HTTP", "HTTPS":
+	case "TCP":
+		if lbType != "network" {
+			return fmt.Errorf("The TCP health check is only supported by a network load balancer")
+		}
+	default:
+		return fmt.Errorf("Unknown health check protocol %q", h.Protocol())
+	}
+	if h.Port() < 0 || h.Port() > 65535 {
+		return fmt.Errorf("The health check port must be between 1 and 65535")
+	}
+	if !strings.HasPrefix(h.Path(), "/") {
+		return fmt.Errorf("The health check path %q must start with /", h.Path())
+	}
+	if h.Interval() < 5 || h.Interval() > 300 {
+		return fmt.Errorf("The health check interval must be between 5 and 300 seconds")
+	}
+	if h.Healthy() < 2 || h.Healthy() > 10 || h.Unhealthy() < 2 || h.Unhealthy() > 10 {
+		return fmt.Errorf("The health check thresholds must be between 2 and 10")
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the health check configuration.
+func (h *HealthCheck) Print() {
+	msg.Info("HealthCheck Config")
+	if h.Protocol() != "" {
+		msg.Detail("%-20s\t%s", "protocol", h.Protocol())
+	}
+	if h.Port() != 0 {
+		msg.Detail("%-20s\t%d", "port", h.Port())
+	}
+	msg.Detail("%-20s\t%s", "path", h.Path())
+	msg.Detail("%-20s\t%d", "interval", h.Interval())
+	msg.Detail("%-20s\t%d", "healthy", h.Healthy())
+	msg.Detail("%-20s\t%d", "unhealthy", h.Unhealthy())
+}
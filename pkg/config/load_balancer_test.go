@@ -0,0 +1,90 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import "testing"
+
+func TestLoadBalancerValidate(t *testing.T) {
+	valid := []*LoadBalancer{
+		{Listeners_: []*Listener{{Protocol_: "http", Port_: 80, TargetPort_: 8080}}},
+		{
+			Scheme_: "internet-facing",
+			Listeners_: []*Listener{
+				{Protocol_: "HTTPS", Port_: 443, TargetPort_: 8080, Certificate_: "arn:aws:acm:us-east-1:123456789012:certificate/abc"},
+				{Protocol_: "HTTP", Port_: 80, TargetPort_: 8080},
+			},
+			HealthCheck_: &HealthCheck{Path_: "/health", Interval_: 10},
+		},
+		{Type_: "network", Listeners_: []*Listener{{Protocol_: "TCP", Port_: 5432}}, HealthCheck_: &HealthCheck{Protocol_: "TCP"}},
+	}
+	for _, l := range valid {
+		if err := l.Validate(); err != nil {
+			t.Errorf("Expected load balancer %+v to be valid: %s", *l, err)
+		}
+	}
+
+	invalid := []*LoadBalancer{
+		{Type_: "classic", Listeners_: []*Listener{{Protocol_: "HTTP", Port_: 80}}},
+		{Scheme_: "public", Listeners_: []*Listener{{Protocol_: "HTTP", Port_: 80}}},
+		{},
+		{Listeners_: []*Listener{{Protocol_: "TCP", Port_: 80}}},
+		{Listeners_: []*Listener{{Protocol_: "HTTPS", Port_: 443}}},
+		{Listeners_: []*Listener{{Protocol_: "HTTP", Port_: 80, Certificate_: "arn"}}},
+		{Listeners_: []*Listener{{Protocol_: "HTTP", Port_: 80}, {Protocol_: "HTTP", Port_: 80, TargetPort_: 8080}}},
+		{Listeners_: []*Listener{{Protocol_: "HTTP", Port_: 70000}}},
+		{Type_: "network", SecurityGroups_: []string{"lb"}, Listeners_: []*Listener{{Protocol_: "TCP", Port_: 80}}},
+		{Listeners_: []*Listener{{Protocol_: "HTTP", Port_: 80}}, HealthCheck_: &HealthCheck{Protocol_: "TCP"}},
+		{Listeners_: []*Listener{{Protocol_: "HTTP", Port_: 80}}, HealthCheck_: &HealthCheck{Path_: "health"}},
+		{Listeners_: []*Listener{{Protocol_: "HTTP", Port_: 80}}, HealthCheck_: &HealthCheck{Healthy_: 1}},
+		{Listeners_: []*Listener{{Protocol_: "HTTP", Port_: 80}}, Draining_: 4000},
+	}
+	for _, l := range invalid {
+		if err := l.Validate(); err == nil {
+			t.Errorf("Expected load balancer %+v to be invalid", *l)
+		}
+	}
+}
+
+func TestLoadBalancerTargetPorts(t *testing.T) {
+	l := &LoadBalancer{
+		Listeners_: []*Listener{
+			{Protocol_: "HTTPS", Port_: 443, TargetPort_: 8080, Certificate_: "arn"},
+			{Protocol_: "HTTP", Port_: 80, TargetPort_: 8080},
+			{Protocol_: "HTTP", Port_: 9000},
+		},
+	}
+	ports := l.TargetPorts()
+	if len(ports) != 2 || ports[0] != 8080 || ports[1] != 9000 {
+		t.Errorf("Unexpected target ports %v", ports)
+	}
+	if p := l.Listeners()[0].TargetProtocol(); p != "HTTP" {
+		t.Errorf("Expected an HTTPS listener to forward HTTP, got %s", p)
+	}
+	if l.Draining() != 300 || l.Type() != "application" || l.Scheme() != "internal" {
+		t.Errorf("Unexpected defaults %d, %s, %s", l.Draining(), l.Type(), l.Scheme())
+	}
+}
@@ -0,0 +1,92 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultNaming is the naming template used when neither the pod nor the
+// datacenter compute has one. It names instances "<pod name>-<instance number>".
+const DefaultNaming = "{pod}-{index:02}"
+
+// The private hostname appends "-internal" to the instance name, which must
+// still fit within a 63 character dns label.
+const maxNameLength = 63 - len("-internal")
+
+var validName = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// ExpandNaming expands the placeholders in a naming template to produce the
+// name of the instance with the given index. The placeholders are the keys
+// of values, such as {pod}, {cluster}, {dc} and {az}, and {index}, which may
+// be zero padded to a width, such as {index:02}.
+func ExpandNaming(template string, values map[string]string, index int) (string, error) {
+	name := ""
+	for s := template; s != ""; {
+		open := strings.Index(s, "{")
+		if open < 0 {
+			name += s
+			break
+		}
+		end := strings.Index(s[open:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("Naming template %q has an unterminated placeholder", template)
+		}
+		name += s[:open]
+		placeholder := s[open+1 : open+end]
+		s = s[open+end+1:]
+
+		key, format := placeholder, ""
+		if n := strings.Index(placeholder, ":"); n >= 0 {
+			key, format = placeholder[:n], placeholder[n+1:]
+		}
+		if key == "index" {
+			width := 0
+			if format != "" {
+				w, err := strconv.Atoi(format)
+				if err != nil || w < 0 {
+					return "", fmt.Errorf("Naming template %q has an invalid index width %q", template, format)
+				}
+				width = w
+			}
+			name += fmt.Sprintf("%0*d", width, index)
+			continue
+		}
+		value, ok := values[key]
+		if !ok || format != "" {
+			return "", fmt.Errorf("Naming template %q has an unknown placeholder {%s}", template, placeholder)
+		}
+		name += value
+	}
+	if len(name) > maxNameLength || !validName.MatchString(name) {
+		return "", fmt.Errorf("Naming template %q produces the invalid hostname %q", template, name)
+	}
+	return name, nil
+}
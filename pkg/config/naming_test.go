@@ -0,0 +1,62 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import "testing"
+
+func TestExpandNaming(t *testing.T) {
+	values := map[string]string{
+		"pod": "web",
+		"dc":  "dev",
+		"az":  "us-east-1b",
+	}
+	tests := []struct {
+		template string
+		index    int
+		expected string
+	}{
+		{DefaultNaming, 3, "web-03"},
+		{"{dc}-{pod}{index}", 12, "dev-web12"},
+		{"{pod}-{az}-{index:03}", 1, "web-us-east-1b-001"},
+	}
+	for _, test := range tests {
+		name, err := ExpandNaming(test.template, values, test.index)
+		if err != nil {
+			t.Errorf("Template %q: %s", test.template, err)
+			continue
+		}
+		if name != test.expected {
+			t.Errorf("Template %q: expected %q, got %q", test.template, test.expected, name)
+		}
+	}
+
+	for _, template := range []string{"{pod}-{host}", "{pod}-{index:xx}", "{pod}-{index", "{pod}_{index}", "{pod}-{index}-"} {
+		if name, err := ExpandNaming(template, values, 1); err == nil {
+			t.Errorf("Expected template %q to be invalid, got %q", template, name)
+		}
+	}
+}
@@ -26,24 +26,35 @@
 
 package config
 
-import "github.com/cisco/arc/pkg/msg"
+import (
+	"fmt"
+
+	"github.com/cisco/arc/pkg/msg"
+)
 
 // The configuration of the network object. It has a name, a
-// cidr block, a list of availability zones (one or more), a list of
-// dns name server ip addresses, a subnet groups element and a
-// security groups element.
+// cidr block, an optional flag requesting an ipv6 cidr block, a list of availability zones (one or more), a list of
+// dns name server ip addresses, a subnet groups element, a
+// security groups element, optional peerings, endpoints, network
+// acls and flow log elements and the nat gateways topology.
 //
 // Note that the name is a convenience field and isn't part of the
 // configuration file. It is set by the application at run time.
 type Network struct {
 	Name_              string
 	CidrBlock_         string              `json:"cidr"`
+	Ipv6_              bool                `json:"ipv6"`
 	AvailabilityZones_ []string            `json:"availability_zones"`
 	DnsNameServers_    []string            `json:"dns_name_servers"`
 	CidrAliases_       map[string]string   `json:"cidr_aliases"`
 	CidrGroups_        map[string][]string `json:"cidr_groups"`
 	SubnetGroups       *SubnetGroups       `json:"subnet_groups"`
 	SecurityGroups     *SecurityGroups     `json:"security_groups"`
+	Peerings_          Peerings            `json:"peerings"`
+	Endpoints_         Endpoints           `json:"endpoints"`
+	NetworkAcls_       NetworkAcls         `json:"network_acls"`
+	FlowLog_           *FlowLog            `json:"flow_log"`
+	NatGateways_       string              `json:"nat_gateways"`
 }
 
 // Name satisfies the resource.StaticNetwork interface.
@@ -61,11 +72,26 @@ func (n *Network) CidrBlock() string {
 	return n.CidrBlock_
 }
 
+// Ipv6 satisfies the resource.StaticNetwork interface. When set the network
+// is dual stack and is assigned an amazon provided ipv6 cidr block.
+func (n *Network) Ipv6() bool {
+	return n.Ipv6_
+}
+
 // AvailabilityZones satisfies the resource.StaticNetwork interface.
 func (n *Network) AvailabilityZones() []string {
 	return n.AvailabilityZones_
 }
 
+// SubnetGroupZones returns the availability zones the subnet group has a
+// subnet in, which are those of its provider target when they have been set.
+func (n *Network) SubnetGroupZones(s *SubnetGroup) []string {
+	if len(s.AvailabilityZones()) > 0 {
+		return s.AvailabilityZones()
+	}
+	return n.AvailabilityZones()
+}
+
 // DnsNameServers satisfies the resource.StaticNetwork interface.
 func (n *Network) DnsNameServers() []string {
 	return n.DnsNameServers_
@@ -81,11 +107,56 @@ func (n *Network) CidrGroups() map[string][]string {
 	return n.CidrGroups_
 }
 
+// Peerings satisfies the resource.StaticNetwork interface.
+func (n *Network) Peerings() Peerings {
+	return n.Peerings_
+}
+
+// Endpoints satisfies the resource.StaticNetwork interface.
+func (n *Network) Endpoints() Endpoints {
+	return n.Endpoints_
+}
+
+// NetworkAcls satisfies the resource.StaticNetwork interface.
+func (n *Network) NetworkAcls() NetworkAcls {
+	return n.NetworkAcls_
+}
+
+// NatGateways satisfies the resource.StaticNetwork interface. It is the egress
+// topology of the private subnets. With per_az, the default, each availability
+// zone has its own nat gateway. With single, all availability zones share the
+// nat gateway of the first availability zone. With none there are no nat gateways.
+func (n *Network) NatGateways() string {
+	if n.NatGateways_ == "" {
+		return "per_az"
+	}
+	return n.NatGateways_
+}
+
+// ValidateNatGateways checks the nat gateways topology is known.
+func (n *Network) ValidateNatGateways() error {
+	switch n.NatGateways() {
+	case "per_az", "single", "none":
+		return nil
+	}
+	return fmt.Errorf("Unknown nat_gateways %q, must be per_az, single or none", n.NatGateways())
+}
+
+// FlowLog is the flow log of the whole network. It is nil when the network's
+// traffic isn't logged.
+func (n *Network) FlowLog() *FlowLog {
+	return n.FlowLog_
+}
+
 // PrintLocal provides a user friendly way to view the configuration local to the network object.
 func (n *Network) PrintLocal() {
 	msg.Info("Network Config")
 	msg.Detail("%-20s\t%s", "name", n.Name())
 	msg.Detail("%-20s\t%s", "cidr", n.CidrBlock())
+	if n.Ipv6() {
+		msg.Detail("%-20s\t%t", "ipv6", n.Ipv6())
+	}
+	msg.Detail("%-20s\t%s", "nat gateways", n.NatGateways())
 	a, sep := "", ""
 	for _, az := range n.AvailabilityZones() {
 		a += sep + az
@@ -146,5 +217,17 @@ func (n *Network) Print() {
 	if n.SecurityGroups != nil {
 		n.SecurityGroups.Print()
 	}
+	if len(n.Peerings()) > 0 {
+		n.Peerings().Print()
+	}
+	if len(n.Endpoints()) > 0 {
+		n.Endpoints().Print()
+	}
+	if len(n.NetworkAcls()) > 0 {
+		n.NetworkAcls().Print()
+	}
+	if n.FlowLog() != nil {
+		n.FlowLog().Print()
+	}
 	msg.IndentDec()
 }
@@ -0,0 +1,239 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// NetworkAcls is a collection of NetworkAcl objects.
+type NetworkAcls []*NetworkAcl
+
+// Find returns the named network acl, or nil if it isn't configured.
+func (n NetworkAcls) Find(name string) *NetworkAcl {
+	for _, acl := range n {
+		if acl.Name() == name {
+			return acl
+		}
+	}
+	return nil
+}
+
+// Validate checks the network acls are uniquely named and well formed.
+func (n NetworkAcls) Validate() error {
+	names := map[string]bool{}
+	for _, acl := range n {
+		if err := acl.Validate(); err != nil {
+			return err
+		}
+		if names[acl.Name()] {
+			return fmt.Errorf("Network acl %q is configured more than once", acl.Name())
+		}
+		names[acl.Name()] = true
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the network acls configuration.
+func (n NetworkAcls) Print() {
+	msg.Info("NetworkAcls Config")
+	msg.IndentInc()
+	for _, acl := range n {
+		acl.Print()
+	}
+	msg.IndentDec()
+}
+
+// The configuration of a network acl. It has a name and a list of numbered
+// entries. Subnet groups refer to the network acl by name.
+type NetworkAcl struct {
+	Name_    string             `json:"name"`
+	Entries_ []*NetworkAclEntry `json:"entries"`
+}
+
+// Name of the network acl.
+func (n *NetworkAcl) Name() string {
+	return n.Name_
+}
+
+// Entries are evaluated in the order of their rule numbers, the first matching
+// entry allows or denies the traffic. Traffic that doesn't match an entry is denied.
+func (n *NetworkAcl) Entries() []*NetworkAclEntry {
+	return n.Entries_
+}
+
+// Validate checks the network acl has a name and valid entries.
+func (n *NetworkAcl) Validate() error {
+	if n.Name() == "" {
+		return fmt.Errorf("Network acl is missing a name")
+	}
+	rules := map[string]bool{}
+	for i, e := range n.Entries() {
+		if err := e.Validate(); err != nil {
+			return fmt.Errorf("Network acl %q, entry %d: %s", n.Name(), i+1, err.Error())
+		}
+		for _, d := range e.Directions() {
+			key := fmt.Sprintf("%s:%d", d, e.Rule())
+			if rules[key] {
+				return fmt.Errorf("Network acl %q, entry %d: The %s rule %d is used more than once", n.Name(), i+1, d, e.Rule())
+			}
+			rules[key] = true
+		}
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the network acl configuration.
+func (n *NetworkAcl) Print() {
+	msg.Info("NetworkAcl Config")
+	msg.Detail("%-20s\t%s", "name", n.Name())
+	msg.IndentInc()
+	for _, e := range n.Entries() {
+		e.Print()
+	}
+	msg.IndentDec()
+}
+
+// The configuration of a network acl entry. Like a security rule it has a
+// description, a list of directions, remotes, protocols and ports, together
+// with the action, being allow or deny, and the entry's rule number.
+//
+// The rule number is kept by the entry when other entries are added or
+// removed, so the deployed entries are only replaced when they change.
+// Network acls are stateless, so return traffic has to be allowed explicitly,
+// typically to the ephemeral ports 1024:65535.
+type NetworkAclEntry struct {
+	Description_ string   `json:"description"`
+	Rule_        int64    `json:"rule"`
+	Action_      string   `json:"action"`
+	Directions_  []string `json:"directions"`
+	Remotes_     []string `json:"remotes"`
+	Protocols_   []string `json:"protocols"`
+	Ports_       []string `json:"ports"`
+}
+
+// The description can be free form text.
+func (e *NetworkAclEntry) Description() string {
+	return e.Description_
+}
+
+// Rule is the number of the entry's first rule, between 1 and 32766. An entry
+// expanding to several remotes, protocols or ports takes the following numbers,
+// so entries should leave room between their rule numbers, e.g. 100, 200.
+func (e *NetworkAclEntry) Rule() int64 {
+	return e.Rule_
+}
+
+// Action is either allow or deny.
+func (e *NetworkAclEntry) Action() string {
+	return e.Action_
+}
+
+// Direction values are either ingress or egress.
+func (e *NetworkAclEntry) Directions() []string {
+	return e.Directions_
+}
+
+// Remotes can be either a cidr block, a cidr group or a subnet group.
+// A cidr block takes the form of "cidr:a.b.c.d/e", e.g. cidr:0.0.0.0/0.
+// A cidr group takes the form of "cidr_group:name", e.g. cidr_group:office.
+// A subnet group takes the form of "subnet_group:name", e.g. subnet_group:bastion.
+func (e *NetworkAclEntry) Remotes() []string {
+	return e.Remotes_
+}
+
+// Values can be "icmp", "udp", "tcp" and "all".
+func (e *NetworkAclEntry) Protocols() []string {
+	return e.Protocols_
+}
+
+// For tcp and udp protocols values can either be a scalar port number, e.g. 22,
+// or a range of ports, e.g. 1024:65535. For icmp values represent the ICMP type
+// and code, e.g. 8:0. The all protocol doesn't use ports.
+func (e *NetworkAclEntry) Ports() []string {
+	return e.Ports_
+}
+
+// Validate checks the entry's rule number, action, directions, remotes and protocols.
+func (e *NetworkAclEntry) Validate() error {
+	if e.Rule() < 1 || e.Rule() > 32766 {
+		return fmt.Errorf("Rule %d is out of range, expecting 1 to 32766", e.Rule())
+	}
+	switch e.Action() {
+	case "allow", "deny":
+	default:
+		return fmt.Errorf("Unknown action %q, expecting allow or deny", e.Action())
+	}
+	if len(e.Directions()) == 0 || len(e.Remotes()) == 0 || len(e.Protocols()) == 0 {
+		return fmt.Errorf("Directions, remotes and protocols are required")
+	}
+	for _, d := range e.Directions() {
+		if d != "ingress" && d != "egress" {
+			return fmt.Errorf("Unknown direction %q, expecting ingress or egress", d)
+		}
+	}
+	for _, r := range e.Remotes() {
+		s := strings.SplitN(r, ":", 2)
+		if len(s) != 2 || s[1] == "" {
+			return fmt.Errorf("Malformed remote %q", r)
+		}
+		switch s[0] {
+		case "cidr", "cidr_group", "subnet_group":
+		default:
+			return fmt.Errorf("Unsupported remote %q, expecting cidr, cidr_group or subnet_group", r)
+		}
+	}
+	for _, p := range e.Protocols() {
+		switch p {
+		case "tcp", "udp", "icmp":
+			if len(e.Ports()) == 0 {
+				return fmt.Errorf("The %s protocol requires ports", p)
+			}
+		case "all":
+		default:
+			return fmt.Errorf("Unknown protocol %q", p)
+		}
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the entry configuration.
+func (e *NetworkAclEntry) Print() {
+	msg.Info("NetworkAclEntry Config")
+	msg.Detail("%-20s\t%s", "description", e.Description())
+	msg.Detail("%-20s\t%d", "rule", e.Rule())
+	msg.Detail("%-20s\t%s", "action", e.Action())
+	msg.Detail("%-20s\t%s", "directions", strings.Join(e.Directions(), ", "))
+	msg.Detail("%-20s\t%s", "remotes", strings.Join(e.Remotes(), ", "))
+	msg.Detail("%-20s\t%s", "protocols", strings.Join(e.Protocols(), ", "))
+	if len(e.Ports()) > 0 {
+		msg.Detail("%-20s\t%s", "ports", strings.Join(e.Ports(), ", "))
+	}
+}
@@ -0,0 +1,64 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import "testing"
+
+func TestNetworkAclValidate(t *testing.T) {
+	entry := &NetworkAclEntry{
+		Rule_:       100,
+		Action_:     "allow",
+		Directions_: []string{"ingress"},
+		Remotes_:    []string{"cidr_group:office"},
+		Protocols_:  []string{"tcp"},
+		Ports_:      []string{"443"},
+	}
+	valid := &NetworkAcl{Name_: "public", Entries_: []*NetworkAclEntry{entry}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Network acl %s: %s", valid.Name(), err)
+	}
+	for _, e := range []*NetworkAclEntry{
+		{Action_: "allow", Directions_: []string{"ingress"}, Remotes_: []string{"cidr:0.0.0.0/0"}, Protocols_: []string{"all"}},
+		{Rule_: 32767, Action_: "allow", Directions_: []string{"ingress"}, Remotes_: []string{"cidr:0.0.0.0/0"}, Protocols_: []string{"all"}},
+		{Rule_: 100, Action_: "permit", Directions_: []string{"ingress"}, Remotes_: []string{"cidr:0.0.0.0/0"}, Protocols_: []string{"all"}},
+		{Rule_: 100, Action_: "deny", Directions_: []string{"inbound"}, Remotes_: []string{"cidr:0.0.0.0/0"}, Protocols_: []string{"all"}},
+		{Rule_: 100, Action_: "deny", Directions_: []string{"ingress"}, Remotes_: []string{"security_group:web"}, Protocols_: []string{"all"}},
+		{Rule_: 100, Action_: "deny", Directions_: []string{"ingress"}, Remotes_: []string{"cidr:0.0.0.0/0"}, Protocols_: []string{"udp"}},
+		{Rule_: 100, Action_: "deny", Directions_: []string{"ingress"}, Remotes_: []string{"cidr:0.0.0.0/0"}, Protocols_: []string{"gre"}},
+	} {
+		if err := e.Validate(); err == nil {
+			t.Errorf("Expected network acl entry %+v to be invalid", *e)
+		}
+	}
+	if err := (NetworkAcls{valid, valid}).Validate(); err == nil {
+		t.Errorf("Expected duplicate network acls to be invalid")
+	}
+	reused := &NetworkAcl{Name_: "reused", Entries_: []*NetworkAclEntry{entry, entry}}
+	if err := reused.Validate(); err == nil {
+		t.Errorf("Expected network acl %s reusing a rule number to be invalid", reused.Name())
+	}
+}
@@ -0,0 +1,180 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// Peerings is a collection of Peering objects.
+type Peerings []*Peering
+
+// Find returns the named peering, or nil if it isn't configured.
+func (p Peerings) Find(name string) *Peering {
+	for _, peering := range p {
+		if peering.Name() == name {
+			return peering
+		}
+	}
+	return nil
+}
+
+// Validate checks the peerings are uniquely named and well formed.
+func (p Peerings) Validate() error {
+	names := map[string]bool{}
+	for _, peering := range p {
+		if err := peering.Validate(); err != nil {
+			return err
+		}
+		if names[peering.Name()] {
+			return fmt.Errorf("Peering %q is configured more than once", peering.Name())
+		}
+		names[peering.Name()] = true
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the peerings configuration.
+func (p Peerings) Print() {
+	msg.Info("Peerings Config")
+	msg.IndentInc()
+	for _, peering := range p {
+		peering.Print()
+	}
+	msg.IndentDec()
+}
+
+// The configuration of a peering connection between the datacenter's vpc and
+// a peer vpc. It has a name, the id of the peer vpc, the account and region
+// the peer vpc lives in and the cidr blocks that are routed to the peer.
+//
+// The route tables element limits the routes to the named route tables,
+// otherwise routes to the peer are added to all of the network's route tables.
+// The accept_with element names the provider target used to accept the peering
+// request in the peer account. Peering requests within the datacenter's account
+// are accepted by the datacenter's provider, in the peer's region.
+type Peering struct {
+	Name_        string   `json:"name"`
+	VpcId_       string   `json:"vpc_id"`
+	Account_     string   `json:"account"`
+	Region_      string   `json:"region"`
+	Cidrs_       []string `json:"cidrs"`
+	RouteTables_ []string `json:"route_tables"`
+	AcceptWith_  string   `json:"accept_with"`
+}
+
+// Name of the peering.
+func (p *Peering) Name() string {
+	return p.Name_
+}
+
+// VpcId is the id of the peer vpc.
+func (p *Peering) VpcId() string {
+	return p.VpcId_
+}
+
+// Account is the number of the account that owns the peer vpc. When empty
+// the peer vpc is in the datacenter's account.
+func (p *Peering) Account() string {
+	return p.Account_
+}
+
+// Region of the peer vpc. When empty the peer vpc is in the datacenter's region.
+func (p *Peering) Region() string {
+	return p.Region_
+}
+
+// Cidrs are the cidr blocks of the peer vpc routed over the peering.
+func (p *Peering) Cidrs() []string {
+	return p.Cidrs_
+}
+
+// RouteTables are the names of the route tables that route to the peer.
+func (p *Peering) RouteTables() []string {
+	return p.RouteTables_
+}
+
+// AcceptWith is the provider target used to accept the peering request.
+func (p *Peering) AcceptWith() string {
+	return p.AcceptWith_
+}
+
+// RoutesTo returns true if the named route table has routes to the peer.
+func (p *Peering) RoutesTo(routeTable string) bool {
+	if len(p.RouteTables()) == 0 {
+		return true
+	}
+	for _, r := range p.RouteTables() {
+		if r == routeTable {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the peering has a name, a peer vpc and valid cidr blocks.
+func (p *Peering) Validate() error {
+	if p.Name() == "" {
+		return fmt.Errorf("Peering is missing a name")
+	}
+	if p.VpcId() == "" {
+		return fmt.Errorf("Peering %q is missing the vpc_id of the peer vpc", p.Name())
+	}
+	if len(p.Cidrs()) == 0 {
+		return fmt.Errorf("Peering %q requires at least one cidr", p.Name())
+	}
+	for _, cidr := range p.Cidrs() {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("Peering %q has an invalid cidr %q", p.Name(), cidr)
+		}
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the peering configuration.
+func (p *Peering) Print() {
+	msg.Info("Peering Config")
+	msg.Detail("%-20s\t%s", "name", p.Name())
+	msg.Detail("%-20s\t%s", "vpc id", p.VpcId())
+	if p.Account() != "" {
+		msg.Detail("%-20s\t%s", "account", p.Account())
+	}
+	if p.Region() != "" {
+		msg.Detail("%-20s\t%s", "region", p.Region())
+	}
+	msg.Detail("%-20s\t%s", "cidrs", strings.Join(p.Cidrs(), ", "))
+	if len(p.RouteTables()) > 0 {
+		msg.Detail("%-20s\t%s", "route tables", strings.Join(p.RouteTables(), ", "))
+	}
+	if p.AcceptWith() != "" {
+		msg.Detail("%-20s\t%s", "accept with", p.AcceptWith())
+	}
+}
@@ -0,0 +1,60 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import "testing"
+
+func TestPeeringValidate(t *testing.T) {
+	valid := &Peering{Name_: "shared", VpcId_: "vpc-1234", Cidrs_: []string{"10.1.0.0/16"}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Peering %s: %s", valid.Name(), err)
+	}
+	for _, p := range []*Peering{
+		{VpcId_: "vpc-1234", Cidrs_: []string{"10.1.0.0/16"}},
+		{Name_: "shared", Cidrs_: []string{"10.1.0.0/16"}},
+		{Name_: "shared", VpcId_: "vpc-1234"},
+		{Name_: "shared", VpcId_: "vpc-1234", Cidrs_: []string{"10.1.0.0"}},
+	} {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Expected peering %+v to be invalid", *p)
+		}
+	}
+	if err := (Peerings{valid, valid}).Validate(); err == nil {
+		t.Errorf("Expected duplicate peerings to be invalid")
+	}
+}
+
+func TestPeeringRoutesTo(t *testing.T) {
+	all := &Peering{Name_: "shared"}
+	if !all.RoutesTo("public") || !all.RoutesTo("private-us-east-1a") {
+		t.Errorf("Expected a peering without route tables to route from every route table")
+	}
+	private := &Peering{Name_: "shared", RouteTables_: []string{"private-us-east-1a"}}
+	if private.RoutesTo("public") || !private.RoutesTo("private-us-east-1a") {
+		t.Errorf("Expected the peering to route from private-us-east-1a only")
+	}
+}
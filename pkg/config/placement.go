@@ -0,0 +1,218 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// The configuration of the placement object. It has a policy, being one of
+// spread, zone or group, the availability zone the instances are pinned to,
+// and the name and strategy, being one of cluster, spread or partition, of
+// the placement group the instances are launched into.
+type Placement struct {
+	Policy_   string `json:"policy"`
+	Zone_     string `json:"zone"`
+	Group_    string `json:"group"`
+	Strategy_ string `json:"strategy"`
+}
+
+// Policy returns how the instances are placed. It defaults to spread, where
+// the instances are spread evenly across the availability zones.
+func (p *Placement) Policy() string {
+	if p == nil || p.Policy_ == "" {
+		return "spread"
+	}
+	return p.Policy_
+}
+
+// Zone is the availability zone the instances are pinned to.
+func (p *Placement) Zone() string {
+	if p == nil {
+		return ""
+	}
+	return p.Zone_
+}
+
+// Group is the name of the placement group the instances are launched into.
+func (p *Placement) Group() string {
+	if p == nil || p.Policy() != "group" {
+		return ""
+	}
+	return p.Group_
+}
+
+// Strategy is the strategy of the placement group.
+func (p *Placement) Strategy() string {
+	if p == nil || p.Policy() != "group" {
+		return ""
+	}
+	return p.Strategy_
+}
+
+// Validate checks the placement policy is complete.
+func (p *Placement) Validate() error {
+	switch p.Policy() {
+	case "spread":
+		if p.Zone() != "" {
+			return fmt.Errorf("The spread placement policy cannot be pinned to a zone")
+		}
+	case "zone":
+		if p.Zone() == "" {
+			return fmt.Errorf("The zone placement policy requires a zone")
+		}
+	case "group":
+		if p.Group() == "" {
+			return fmt.Errorf("The group placement policy requires a group")
+		}
+		switch p.Strategy() {
+		case "cluster", "spread", "partition":
+		default:
+			return fmt.Errorf("Unknown placement group strategy %q", p.Strategy())
+		}
+	default:
+		return fmt.Errorf("Unknown placement policy %q", p.Policy())
+	}
+	return nil
+}
+
+// pinned returns the zone all instances are placed in, or the empty string
+// if they are spread. Cluster placement groups cannot span zones, so they
+// default to the first available zone.
+func (p *Placement) pinned(available []string) string {
+	if p.Zone() != "" {
+		return p.Zone()
+	}
+	if p.Strategy() == "cluster" && len(available) > 0 {
+		return available[0]
+	}
+	return ""
+}
+
+// Zones returns the availability zone of each of count instances, chosen
+// from the available zones.
+func (p *Placement) Zones(available []string, count int) ([]string, error) {
+	if len(available) == 0 {
+		return nil, fmt.Errorf("No availability zones available for placement")
+	}
+	zone := p.pinned(available)
+	if zone != "" {
+		found := false
+		for _, az := range available {
+			if az == zone {
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Placement zone %q is not one of the availability zones %s", zone, strings.Join(available, ", "))
+		}
+	}
+	zones := make([]string, count)
+	for i := range zones {
+		if zone != "" {
+			zones[i] = zone
+		} else {
+			// Chosen via round robin, always starting at 0.
+			zones[i] = available[i%len(available)]
+		}
+	}
+	return zones, nil
+}
+
+// Check returns an error if the zones the instances are deployed in violate
+// the placement policy.
+func (p *Placement) Check(available []string, deployed []string) error {
+	if zone := p.pinned(available); zone != "" {
+		for _, az := range deployed {
+			if az != zone {
+				return fmt.Errorf("instance in %s, pinned to %s", az, zone)
+			}
+		}
+		return nil
+	}
+	counts := Distribution(deployed)
+	min, max := -1, 0
+	for _, az := range available {
+		n := counts[az]
+		if min < 0 || n < min {
+			min = n
+		}
+		if n > max {
+			max = n
+		}
+		delete(counts, az)
+	}
+	for az := range counts {
+		return fmt.Errorf("instance in %s, not one of the availability zones", az)
+	}
+	if max-min > 1 {
+		return fmt.Errorf("uneven spread %s", FormatDistribution(Distribution(deployed)))
+	}
+	return nil
+}
+
+// Distribution returns the number of instances in each availability zone.
+func Distribution(zones []string) map[string]int {
+	counts := map[string]int{}
+	for _, az := range zones {
+		counts[az]++
+	}
+	return counts
+}
+
+// FormatDistribution formats the number of instances in each availability
+// zone, ordered by zone.
+func FormatDistribution(counts map[string]int) string {
+	zones := []string{}
+	for az := range counts {
+		zones = append(zones, az)
+	}
+	sort.Strings(zones)
+	s, sep := "", ""
+	for _, az := range zones {
+		s += fmt.Sprintf("%s%s: %d", sep, az, counts[az])
+		sep = ", "
+	}
+	return s
+}
+
+// Print provides a user friendly way to view the placement configuration.
+func (p *Placement) Print() {
+	msg.Info("Placement Config")
+	msg.Detail("%-20s\t%s", "policy", p.Policy())
+	if p.Zone() != "" {
+		msg.Detail("%-20s\t%s", "zone", p.Zone())
+	}
+	if p.Group() != "" {
+		msg.Detail("%-20s\t%s", "group", p.Group())
+		msg.Detail("%-20s\t%s", "strategy", p.Strategy())
+	}
+}
@@ -0,0 +1,98 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+var azs = []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+
+func TestPlacementZones(t *testing.T) {
+	tests := []struct {
+		placement *Placement
+		expected  string
+	}{
+		{nil, "us-east-1a us-east-1b us-east-1c us-east-1a"},
+		{&Placement{Policy_: "zone", Zone_: "us-east-1b"}, "us-east-1b us-east-1b us-east-1b us-east-1b"},
+		{&Placement{Policy_: "group", Group_: "db", Strategy_: "cluster"}, "us-east-1a us-east-1a us-east-1a us-east-1a"},
+		{&Placement{Policy_: "group", Group_: "db", Strategy_: "spread"}, "us-east-1a us-east-1b us-east-1c us-east-1a"},
+		{&Placement{Policy_: "group", Group_: "db", Strategy_: "partition", Zone_: "us-east-1c"}, "us-east-1c us-east-1c us-east-1c us-east-1c"},
+	}
+	for _, test := range tests {
+		if err := test.placement.Validate(); err != nil {
+			t.Errorf("Placement %s: %s", test.placement.Policy(), err)
+		}
+		zones, err := test.placement.Zones(azs, 4)
+		if err != nil {
+			t.Errorf("Placement %s: %s", test.placement.Policy(), err)
+			continue
+		}
+		if s := strings.Join(zones, " "); s != test.expected {
+			t.Errorf("Placement %s: expected %q, got %q", test.placement.Policy(), test.expected, s)
+		}
+	}
+
+	p := &Placement{Policy_: "zone", Zone_: "us-west-2a"}
+	if _, err := p.Zones(azs, 1); err == nil {
+		t.Errorf("Expected an error for a zone that isn't available")
+	}
+	for _, p := range []*Placement{{Policy_: "zone"}, {Policy_: "group", Group_: "db"}, {Policy_: "pack"}} {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Expected placement %+v to be invalid", *p)
+		}
+	}
+}
+
+func TestPlacementCheck(t *testing.T) {
+	var spread *Placement
+	if err := spread.Check(azs, []string{"us-east-1a", "us-east-1b"}); err != nil {
+		t.Errorf("Expected a spread of 1, 1, 0 to be even: %s", err)
+	}
+	if err := spread.Check(azs, []string{"us-east-1a", "us-east-1a", "us-east-1b"}); err == nil {
+		t.Errorf("Expected a spread of 2, 1, 0 to be uneven")
+	}
+	if err := spread.Check(azs, []string{"us-east-1a", "us-east-1d"}); err == nil {
+		t.Errorf("Expected an unknown zone to be reported")
+	}
+
+	pinned := &Placement{Policy_: "zone", Zone_: "us-east-1b"}
+	if err := pinned.Check(azs, []string{"us-east-1b", "us-east-1b"}); err != nil {
+		t.Errorf("Expected pinned instances to pass: %s", err)
+	}
+	if err := pinned.Check(azs, []string{"us-east-1b", "us-east-1c"}); err == nil {
+		t.Errorf("Expected an instance outside the pinned zone to be reported")
+	}
+}
+
+func TestFormatDistribution(t *testing.T) {
+	s := FormatDistribution(Distribution([]string{"us-east-1b", "us-east-1a", "us-east-1b"}))
+	if s != "us-east-1a: 1, us-east-1b: 2" {
+		t.Errorf("Unexpected distribution %q", s)
+	}
+}
@@ -50,19 +50,29 @@ func (p *Pods) Print() {
 // The configuration of the pod object. It contains a name, a servertype,
 // the version of the servertype, the base image, the machine type, the associated
 // subnet group, the associated security groups, the count being the number of instances
-// created, and the list of volume templates to use for each instance.
+// created, the list of volume templates to use for each instance, how the
+// instances are purchased, the optional autoscaling group managing them and the
+// optional load balancer the instances are registered with.
 type Pod struct {
-	Name_           string   `json:"pod"`
-	ServerType_     string   `json:"servertype"`
-	Version_        int      `json:"version"`
-	Image_          string   `json:"image"`
-	InstanceType_   string   `json:"type"`
-	Role_           string   `json:"role"`
-	SubnetGroup_    string   `json:"subnet_group"`
-	SecurityGroups_ []string `json:"security_groups"`
-	Count_          int      `json:"count"`
-	Teams_          []string `json:"teams"`
-	Volumes         *Volumes `json:"volumes"`
+	Name_           string        `json:"pod"`
+	ServerType_     string        `json:"servertype"`
+	Version_        int           `json:"version"`
+	Image_          string        `json:"image"`
+	InstanceType_   string        `json:"type"`
+	Role_           string        `json:"role"`
+	SubnetGroup_    string        `json:"subnet_group"`
+	SecurityGroups_ []string      `json:"security_groups"`
+	Count_          int           `json:"count"`
+	Teams_          []string      `json:"teams"`
+	Volumes         *Volumes      `json:"volumes"`
+	Purchasing_     *Purchasing   `json:"purchasing"`
+	AutoScaling_    *AutoScaling  `json:"autoscaling"`
+	Bootstrap_      string        `json:"bootstrap"`
+	Baked_          bool          `json:"baked"`
+	Retention_      *Retention    `json:"retention"`
+	Placement_      *Placement    `json:"placement"`
+	Naming_         string        `json:"naming"`
+	LoadBalancer_   *LoadBalancer `json:"load_balancer"`
 	Instances       *Instances
 }
 
@@ -105,6 +115,18 @@ func (p *Pod) Image() string {
 	return p.Image_
 }
 
+// Baked returns true if the instances in the pod are launched from the image
+// baked for the pod, rather than the base OS image.
+func (p *Pod) Baked() bool {
+	return p.Baked_
+}
+
+// BakedImage returns the name of the image baked for the pod's base OS image,
+// servertype and version. This is the name used in the provider's images.
+func (p *Pod) BakedImage() string {
+	return fmt.Sprintf("%s-%s-%s", p.Image(), p.ServerType(), p.Version())
+}
+
 // InstanceType satisfies the resource.StaticPod interface. The instance type specifies
 // the cloud providers machine type which defines the virtual cpus, memory and disk available
 // to the instance. For example, in AWS m4.large is an instance type.
@@ -112,6 +134,21 @@ func (p *Pod) InstanceType() string {
 	return p.InstanceType_
 }
 
+// InstanceTypes returns the pod's instance type followed by its fallback
+// instance types, in the order they should be tried.
+func (p *Pod) InstanceTypes() []string {
+	return append([]string{p.InstanceType()}, p.Purchasing().FallbackTypes()...)
+}
+
+// Purchasing returns how the instances in the pod are purchased. A pod without
+// a purchasing element uses on-demand instances.
+func (p *Pod) Purchasing() *Purchasing {
+	if p.Purchasing_ == nil {
+		return &Purchasing{}
+	}
+	return p.Purchasing_
+}
+
 // Role satisfies the resource.StaticPod interface. The role is optional and allows
 // instances in this pod to acquire an IAM role in order to interact with AWS
 // programmatically.
@@ -132,10 +169,64 @@ func (p *Pod) SecurityGroups() []string {
 }
 
 // Count satisfies the resource.StaticPod interface. The pod will have count number of instances.
+// A pod managed by an autoscaling group has as many instances as the group's maximum.
 func (p *Pod) Count() int {
+	if p.AutoScaling_ != nil {
+		return p.AutoScaling_.Max()
+	}
 	return p.Count_
 }
 
+// AutoScaling returns the autoscaling configuration of the pod. It is nil
+// unless the pod's instances are managed by an autoscaling group.
+func (p *Pod) AutoScaling() *AutoScaling {
+	return p.AutoScaling_
+}
+
+// Bootstrap returns how the instances in the pod are set up on first boot,
+// either "ssh" (the default) where arc runs the setup scripts over ssh once
+// the instance is reachable, or "cloud-init" where the setup scripts are
+// passed to the instance as user-data when it is launched.
+func (p *Pod) Bootstrap() string {
+	if p.Bootstrap_ == "" {
+		return "ssh"
+	}
+	return p.Bootstrap_
+}
+
+// CloudInit returns true if the instances in the pod are set up by cloud-init.
+func (p *Pod) CloudInit() bool {
+	return p.Bootstrap() == "cloud-init"
+}
+
+// Retention returns the policy used when pruning the volume snapshots of the
+// pod's instances. It is nil when the pod doesn't have one.
+func (p *Pod) Retention() *Retention {
+	return p.Retention_
+}
+
+// Placement returns how the instances in the pod are placed across the
+// availability zones. A pod without a placement element is spread evenly.
+func (p *Pod) Placement() *Placement {
+	if p.Placement_ == nil {
+		return &Placement{}
+	}
+	return p.Placement_
+}
+
+// Naming returns the template the names of the pod's instances are expanded
+// from. It is empty when the pod uses the datacenter compute's template.
+func (p *Pod) Naming() string {
+	return p.Naming_
+}
+
+// LoadBalancer returns the load balancer configuration of the pod. It is nil
+// unless the pod's instances are registered with a load balancer. The
+// autoscaling group of a pod registers the instances it launches.
+func (p *Pod) LoadBalancer() *LoadBalancer {
+	return p.LoadBalancer_
+}
+
 // Teams satisfies the resource.StaticPod interface. The pod will have the users in the given teams setup.
 func (p *Pod) Teams() []string {
 	return p.Teams_
@@ -168,7 +259,29 @@ func (p *Pod) Print() {
 	}
 	msg.Detail("%-20s\t%s", "teams", teams)
 	msg.Detail("%-20s\t%d", "count", p.Count())
+	msg.Detail("%-20s\t%s", "bootstrap", p.Bootstrap())
+	if p.Naming() != "" {
+		msg.Detail("%-20s\t%s", "naming", p.Naming())
+	}
+	if p.Baked() {
+		msg.Detail("%-20s\t%s", "baked", p.BakedImage())
+	}
 	msg.IndentInc()
+	if p.Purchasing_ != nil {
+		p.Purchasing_.Print()
+	}
+	if p.AutoScaling_ != nil {
+		p.AutoScaling_.Print()
+	}
+	if p.Retention_ != nil {
+		p.Retention_.Print()
+	}
+	if p.Placement_ != nil {
+		p.Placement_.Print()
+	}
+	if p.LoadBalancer_ != nil {
+		p.LoadBalancer_.Print()
+	}
 	if p.Volumes != nil {
 		p.Volumes.Print()
 	}
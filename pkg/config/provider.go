@@ -26,16 +26,59 @@
 
 package config
 
-import "github.com/cisco/arc/pkg/msg"
+import (
+	"fmt"
+
+	"github.com/cisco/arc/pkg/msg"
+)
 
 // The configuration of the provider object. It has a vendor, a key value map of
-// provider data, and an optional map of os images made available by the provider.
+// provider data, an optional map of os images made available by the provider,
+// and an optional map of named targets.
 type Provider struct {
-	Vendor string            `json:"vendor"`
+	Vendor  string                     `json:"vendor"`
+	Data    map[string]string          `json:"data"`
+	Images  map[string]string          `json:"images"`
+	Targets map[string]*ProviderTarget `json:"targets"`
+}
+
+// ProviderTarget is an additional account, region or role that resources can be
+// pinned to. Its data and images are layered over those of the provider.
+type ProviderTarget struct {
 	Data   map[string]string `json:"data"`
 	Images map[string]string `json:"images"`
 }
 
+// Target returns the provider configuration for the named target. An empty
+// name refers to the provider itself.
+func (p *Provider) Target(name string) (*Provider, error) {
+	if name == "" {
+		return p, nil
+	}
+	t := p.Targets[name]
+	if t == nil {
+		return nil, fmt.Errorf("Unknown provider target %q", name)
+	}
+	target := &Provider{
+		Vendor: p.Vendor,
+		Data:   map[string]string{},
+		Images: map[string]string{},
+	}
+	for k, v := range p.Data {
+		target.Data[k] = v
+	}
+	for k, v := range t.Data {
+		target.Data[k] = v
+	}
+	for k, v := range p.Images {
+		target.Images[k] = v
+	}
+	for k, v := range t.Images {
+		target.Images[k] = v
+	}
+	return target, nil
+}
+
 // Print provides a user friendly way to view the configuration of the provider object.
 func (p *Provider) Print() {
 	msg.Info("Provider Config")
@@ -57,5 +100,14 @@ func (p *Provider) Print() {
 		}
 		msg.Detail("%-20s\t%s", k, v)
 	}
+	for k, t := range p.Targets {
+		msg.Info("Vendor Target")
+		msg.Detail("%-20s\t%s", "name", k)
+		msg.IndentInc()
+		for k, v := range t.Data {
+			msg.Detail("%-20s\t%s", k, v)
+		}
+		msg.IndentDec()
+	}
 	msg.IndentDec()
 }
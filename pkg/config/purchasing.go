@@ -0,0 +1,125 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// The configuration of the purchasing object. It has a strategy, being one of
+// on-demand, spot or reserved, an optional maximum spot price, the id of the
+// capacity reservation reserved instances are launched into, and a list of
+// instance types to fall back to when there isn't capacity for the pod's
+// instance type.
+type Purchasing struct {
+	Strategy_      string   `json:"strategy"`
+	MaxPrice_      string   `json:"max_price"`
+	Reservation_   string   `json:"reservation"`
+	FallbackTypes_ []string `json:"fallback_types"`
+}
+
+// Strategy returns how instances are purchased. It defaults to on-demand.
+func (p *Purchasing) Strategy() string {
+	if p == nil || p.Strategy_ == "" {
+		return "on-demand"
+	}
+	return p.Strategy_
+}
+
+// Spot returns true if instances are purchased from spot capacity.
+func (p *Purchasing) Spot() bool {
+	return p.Strategy() == "spot"
+}
+
+// Reserved returns true if instances are launched into a capacity reservation.
+func (p *Purchasing) Reserved() bool {
+	return p.Strategy() == "reserved"
+}
+
+// MaxPrice is the maximum hourly price paid for spot instances. When empty
+// the on-demand price is the maximum.
+func (p *Purchasing) MaxPrice() string {
+	if p == nil {
+		return ""
+	}
+	return p.MaxPrice_
+}
+
+// Reservation is the id of the capacity reservation reserved instances are
+// launched into.
+func (p *Purchasing) Reservation() string {
+	if p == nil {
+		return ""
+	}
+	return p.Reservation_
+}
+
+// FallbackTypes are the instance types tried, in order, when the pod's
+// instance type isn't available.
+func (p *Purchasing) FallbackTypes() []string {
+	if p == nil {
+		return nil
+	}
+	return p.FallbackTypes_
+}
+
+// Validate checks the purchasing configuration is consistent.
+func (p *Purchasing) Validate() error {
+	switch p.Strategy() {
+	case "on-demand":
+	case "spot":
+	case "reserved":
+		if p.Reservation() == "" {
+			return fmt.Errorf("The reserved purchasing strategy requires a reservation")
+		}
+		// A capacity reservation is for a single instance type.
+		if len(p.FallbackTypes()) > 0 {
+			return fmt.Errorf("The reserved purchasing strategy can't have fallback types")
+		}
+	default:
+		return fmt.Errorf("Unknown purchasing strategy %q", p.Strategy())
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the purchasing configuration.
+func (p *Purchasing) Print() {
+	msg.Info("Purchasing Config")
+	msg.Detail("%-20s\t%s", "strategy", p.Strategy())
+	if p.MaxPrice() != "" {
+		msg.Detail("%-20s\t%s", "max_price", p.MaxPrice())
+	}
+	if p.Reservation() != "" {
+		msg.Detail("%-20s\t%s", "reservation", p.Reservation())
+	}
+	if len(p.FallbackTypes()) > 0 {
+		msg.Detail("%-20s\t%s", "fallback_types", strings.Join(p.FallbackTypes(), ", "))
+	}
+}
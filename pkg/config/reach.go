@@ -0,0 +1,374 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cisco/arc/pkg/net"
+)
+
+// ReachEndpoint is one side of a flow evaluated by Reach. It has the
+// security groups the endpoint belongs to and the cidr blocks its addresses
+// are taken from.
+type ReachEndpoint struct {
+	Name           string
+	SecurityGroups []string
+	Cidrs          []string
+}
+
+// ReachMatch is a security rule that allows the flow. A partial match
+// allows only some of the endpoint's addresses, e.g. a cidr remote covering
+// one of the subnets of a subnet group.
+type ReachMatch struct {
+	SecurityGroup string
+	Rule          *SecurityRule
+	Remote        string
+	Partial       bool
+}
+
+// Reachability is the evaluation of a flow against the egress rules of the
+// source's security groups and the ingress rules of the destination's
+// security groups. A side without security groups, such as a cidr outside
+// of the network, isn't checked.
+type Reachability struct {
+	Source         *ReachEndpoint
+	Destination    *ReachEndpoint
+	Protocol       string
+	Port           int
+	EgressChecked  bool
+	IngressChecked bool
+	Egress         []*ReachMatch
+	Ingress        []*ReachMatch
+}
+
+// EgressAllowed returns true if a rule allows the flow out of the source.
+func (r *Reachability) EgressAllowed() bool {
+	return !r.EgressChecked || fullMatch(r.Egress)
+}
+
+// IngressAllowed returns true if a rule allows the flow into the destination.
+func (r *Reachability) IngressAllowed() bool {
+	return !r.IngressChecked || fullMatch(r.Ingress)
+}
+
+// Reachable returns true if the flow is allowed in both directions.
+func (r *Reachability) Reachable() bool {
+	return r.EgressAllowed() && r.IngressAllowed()
+}
+
+func fullMatch(matches []*ReachMatch) bool {
+	for _, m := range matches {
+		if !m.Partial {
+			return true
+		}
+	}
+	return false
+}
+
+// ReachEndpoint resolves an endpoint of the form "kind:name" using the
+// configuration. A pod is either "pod:name" or "pod:cluster/name", a database
+// is "database:name", a subnet group is "subnet_group:name", a security group
+// is "security_group:name" and a cidr block is either "cidr:a.b.c.d/e" or
+// "cidr:alias" using the network's cidr aliases.
+func (a *Arc) ReachEndpoint(endpoint string) (*ReachEndpoint, error) {
+	if a.DataCenter == nil || a.DataCenter.Network == nil {
+		return nil, fmt.Errorf("The datacenter doesn't have a network")
+	}
+	n := a.DataCenter.Network
+	s := strings.SplitN(endpoint, ":", 2)
+	if len(s) != 2 || s[1] == "" {
+		return nil, fmt.Errorf("Malformed endpoint: %s", endpoint)
+	}
+	kind, name := s[0], s[1]
+	e := &ReachEndpoint{Name: endpoint}
+
+	switch kind {
+	case "pod":
+		pod := a.findPod(name)
+		if pod == nil {
+			return nil, fmt.Errorf("Unknown pod: %s", name)
+		}
+		cidrs, err := n.SubnetGroupCidrs(pod.SubnetGroup())
+		if err != nil {
+			return nil, err
+		}
+		e.SecurityGroups, e.Cidrs = pod.SecurityGroups(), cidrs
+	case "database":
+		if a.DatabaseService == nil {
+			return nil, fmt.Errorf("Unknown database: %s", name)
+		}
+		var db *Database
+		for _, d := range a.DatabaseService.Databases {
+			if d.Name() == name {
+				db = d
+			}
+		}
+		if db == nil {
+			return nil, fmt.Errorf("Unknown database: %s", name)
+		}
+		cidrs, err := n.SubnetGroupCidrs(db.SubnetGroup())
+		if err != nil {
+			return nil, err
+		}
+		e.SecurityGroups, e.Cidrs = db.SecurityGroups(), cidrs
+	case "subnet_group":
+		cidrs, err := n.SubnetGroupCidrs(name)
+		if err != nil {
+			return nil, err
+		}
+		e.Cidrs = cidrs
+	case "security_group":
+		e.SecurityGroups = []string{name}
+	case "cidr":
+		if alias := n.CidrAliases()[name]; alias != "" {
+			name = alias
+		}
+		if _, err := net.Contains(name, name); err != nil {
+			return nil, fmt.Errorf("Malformed endpoint: %s", endpoint)
+		}
+		e.Cidrs = []string{name}
+	default:
+		return nil, fmt.Errorf("Unknown endpoint: %s", kind)
+	}
+	for _, name := range e.SecurityGroups {
+		if n.findSecurityGroup(name) == nil {
+			return nil, fmt.Errorf("Unknown security_group: %s", name)
+		}
+	}
+	return e, nil
+}
+
+func (a *Arc) findPod(name string) *Pod {
+	if a.DataCenter.Compute == nil || a.DataCenter.Compute.Clusters == nil {
+		return nil
+	}
+	cluster := ""
+	if s := strings.SplitN(name, "/", 2); len(s) == 2 {
+		cluster, name = s[0], s[1]
+	}
+	for _, c := range *a.DataCenter.Compute.Clusters {
+		if c.Pods == nil || (cluster != "" && c.Name() != cluster) {
+			continue
+		}
+		for _, p := range *c.Pods {
+			if p.Name() == name {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// SubnetGroupCidrs returns the ipv4 cidr blocks of the named subnet group's
+// subnets, one for each availability zone of the subnet group's target.
+func (n *Network) SubnetGroupCidrs(name string) ([]string, error) {
+	if n.SubnetGroups != nil {
+		for _, s := range *n.SubnetGroups {
+			if s.Name() == name {
+				return net.CidrBlocks(s.CidrBlock(), len(n.SubnetGroupZones(s)))
+			}
+		}
+	}
+	return nil, fmt.Errorf("Unknown subnet_group: %s", name)
+}
+
+func (n *Network) findSecurityGroup(name string) *SecurityGroup {
+	if n.SecurityGroups == nil {
+		return nil
+	}
+	for _, s := range *n.SecurityGroups {
+		if s.Name() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Reach evaluates the configured security rules for a flow from the source
+// to the destination using the given protocol and port. For icmp the port is
+// the icmp type. Only the configuration is used, nothing is read from the provider.
+func (n *Network) Reach(src, dst *ReachEndpoint, protocol string, port int) (*Reachability, error) {
+	r := &Reachability{
+		Source:         src,
+		Destination:    dst,
+		Protocol:       protocol,
+		Port:           port,
+		EgressChecked:  len(src.SecurityGroups) > 0,
+		IngressChecked: len(dst.SecurityGroups) > 0,
+	}
+	var err error
+	if r.Egress, err = n.reachMatches(src.SecurityGroups, "egress", dst, protocol, port); err != nil {
+		return nil, err
+	}
+	if r.Ingress, err = n.reachMatches(dst.SecurityGroups, "ingress", src, protocol, port); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reachMatches finds the rules of the security groups, in the given direction,
+// that allow the flow to or from the remote endpoint.
+func (n *Network) reachMatches(groups []string, direction string, remote *ReachEndpoint, protocol string, port int) ([]*ReachMatch, error) {
+	matches := []*ReachMatch{}
+	for _, name := range groups {
+		group := n.findSecurityGroup(name)
+		if group == nil {
+			return nil, fmt.Errorf("Unknown security_group: %s", name)
+		}
+		if group.SecurityRules == nil {
+			continue
+		}
+		for _, rule := range *group.SecurityRules {
+			if !contains(rule.Directions(), direction) {
+				continue
+			}
+			ok, err := ruleAllows(rule, protocol, port)
+			if err != nil {
+				return nil, fmt.Errorf("Security group %s: %s", name, err)
+			}
+			if !ok {
+				continue
+			}
+			for _, r := range rule.Remotes() {
+				covered, partial, err := n.remoteCovers(r, remote)
+				if err != nil {
+					return nil, fmt.Errorf("Security group %s: %s", name, err)
+				}
+				if covered || partial {
+					matches = append(matches, &ReachMatch{SecurityGroup: name, Rule: rule, Remote: r, Partial: !covered})
+				}
+			}
+		}
+	}
+	return matches, nil
+}
+
+// ruleAllows returns true if the rule's protocols and ports include the
+// protocol and port. The protocol "-1" stands for all protocols and ports.
+func ruleAllows(rule *SecurityRule, protocol string, port int) (bool, error) {
+	for _, p := range rule.Protocols() {
+		if p == "-1" {
+			return true, nil
+		}
+		if p != protocol {
+			continue
+		}
+		for _, ports := range rule.Ports() {
+			s := strings.Split(ports, ":")
+			from, err := strconv.Atoi(strings.TrimSpace(s[0]))
+			if err != nil {
+				return false, fmt.Errorf("Malformed port: %s", ports)
+			}
+			to := from
+			if len(s) > 1 {
+				if to, err = strconv.Atoi(strings.TrimSpace(s[1])); err != nil {
+					return false, fmt.Errorf("Malformed port: %s", ports)
+				}
+			}
+			// The icmp "ports" are the icmp type and code.
+			if protocol == "icmp" {
+				to = from
+			}
+			if from == -1 || (from <= port && port <= to) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// remoteCovers checks a rule's remote against the endpoint. It is covered
+// when all of the endpoint's addresses are within the remote and partially
+// covered when only some of them are.
+func (n *Network) remoteCovers(remote string, e *ReachEndpoint) (covered, partial bool, err error) {
+	s := strings.SplitN(remote, ":", 2)
+	if len(s) != 2 {
+		return false, false, fmt.Errorf("Malformed remote: %s", remote)
+	}
+	cidrs := []string{}
+	switch s[0] {
+	case "security_group":
+		return contains(e.SecurityGroups, s[1]), false, nil
+	case "subnet_group":
+		if cidrs, err = n.SubnetGroupCidrs(s[1]); err != nil {
+			return
+		}
+	case "cidr":
+		cidr := s[1]
+		if alias := n.CidrAliases()[cidr]; alias != "" {
+			cidr = alias
+		}
+		cidrs = append(cidrs, cidr)
+	case "cidr_group":
+		group, ok := n.CidrGroups()[s[1]]
+		if !ok {
+			return false, false, fmt.Errorf("Cidr group %s does not exist", s[1])
+		}
+		cidrs = append(cidrs, group...)
+	case "peer":
+		peering := n.Peerings().Find(s[1])
+		if peering == nil {
+			return false, false, fmt.Errorf("Unknown peer: %s", s[1])
+		}
+		cidrs = append(cidrs, peering.Cidrs()...)
+	default:
+		return false, false, fmt.Errorf("Unknown remote: %s", s[0])
+	}
+	if len(e.Cidrs) == 0 {
+		return false, false, nil
+	}
+	covered = true
+	for _, ec := range e.Cidrs {
+		within, overlaps := false, false
+		for _, rc := range cidrs {
+			c, err := net.Contains(rc, ec)
+			if err != nil {
+				return false, false, err
+			}
+			o, err := net.Overlaps(rc, ec)
+			if err != nil {
+				return false, false, err
+			}
+			within, overlaps = within || c, overlaps || o
+		}
+		covered = covered && within
+		partial = partial || overlaps
+	}
+	return covered, partial && !covered, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
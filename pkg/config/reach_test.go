@@ -0,0 +1,133 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import "testing"
+
+func reachArc() *Arc {
+	return &Arc{
+		DataCenter: &DataCenter{
+			Network: &Network{
+				CidrBlock_:         "10.0.0.0/16",
+				AvailabilityZones_: []string{"a", "b"},
+				CidrAliases_:       map[string]string{"office": "192.168.1.0/24"},
+				SubnetGroups: &SubnetGroups{
+					{Name_: "web", CidrBlock_: "10.0.0.0/24"},
+					{Name_: "db", CidrBlock_: "10.0.10.0/24"},
+				},
+				SecurityGroups: &SecurityGroups{
+					{Name_: "web", SecurityRules: &SecurityRules{
+						{Directions_: []string{"egress"}, Remotes_: []string{"subnet_group:db"}, Protocols_: []string{"tcp"}, Ports_: []string{"5432"}},
+						{Directions_: []string{"ingress"}, Remotes_: []string{"cidr:office"}, Protocols_: []string{"tcp"}, Ports_: []string{"443"}},
+					}},
+					{Name_: "db", SecurityRules: &SecurityRules{
+						{Directions_: []string{"ingress"}, Remotes_: []string{"cidr:10.0.0.0/24"}, Protocols_: []string{"tcp"}, Ports_: []string{"5000:6000"}},
+					}},
+				},
+			},
+			Compute: &Compute{Clusters: &Clusters{
+				{Name_: "app", Pods: &Pods{{Name_: "web", SubnetGroup_: "web", SecurityGroups_: []string{"web"}}}},
+			}},
+		},
+		DatabaseService: &DatabaseService{Databases: []*Database{
+			{Name_: "main", SubnetGroup_: "db", SecurityGroups_: []string{"db"}},
+		}},
+	}
+}
+
+func reach(t *testing.T, a *Arc, from, to, protocol string, port int) *Reachability {
+	src, err := a.ReachEndpoint(from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst, err := a.ReachEndpoint(to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := a.DataCenter.Network.Reach(src, dst, protocol, port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestReach(t *testing.T) {
+	a := reachArc()
+
+	// The db ingress rule only covers the web subnet of the first availability zone.
+	r := reach(t, a, "pod:app/web", "database:main", "tcp", 5432)
+	if !r.EgressAllowed() {
+		t.Errorf("Expected egress from web to be allowed")
+	}
+	if r.IngressAllowed() || len(r.Ingress) != 1 || !r.Ingress[0].Partial {
+		t.Errorf("Expected ingress to db to be partially allowed, got %+v", r.Ingress)
+	}
+
+	(*a.DataCenter.Network.SecurityGroups)[1].SecurityRules = &SecurityRules{
+		{Directions_: []string{"ingress"}, Remotes_: []string{"security_group:web"}, Protocols_: []string{"tcp"}, Ports_: []string{"5432"}},
+	}
+	if r := reach(t, a, "pod:web", "database:main", "tcp", 5432); !r.Reachable() {
+		t.Errorf("Expected web to reach db")
+	}
+	if r := reach(t, a, "pod:web", "database:main", "tcp", 3306); r.Reachable() {
+		t.Errorf("Expected web not to reach db on 3306")
+	}
+	if r := reach(t, a, "cidr:office", "pod:web", "tcp", 443); !r.Reachable() || r.EgressChecked {
+		t.Errorf("Expected the office to reach web without checking egress")
+	}
+	if r := reach(t, a, "cidr:10.1.0.0/24", "pod:web", "tcp", 443); r.Reachable() {
+		t.Errorf("Expected 10.1.0.0/24 not to reach web")
+	}
+}
+
+func TestReachEndpointInvalid(t *testing.T) {
+	a := reachArc()
+	for _, e := range []string{"pod:nope", "database:nope", "subnet_group:nope", "cidr:nope", "host:web", "pod"} {
+		if _, err := a.ReachEndpoint(e); err == nil {
+			t.Errorf("Expected endpoint %s to be invalid", e)
+		}
+	}
+}
+
+func TestSubnetGroupCidrsTargetZones(t *testing.T) {
+	n := reachArc().DataCenter.Network
+	cidrs, err := n.SubnetGroupCidrs("web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cidrs) != 2 {
+		t.Errorf("Expected a cidr per network zone, got %v", cidrs)
+	}
+	(*n.SubnetGroups)[0].SetAvailabilityZones([]string{"c"})
+	cidrs, err = n.SubnetGroupCidrs("web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cidrs) != 1 || cidrs[0] != "10.0.0.0/24" {
+		t.Errorf("Expected a cidr per target zone, got %v", cidrs)
+	}
+}
@@ -0,0 +1,118 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// The configuration of the retention object. It is the number of daily,
+// weekly and monthly volume snapshots kept when snapshots are pruned.
+type Retention struct {
+	Daily_   int `json:"daily"`
+	Weekly_  int `json:"weekly"`
+	Monthly_ int `json:"monthly"`
+}
+
+// Daily is the number of days for which the newest snapshot is kept.
+func (r *Retention) Daily() int {
+	return r.Daily_
+}
+
+// Weekly is the number of weeks for which the newest snapshot is kept.
+func (r *Retention) Weekly() int {
+	return r.Weekly_
+}
+
+// Monthly is the number of months for which the newest snapshot is kept.
+func (r *Retention) Monthly() int {
+	return r.Monthly_
+}
+
+// Validate checks the retention configuration keeps at least one snapshot.
+func (r *Retention) Validate() error {
+	if r.Daily() < 0 || r.Weekly() < 0 || r.Monthly() < 0 {
+		return fmt.Errorf("The snapshot retention counts cannot be negative")
+	}
+	if r.Daily()+r.Weekly()+r.Monthly() == 0 {
+		return fmt.Errorf("The snapshot retention doesn't keep any snapshots")
+	}
+	return nil
+}
+
+// Keep returns which of the snapshots taken at the given times are kept.
+// The newest snapshot of each of the most recent days, weeks and months,
+// up to the configured counts, is kept.
+func (r *Retention) Keep(times []time.Time) []bool {
+	order := make([]int, len(times))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return times[order[a]].After(times[order[b]])
+	})
+
+	keep := make([]bool, len(times))
+	keepPeriods(times, order, keep, r.Daily(), func(t time.Time) string {
+		return t.Format("2006-01-02")
+	})
+	keepPeriods(times, order, keep, r.Weekly(), func(t time.Time) string {
+		y, w := t.ISOWeek()
+		return fmt.Sprintf("%d-%02d", y, w)
+	})
+	keepPeriods(times, order, keep, r.Monthly(), func(t time.Time) string {
+		return t.Format("2006-01")
+	})
+	return keep
+}
+
+func keepPeriods(times []time.Time, order []int, keep []bool, n int, period func(time.Time) string) {
+	seen := map[string]bool{}
+	for _, i := range order {
+		p := period(times[i].UTC())
+		if seen[p] {
+			continue
+		}
+		if len(seen) == n {
+			return
+		}
+		seen[p] = true
+		keep[i] = true
+	}
+}
+
+// Print provides a user friendly way to view the retention configuration.
+func (r *Retention) Print() {
+	msg.Info("Retention Config")
+	msg.Detail("%-20s\t%d", "daily", r.Daily())
+	msg.Detail("%-20s\t%d", "weekly", r.Weekly())
+	msg.Detail("%-20s\t%d", "monthly", r.Monthly())
+}
@@ -0,0 +1,76 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetentionKeep(t *testing.T) {
+	// Two snapshots a day, newest first, for 30 days starting on a Monday.
+	start := time.Date(2018, time.June, 4, 0, 0, 0, 0, time.UTC)
+	times := []time.Time{}
+	for d := 29; d >= 0; d-- {
+		day := start.AddDate(0, 0, d)
+		times = append(times, day.Add(18*time.Hour), day.Add(6*time.Hour))
+	}
+
+	r := &Retention{Daily_: 7, Weekly_: 4}
+	keep := r.Keep(times)
+
+	kept := 0
+	for i, k := range keep {
+		if !k {
+			continue
+		}
+		kept++
+		if times[i].Hour() != 18 {
+			t.Errorf("Expected only the newest snapshot of a day to be kept, kept %s", times[i])
+		}
+	}
+	// The 7 days cover the newest snapshots of the 2 most recent weeks, so
+	// the 4 weeks add the newest snapshots of the 2 weeks before them.
+	if kept != 9 {
+		t.Errorf("Expected 9 snapshots kept, got %d", kept)
+	}
+	if !keep[0] {
+		t.Error("Expected the newest snapshot to be kept")
+	}
+}
+
+func TestRetentionValidate(t *testing.T) {
+	if err := (&Retention{}).Validate(); err == nil {
+		t.Error("Expected an error for a retention that keeps nothing")
+	}
+	if err := (&Retention{Daily_: -1, Weekly_: 2}).Validate(); err == nil {
+		t.Error("Expected an error for a negative count")
+	}
+	if err := (&Retention{Monthly_: 6}).Validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -58,9 +58,13 @@ func (s *SecurityRule) Description() string {
 	return s.Description_
 }
 
-// Remotes can be either a cidr block, a subnet group or a security group.
-// A cidr block takes the form of "cidr:a.b.c.d/e", e.g. cidr:10.0.0.0/24.
-// A subnet group takes the form of "subnet_group:name", e.g. subnet_group:bastion.
+// Remotes can be either a cidr block, a subnet group, a peering or a security group.
+// A cidr block takes the form of "cidr:a.b.c.d/e", e.g. cidr:10.0.0.0/24, or
+// an ipv6 cidr block, e.g. cidr:2001:db8::/32.
+// A subnet group takes the form of "subnet_group:name", e.g. subnet_group:bastion,
+// and covers the ipv6 cidrs of dual stack subnet groups as well.
+// A peering takes the form of "peer:name", e.g. peer:shared-services, and covers
+// the cidrs of the peer vpc.
 // A security group takes the form of "security_group:name", e.g. security_group:bastion.
 // Avoid using security group remotes if at all possible.
 func (s *SecurityRule) Remotes() []string {
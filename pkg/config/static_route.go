@@ -0,0 +1,116 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// StaticRoutes is a collection of StaticRoute objects.
+type StaticRoutes []*StaticRoute
+
+// Validate checks the static routes are well formed and have unique destinations.
+func (s StaticRoutes) Validate() error {
+	destinations := map[string]bool{}
+	for _, r := range s {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if destinations[r.Destination()] {
+			return fmt.Errorf("Static route: The destination %s is routed more than once", r.Destination())
+		}
+		destinations[r.Destination()] = true
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the static routes configuration.
+func (s StaticRoutes) Print() {
+	msg.Info("StaticRoutes Config")
+	msg.IndentInc()
+	for _, r := range s {
+		r.Print()
+	}
+	msg.IndentDec()
+}
+
+// The configuration of a static route added to a subnet group's route tables.
+// It has the destination cidr block and a target, either the name of a peering
+// or the name of an instance, e.g. a vpn appliance. Provisioning the route
+// disables the source/destination check of an instance target so it can forward
+// traffic. The default route is left to the subnet group's internet or nat gateway.
+type StaticRoute struct {
+	Destination_ string `json:"destination"`
+	Peering_     string `json:"peering,omitempty"`
+	Instance_    string `json:"instance,omitempty"`
+}
+
+// Destination is the cidr block being routed.
+func (r *StaticRoute) Destination() string {
+	return r.Destination_
+}
+
+// Peering is the name of the peering the destination is routed to.
+func (r *StaticRoute) Peering() string {
+	return r.Peering_
+}
+
+// Instance is the name of the instance the destination is routed to.
+func (r *StaticRoute) Instance() string {
+	return r.Instance_
+}
+
+// Validate checks the destination is a cidr block other than the default route
+// and there is exactly one target.
+func (r *StaticRoute) Validate() error {
+	_, ipnet, err := net.ParseCIDR(r.Destination())
+	if err != nil {
+		return fmt.Errorf("Static route: Malformed destination %q", r.Destination())
+	}
+	if ones, _ := ipnet.Mask.Size(); ones == 0 {
+		return fmt.Errorf("Static route %s: The default route is owned by the subnet group's gateway", r.Destination())
+	}
+	if (r.Peering() == "") == (r.Instance() == "") {
+		return fmt.Errorf("Static route %s: Needs either a peering or an instance target", r.Destination())
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view a static route configuration.
+func (r *StaticRoute) Print() {
+	msg.Info("StaticRoute Config")
+	msg.Detail("%-20s\t%s", "destination", r.Destination())
+	if r.Peering() != "" {
+		msg.Detail("%-20s\t%s", "peering", r.Peering())
+	}
+	if r.Instance() != "" {
+		msg.Detail("%-20s\t%s", "instance", r.Instance())
+	}
+}
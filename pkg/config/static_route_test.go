@@ -0,0 +1,53 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import "testing"
+
+func TestStaticRoutesValidate(t *testing.T) {
+	valid := StaticRoutes{
+		{Destination_: "10.1.0.0/16", Peering_: "shared"},
+		{Destination_: "192.168.0.0/16", Instance_: "vpn-01"},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Static routes: %s", err)
+	}
+	for _, r := range []*StaticRoute{
+		{Destination_: "10.1.0.0", Peering_: "shared"},
+		{Destination_: "10.1.0.0/16"},
+		{Destination_: "10.1.0.0/16", Peering_: "shared", Instance_: "vpn-01"},
+		{Destination_: "0.0.0.0/0", Instance_: "vpn-01"},
+		{Destination_: "::/0", Peering_: "shared"},
+	} {
+		if err := r.Validate(); err == nil {
+			t.Errorf("Expected static route %+v to be invalid", *r)
+		}
+	}
+	if err := (StaticRoutes{valid[0], valid[0]}).Validate(); err == nil {
+		t.Errorf("Expected duplicate destinations to be invalid")
+	}
+}
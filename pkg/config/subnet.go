@@ -31,8 +31,9 @@ import "github.com/cisco/arc/pkg/msg"
 // The configuration of the subnet object. It has a name, the
 // starting cidr block of the subnet group, the type of access
 // (public, private, local) for the subnet group, the availability
-// zone where the subet is located and the flag indicating whether
-// this subnet needs to have a separate routetable..
+// zone where the subet is located, the flag indicating whether
+// this subnet needs to have a separate routetable and the ipv6
+// subnet id of dual stack subnets.
 //
 // A subnet is not part of the configuration file, so the existence
 // of this structure is a convenience meant to hold per subnet data
@@ -44,6 +45,7 @@ type Subnet struct {
 	Access_           string
 	AvailabilityZone_ string
 	ManageRoutes_     bool
+	Ipv6Subnet_       string
 }
 
 // Name satisfies the resource.StaticSubnet interface.
@@ -110,6 +112,18 @@ func (s *Subnet) SetManageRoutes(manageRoutes bool) {
 	s.ManageRoutes_ = manageRoutes
 }
 
+// Ipv6Subnet satisfies the resource.StaticSubnet interface.
+// The ipv6 subnet id is derived from the starting ipv6 subnet id
+// of the subnet group that owns this subnet.
+func (s *Subnet) Ipv6Subnet() string {
+	return s.Ipv6Subnet_
+}
+
+// SetIpv6Subnet provides a way to set the ipv6 subnet id at runtime.
+func (s *Subnet) SetIpv6Subnet(ipv6Subnet string) {
+	s.Ipv6Subnet_ = ipv6Subnet
+}
+
 // PrintLocal provides a user friendly way to view the configuration local to the arc object.
 func (s *Subnet) PrintLocal() {
 	msg.Info("Subnet Config")
@@ -119,6 +133,9 @@ func (s *Subnet) PrintLocal() {
 	msg.Detail("%-20s\t%s", "access", s.Access())
 	msg.Detail("%-20s\t%s", "availability zone", s.AvailabilityZone())
 	msg.Detail("%-20s\t%s", "manage routes", s.ManageRoutes())
+	if s.Ipv6Subnet() != "" {
+		msg.Detail("%-20s\t%s", "ipv6 subnet", s.Ipv6Subnet())
+	}
 }
 
 // Print provides a user friendly way to view the subnet configuration.
@@ -42,14 +42,31 @@ func (s *SubnetGroups) Print() {
 }
 
 // The configuration of the subnet group object. It has a name, the
-// starting cidr block of the subnet group, and the type of access
-// (public, private, local) for the subnet group.
+// starting cidr block of the subnet group, the type of access
+// (public, private, local) for the subnet group, optionally the
+// name of the network acl applied to the subnet group's subnets and
+// optionally the starting ipv6 subnet id of the subnet group. A subnet
+// group without a cidr block has its cidr block planned from its size.
 type SubnetGroup struct {
 	Name_         string       `json:"subnet"`
 	CidrBlock_    string       `json:"cidr"`
-	CidrBlocks_   []*CidrBlock `json:"cidrs"`
+	CidrBlocks_   []*CidrBlock `json:"cidrs,omitempty"`
+	Size_         int          `json:"size,omitempty"`
 	Access_       string       `json:"access"`
 	ManageRoutes_ bool         `json:"manage_routes"`
+	Target_       string       `json:"target,omitempty"`
+	NetworkAcl_   string       `json:"network_acl,omitempty"`
+	Ipv6Subnet_   string       `json:"ipv6_subnet,omitempty"`
+	FlowLog_      *FlowLog     `json:"flow_log,omitempty"`
+	Routes_       StaticRoutes `json:"routes,omitempty"`
+
+	// networkCidrBlock is the cidr block of the network of the provider target
+	// the subnet group is pinned to, when it isn't the datacenter's network.
+	networkCidrBlock string
+
+	// availabilityZones are the zones of the provider target the subnet group
+	// is pinned to, which has a subnet in each of them.
+	availabilityZones []string
 }
 
 // Name satisfies the resource.StaticSubnetGroup interface.
@@ -68,11 +85,78 @@ func (s *SubnetGroup) CidrBlocks() []*CidrBlock {
 	return s.CidrBlocks_
 }
 
+// SetCidrBlock sets the starting cidr block of the subnet group.
+func (s *SubnetGroup) SetCidrBlock(cidrBlock string) {
+	s.CidrBlock_ = cidrBlock
+}
+
+// Size is the prefix length, e.g. 24 for a /24, of each subnet of the subnet
+// group. It is used to plan the cidr block of a subnet group without one.
+func (s *SubnetGroup) Size() int {
+	return s.Size_
+}
+
 // ManageRoutes satisfies the resource.StaticSubnetGroup interface.
 func (s *SubnetGroup) ManageRoutes() bool {
 	return s.ManageRoutes_
 }
 
+// Target is the name of the provider target the subnet group is pinned to.
+// An empty target refers to the datacenter provider.
+func (s *SubnetGroup) Target() string {
+	return s.Target_
+}
+
+// NetworkCidrBlock is the cidr block of the network the subnet group lives in.
+// It is empty when the subnet group lives in the datacenter's network.
+func (s *SubnetGroup) NetworkCidrBlock() string {
+	return s.networkCidrBlock
+}
+
+// SetNetworkCidrBlock sets the cidr block of the network of the provider
+// target the subnet group is pinned to.
+func (s *SubnetGroup) SetNetworkCidrBlock(cidrBlock string) {
+	s.networkCidrBlock = cidrBlock
+}
+
+// AvailabilityZones are the zones of the provider target the subnet group is
+// pinned to. They are empty until set, use Network.SubnetGroupZones instead.
+func (s *SubnetGroup) AvailabilityZones() []string {
+	return s.availabilityZones
+}
+
+// SetAvailabilityZones sets the zones of the provider target the subnet group
+// is pinned to.
+func (s *SubnetGroup) SetAvailabilityZones(zones []string) {
+	s.availabilityZones = zones
+}
+
+// NetworkAcl is the name of the network acl applied to the subnet group.
+// An empty name leaves the subnets with the vpc's default network acl.
+func (s *SubnetGroup) NetworkAcl() string {
+	return s.NetworkAcl_
+}
+
+// Ipv6Subnet satisfies the resource.StaticSubnetGroup interface. It is the hex
+// id, 00 to ff, of the /64 carved from the network's ipv6 cidr block for the
+// first availability zone. Each following availability zone uses the next id.
+// An empty id leaves the subnet group ipv4 only.
+func (s *SubnetGroup) Ipv6Subnet() string {
+	return s.Ipv6Subnet_
+}
+
+// FlowLog is the flow log of the subnet group's subnets. It is nil when
+// the subnet group's traffic isn't logged on its own.
+func (s *SubnetGroup) FlowLog() *FlowLog {
+	return s.FlowLog_
+}
+
+// Routes satisfies the resource.StaticSubnetGroup interface. They are the
+// static routes added to the route tables of a subnet group managing its routes.
+func (s *SubnetGroup) Routes() StaticRoutes {
+	return s.Routes_
+}
+
 // Access satisfies the resource.StaticSubnetGroup interface.
 //
 // Access return values and meanings.
@@ -105,13 +189,33 @@ func (s *SubnetGroup) PrintLocal() {
 	msg.Info("SubnetGroup Config")
 	msg.Detail("%-20s\t%s", "name", s.Name())
 	msg.Detail("%-20s\t%s", "cidr", s.CidrBlock())
+	if s.Size() != 0 {
+		msg.Detail("%-20s\t/%d", "size", s.Size())
+	}
 	msg.Detail("%-20s\t%s", "access", s.Access())
 	msg.Detail("%-20s\t%t", "manage routes", s.ManageRoutes())
+	if s.Target() != "" {
+		msg.Detail("%-20s\t%s", "target", s.Target())
+	}
+	if s.NetworkAcl() != "" {
+		msg.Detail("%-20s\t%s", "network acl", s.NetworkAcl())
+	}
+	if s.Ipv6Subnet() != "" {
+		msg.Detail("%-20s\t%s", "ipv6 subnet", s.Ipv6Subnet())
+	}
 }
 
 // Print provides a user friendly way to view a subnet group configuration.
 func (s *SubnetGroup) Print() {
 	s.PrintLocal()
+	msg.IndentInc()
+	if s.FlowLog() != nil {
+		s.FlowLog().Print()
+	}
+	if len(s.Routes()) > 0 {
+		s.Routes().Print()
+	}
+	msg.IndentDec()
 }
 
 type CidrBlock struct {
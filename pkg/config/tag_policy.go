@@ -0,0 +1,260 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cisco/arc/pkg/msg"
+)
+
+// The configuration of the tag policy object. It is the list of rules the
+// tags of the datacenter's or amp's resources must follow.
+type TagPolicy struct {
+	Rules_ []*TagRule `json:"rules"`
+}
+
+// Rules returns the rules of the tag policy.
+func (t *TagPolicy) Rules() []*TagRule {
+	if t == nil {
+		return nil
+	}
+	return t.Rules_
+}
+
+// Validate checks each of the rules of the tag policy.
+func (t *TagPolicy) Validate() error {
+	seen := map[string]bool{}
+	for _, r := range t.Rules() {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if seen[r.Key()] {
+			return fmt.Errorf("Tag policy key %q is used by multiple rules", r.Key())
+		}
+		seen[r.Key()] = true
+	}
+	return nil
+}
+
+// Check returns the problems with the tags of a resource of the given kind.
+func (t *TagPolicy) Check(kind string, tags map[string]string) []string {
+	problems := []string{}
+	for _, r := range t.Rules() {
+		if !r.Applies(kind) {
+			continue
+		}
+		value, ok := tags[r.Key()]
+		if err := r.Check(value, ok); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	return problems
+}
+
+// Fix returns the tags to set to fix the tags of a resource of the given kind,
+// and the problems that cannot be fixed. A value is inherited from the tags of
+// the resource's owner, such as the instance a volume is attached to, when the
+// rule allows it, otherwise it is taken from the defaults or the rule's default.
+func (t *TagPolicy) Fix(kind string, tags, inherited, defaults map[string]string) (map[string]string, []string) {
+	fixed := map[string]string{}
+	problems := []string{}
+	for _, r := range t.Rules() {
+		if !r.Applies(kind) {
+			continue
+		}
+		value, ok := tags[r.Key()]
+		err := r.Check(value, ok)
+		if err == nil {
+			continue
+		}
+		candidates := []string{}
+		if r.Inherit() {
+			if v, ok := inherited[r.Key()]; ok {
+				candidates = append(candidates, v)
+			}
+		}
+		if v, ok := defaults[r.Key()]; ok {
+			candidates = append(candidates, v)
+		}
+		if r.Default() != "" {
+			candidates = append(candidates, r.Default())
+		}
+		found := false
+		for _, v := range candidates {
+			if r.Check(v, true) == nil {
+				fixed[r.Key()] = v
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, err.Error())
+		}
+	}
+	return fixed, problems
+}
+
+// Print provides a user friendly way to view the tag policy configuration.
+func (t *TagPolicy) Print() {
+	msg.Info("Tag Policy Config")
+	msg.IndentInc()
+	for _, r := range t.Rules() {
+		r.Print()
+	}
+	msg.IndentDec()
+}
+
+// The configuration of the tag rule object. It has the key of a required
+// tag, the values or the pattern the value must match, the default value
+// used when fixing the tag, the kinds of resources the rule applies to, and
+// whether resources such as volumes inherit the value from their instance.
+type TagRule struct {
+	Key_       string   `json:"key"`
+	Values_    []string `json:"values"`
+	Pattern_   string   `json:"pattern"`
+	Default_   string   `json:"default"`
+	Resources_ []string `json:"resources"`
+	Inherit_   bool     `json:"inherit"`
+}
+
+// Key is the key of the required tag.
+func (r *TagRule) Key() string {
+	return r.Key_
+}
+
+// Values are the values the tag is allowed to have. Any value is allowed
+// when empty.
+func (r *TagRule) Values() []string {
+	return r.Values_
+}
+
+// Pattern is the regular expression the whole value of the tag must match.
+func (r *TagRule) Pattern() string {
+	return r.Pattern_
+}
+
+// Default is the value the tag is set to when it is fixed and the value
+// cannot be inherited or taken from the security tags.
+func (r *TagRule) Default() string {
+	return r.Default_
+}
+
+// Resources are the kinds of resources the rule applies to: instance, volume,
+// elastic-ip, hosted-zone, vpc, subnet, security-group or bucket. The rule
+// applies to every kind when empty.
+func (r *TagRule) Resources() []string {
+	return r.Resources_
+}
+
+// Inherit returns true if resources take the value of the tag from the
+// resource they belong to.
+func (r *TagRule) Inherit() bool {
+	return r.Inherit_
+}
+
+// Applies returns true if the rule applies to resources of the given kind.
+func (r *TagRule) Applies(kind string) bool {
+	if len(r.Resources()) == 0 {
+		return true
+	}
+	for _, k := range r.Resources() {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the rule has a key, a valid pattern and a valid default.
+func (r *TagRule) Validate() error {
+	if r.Key() == "" {
+		return fmt.Errorf("Tag policy rule is missing a key")
+	}
+	if r.Pattern() != "" {
+		if _, err := regexp.Compile("^(?:" + r.Pattern() + ")$"); err != nil {
+			return fmt.Errorf("Tag policy key %q: %s", r.Key(), err)
+		}
+	}
+	if r.Default() != "" {
+		if err := r.Check(r.Default(), true); err != nil {
+			return fmt.Errorf("Tag policy default: %s", err)
+		}
+	}
+	return nil
+}
+
+// Check returns an error if the tag is missing or its value isn't allowed.
+func (r *TagRule) Check(value string, present bool) error {
+	if !present {
+		return fmt.Errorf("missing tag %q", r.Key())
+	}
+	if len(r.Values()) > 0 {
+		allowed := false
+		for _, v := range r.Values() {
+			if v == value {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("tag %q value %q is not one of %s", r.Key(), value, strings.Join(r.Values(), ", "))
+		}
+	}
+	if r.Pattern() != "" {
+		re, err := regexp.Compile("^(?:" + r.Pattern() + ")$")
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("tag %q value %q does not match %q", r.Key(), value, r.Pattern())
+		}
+	}
+	return nil
+}
+
+// Print provides a user friendly way to view the tag rule configuration.
+func (r *TagRule) Print() {
+	msg.Detail("%-20s\t%s", "key", r.Key())
+	if len(r.Values()) > 0 {
+		values := append([]string{}, r.Values()...)
+		sort.Strings(values)
+		msg.Detail("%-20s\t%s", "values", strings.Join(values, ", "))
+	}
+	if r.Pattern() != "" {
+		msg.Detail("%-20s\t%s", "pattern", r.Pattern())
+	}
+	if r.Default() != "" {
+		msg.Detail("%-20s\t%s", "default", r.Default())
+	}
+	if len(r.Resources()) > 0 {
+		msg.Detail("%-20s\t%s", "resources", strings.Join(r.Resources(), ", "))
+	}
+	msg.Detail("%-20s\t%t", "inherit", r.Inherit())
+}
@@ -0,0 +1,81 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package config
+
+import "testing"
+
+var tagPolicy = &TagPolicy{
+	Rules_: []*TagRule{
+		{Key_: "Team", Inherit_: true},
+		{Key_: "Environment", Values_: []string{"dev", "prod"}, Default_: "dev"},
+		{Key_: "CostCenter", Pattern_: "[0-9]{4}", Resources_: []string{"instance"}},
+	},
+}
+
+func TestTagPolicyCheck(t *testing.T) {
+	if err := tagPolicy.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	tags := map[string]string{"Team": "web", "Environment": "prod", "CostCenter": "1234"}
+	if problems := tagPolicy.Check("instance", tags); len(problems) != 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+	tags = map[string]string{"Environment": "staging", "CostCenter": "12a4"}
+	if problems := tagPolicy.Check("instance", tags); len(problems) != 3 {
+		t.Errorf("Expected 3 problems, got %v", problems)
+	}
+	if problems := tagPolicy.Check("volume", tags); len(problems) != 2 {
+		t.Errorf("Expected the cost center rule to only apply to instances, got %v", problems)
+	}
+}
+
+func TestTagPolicyFix(t *testing.T) {
+	tags := map[string]string{"Environment": "staging"}
+	inherited := map[string]string{"Team": "web", "Environment": "prod"}
+	fixed, problems := tagPolicy.Fix("volume", tags, inherited, nil)
+	if len(problems) != 0 {
+		t.Errorf("Expected the volume tags to be fixed, got %v", problems)
+	}
+	// Environment isn't inherited, so it falls back to the rule's default.
+	if fixed["Team"] != "web" || fixed["Environment"] != "dev" || len(fixed) != 2 {
+		t.Errorf("Unexpected fixed tags %v", fixed)
+	}
+
+	fixed, problems = tagPolicy.Fix("instance", map[string]string{}, nil, map[string]string{"Team": "db"})
+	if fixed["Team"] != "db" || len(problems) != 1 {
+		t.Errorf("Expected the cost center to be unfixable, got %v, %v", fixed, problems)
+	}
+}
+
+func TestTagPolicyValidate(t *testing.T) {
+	for _, r := range []*TagRule{{}, {Key_: "A", Pattern_: "("}, {Key_: "A", Values_: []string{"x"}, Default_: "y"}} {
+		p := &TagPolicy{Rules_: []*TagRule{r}}
+		if err := p.Validate(); err == nil {
+			t.Errorf("Expected rule %+v to be invalid", *r)
+		}
+	}
+}
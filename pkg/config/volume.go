@@ -49,6 +49,8 @@ type Volume struct {
 	Inodes_     int    `json:"inodes"`
 	MountPoint_ string `json:"mount_point"`
 	Preserve_   bool   `json:"preserve"`
+
+	EncryptionKey_ string `json:"encryption_key"`
 }
 
 func (v *Volume) Device() string {
@@ -87,6 +89,13 @@ func (v *Volume) Preserve() bool {
 	return v.Preserve_
 }
 
+// EncryptionKey is the name of the amp managed encryption key used to
+// encrypt the volume. When empty non-boot volumes are encrypted with
+// the provider's default key.
+func (v *Volume) EncryptionKey() string {
+	return v.EncryptionKey_
+}
+
 func (v *Volume) Print() {
 	msg.Info("Volume Config")
 	msg.Detail("%-20s\t%s", "device", v.Device())
@@ -95,6 +104,9 @@ func (v *Volume) Print() {
 	msg.Detail("%-20s\t%t", "keep", v.Keep())
 	msg.Detail("%-20s\t%t", "boot", v.Boot())
 	msg.Detail("%-20s\t%t", "preserve", v.Preserve())
+	if v.EncryptionKey() != "" {
+		msg.Detail("%-20s\t%s", "encryption key", v.EncryptionKey())
+	}
 	if v.FsType() != "" {
 		msg.Detail("%-20s\t%s", "fstype", v.FsType())
 		msg.Detail("%-20s\t%s", "mount point", v.MountPoint())
@@ -67,6 +67,7 @@ func Init(appname, version string) error {
 		}
 	}
 	latest := appDir + "/latest"
+	cache := appDir + "/cache"
 
 	// Using a modified RFC3339 format to having colons in the path
 	t := time.Now()
@@ -107,6 +108,7 @@ func Init(appname, version string) error {
 
 	env[strings.ToUpper(appname)] = appDir
 	env["ROOT"] = root
+	env["CACHE"] = cache
 	env["VERSION"] = version
 	env["SPARK_TOKEN"] = initSpark()
 	env["USER"] = u.Username
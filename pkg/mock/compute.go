@@ -56,6 +56,18 @@ func (n *compute) AuditEIP(flags ...string) error {
 	return nil
 }
 
+func (n *compute) AuditTags(policy *config.TagPolicy, flags ...string) error {
+	return nil
+}
+
+func (n *compute) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	return nil
+}
+
 func (n *compute) AuditInstances(flags ...string) error {
 	return nil
 }
+
+func (n *compute) LoadCache(names []string, readOnly bool) error {
+	return nil
+}
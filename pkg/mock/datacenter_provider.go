@@ -45,6 +45,28 @@ func NewDataCenterProvider(cfg *config.DataCenter) (provider.DataCenter, error)
 	}, nil
 }
 
+func (p *dataCenterProvider) Target(name string) (provider.DataCenter, error) {
+	cfg, err := p.Provider.Target(name)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == p.Provider {
+		return p, nil
+	}
+	log.Info("Initializing mock datacenter provider target %q", name)
+	return &dataCenterProvider{
+		Provider: cfg,
+	}, nil
+}
+
+func (p *dataCenterProvider) AvailabilityZones(zones []string) []string {
+	return zones
+}
+
+func (p *dataCenterProvider) CidrBlock(cidrBlock string) string {
+	return cidrBlock
+}
+
 func (p *dataCenterProvider) NewNetwork(cfg *config.Network) (resource.ProviderNetwork, error) {
 	return newNetwork(cfg, p)
 }
@@ -61,6 +83,10 @@ func (p *dataCenterProvider) NewNetworkPost(net resource.Network, cfg *config.Ne
 	return newNetworkPost(cfg, p)
 }
 
+func (p *dataCenterProvider) NewEndpoints(net resource.Network, cfg *config.Network) (resource.ProviderEndpoints, error) {
+	return newEndpoints(cfg, p)
+}
+
 func (p *dataCenterProvider) NewCompute(cfg *config.Compute) (resource.ProviderCompute, error) {
 	return newCompute(cfg, p)
 }
@@ -69,6 +95,18 @@ func (p *dataCenterProvider) NewKeyPair(cfg *config.KeyPair) (resource.ProviderK
 	return newKeyPair(cfg, p)
 }
 
+func (p *dataCenterProvider) NewAutoScalingGroup(pod resource.Pod, cfg *config.Pod) (resource.ProviderAutoScalingGroup, error) {
+	return newAutoScalingGroup(cfg, p)
+}
+
+func (p *dataCenterProvider) NewPlacementGroup(pod resource.Pod, cfg *config.Pod) (resource.ProviderPlacementGroup, error) {
+	return newPlacementGroup(cfg, p)
+}
+
+func (p *dataCenterProvider) NewLoadBalancer(pod resource.Pod, cfg *config.Pod) (resource.ProviderLoadBalancer, error) {
+	return newLoadBalancer(cfg, p)
+}
+
 func (p *dataCenterProvider) NewInstance(instance resource.Instance, cfg *config.Instance) (resource.ProviderInstance, error) {
 	return newInstance(cfg, p)
 }
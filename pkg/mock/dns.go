@@ -57,3 +57,15 @@ func (n *dns) Id() string {
 func (n *dns) AuditDnsRecords(flags ...string) error {
 	return nil
 }
+
+func (n *dns) AuditTags(policy *config.TagPolicy, flags ...string) error {
+	return nil
+}
+
+func (n *dns) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	return nil
+}
+
+func (n *dns) LoadCache(names []string, readOnly bool) error {
+	return nil
+}
@@ -72,6 +72,10 @@ func (i *instance) PublicIPAddress() string {
 	return i.publicIPAddress
 }
 
+func (i *instance) Ipv6Address() string {
+	return ""
+}
+
 func (i *instance) Started() bool {
 	return true
 }
@@ -80,10 +84,60 @@ func (i *instance) Stopped() bool {
 	return false
 }
 
+func (i *instance) Pending() bool {
+	return false
+}
+
+func (i *instance) Stopping() bool {
+	return false
+}
+
+func (i *instance) ShuttingDown() bool {
+	return false
+}
+
+func (i *instance) Lifecycle() string {
+	return i.Instance.Purchasing().Strategy()
+}
+
+func (i *instance) AvailabilityZone() string {
+	return ""
+}
+
+func (i *instance) DeployedType() string {
+	return i.Instance.InstanceType()
+}
+
+func (i *instance) SetInstanceType(instanceType string) error {
+	log.Info("Setting mock instance type %q", instanceType)
+	return nil
+}
+
+func (i *instance) Baked() bool {
+	return i.Instance.Baked()
+}
+
+func (i *instance) Adopted() bool {
+	return false
+}
+
+func (i *instance) CreateImage(name string, tags map[string]string) (string, error) {
+	log.Info("Creating mock image %q", name)
+	return "0xba4edb0b", nil
+}
+
+func (i *instance) SetUserData(data []byte) {
+	log.Info("Setting mock instance user-data, %d bytes", len(data))
+}
+
 func (i *instance) SetTags(t map[string]string) error {
 	return nil
 }
 
+func (i *instance) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	return nil
+}
+
 func (i *instance) Audit(flags ...string) error {
 	return nil
 }
@@ -27,9 +27,12 @@
 package mock
 
 import (
+	"fmt"
+
 	"github.com/cisco/arc/pkg/config"
 	"github.com/cisco/arc/pkg/log"
 	"github.com/cisco/arc/pkg/resource"
+	"github.com/cisco/arc/pkg/route"
 )
 
 // network implements the resource.ProviderNetwork interface.
@@ -68,6 +71,22 @@ func (n *network) AuditSecgroups(flags ...string) error {
 	return nil
 }
 
+func (n *network) ImportSecgroup(group string) (*config.SecurityGroup, error) {
+	return nil, fmt.Errorf("Unknown security group %q", group)
+}
+
+func (n *network) AdoptSecgroup(req *route.Request, group, name string) error {
+	return fmt.Errorf("Unknown security group %q", group)
+}
+
+func (n *network) AuditTags(policy *config.TagPolicy, flags ...string) error {
+	return nil
+}
+
+func (n *network) FixTags(policy *config.TagPolicy, defaults map[string]string) error {
+	return nil
+}
+
 // networkPost implements the resource.ProviderNetworkPost interface.
 type networkPost struct {
 	*mock
@@ -83,3 +102,19 @@ func newNetworkPost(cfg *config.Network, p *dataCenterProvider) (resource.Provid
 	}
 	return n, nil
 }
+
+// endpoints implements the resource.ProviderEndpoints interface.
+type endpoints struct {
+	*mock
+	*config.Network
+}
+
+// newEndpoints constructs the mock endpoints.
+func newEndpoints(cfg *config.Network, p *dataCenterProvider) (resource.ProviderEndpoints, error) {
+	log.Info("Initializing mock endpoints")
+	e := &endpoints{
+		mock:    newMock("endpoints", p.Provider),
+		Network: cfg,
+	}
+	return e, nil
+}
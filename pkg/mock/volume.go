@@ -93,3 +93,25 @@ func (v *volume) Info() {
 
 func (v *volume) Reset() {
 }
+
+func (v *volume) Snapshot(map[string]string) (string, error) {
+	log.Info("Creating mock snapshot of volume %q", v.Device())
+	return "0x5a4b5407", nil
+}
+
+func (v *volume) Snapshots(map[string]string) ([]*resource.Snapshot, error) {
+	return nil, nil
+}
+
+func (v *volume) DeleteSnapshot(id string) error {
+	log.Info("Deleting mock snapshot %q", id)
+	return nil
+}
+
+func (v *volume) Restore(id string) (string, error) {
+	log.Info("Restoring mock volume %q from snapshot %q", v.Device(), id)
+	return v.id, nil
+}
+
+func (v *volume) UseSnapshot(id string) {
+}
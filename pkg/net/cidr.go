@@ -29,28 +29,82 @@ package net
 import (
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 )
 
+// NextCidrBlock returns the cidr block of the same size that follows the given
+// cidr block. Both ipv4 and ipv6 cidr blocks are supported.
 func NextCidrBlock(cidrBlock string) (string, error) {
 	ip, ipnet, err := net.ParseCIDR(cidrBlock)
 	if err != nil {
 		return "", err
 	}
 
-	ip4 := ip.To4()
+	addr := ip.To4()
+	if addr == nil {
+		addr = ip.To16()
+	}
 	ones, bits := ipnet.Mask.Size()
 	if ones >= bits {
 		return "", fmt.Errorf("Malformed CIDR %s, size of mask is equal to or larger than number of bits.", cidrBlock)
 	}
 	var shift uint
 	shift = (uint(bits) - uint(ones)) % 8
-	offset := len(ip4) - (bits-ones)/8 - 1
+	offset := len(addr) - (bits-ones)/8 - 1
 
-	n := ip4[offset] >> shift
+	n := addr[offset] >> shift
 	if n >= 255>>shift {
 		return "", fmt.Errorf("Cidr %s cannot be incremented, it would overflow", cidrBlock)
 	}
-	ip4[offset] = (n + 1) << shift
+	addr[offset] = (n + 1) << shift
+
+	return fmt.Sprintf("%s/%d", addr.String(), ones), nil
+}
+
+// IsIpv6 returns true if the cidr block or address is ipv6.
+func IsIpv6(cidrBlock string) bool {
+	return strings.Contains(cidrBlock, ":")
+}
+
+// Ipv6SubnetBlock returns the /64 cidr block identified by the subnet id within the
+// /56 cidr block, such as the block amazon provides to a vpc. The subnet id is the
+// two digit hex value of the eight bits between the /56 and the /64, i.e. "0a".
+func Ipv6SubnetBlock(cidrBlock, subnetId string) (string, error) {
+	_, ipnet, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return "", err
+	}
+	if ones, bits := ipnet.Mask.Size(); bits != 128 || ones != 56 {
+		return "", fmt.Errorf("Malformed CIDR %s, expecting an ipv6 /56 cidr block", cidrBlock)
+	}
+	id, err := ParseIpv6Subnet(subnetId)
+	if err != nil {
+		return "", err
+	}
+	addr := ipnet.IP.To16()
+	addr[7] = id
+
+	return fmt.Sprintf("%s/64", addr.String()), nil
+}
 
-	return fmt.Sprintf("%s/%d", ip4.String(), ones), nil
+// NextIpv6Subnet returns the ipv6 subnet id that follows the given subnet id.
+func NextIpv6Subnet(subnetId string) (string, error) {
+	id, err := ParseIpv6Subnet(subnetId)
+	if err != nil {
+		return "", err
+	}
+	if id >= 255 {
+		return "", fmt.Errorf("Ipv6 subnet id %s cannot be incremented, it would overflow", subnetId)
+	}
+	return fmt.Sprintf("%02x", id+1), nil
+}
+
+// ParseIpv6Subnet parses the two digit hex ipv6 subnet id.
+func ParseIpv6Subnet(subnetId string) (byte, error) {
+	id, err := strconv.ParseUint(subnetId, 16, 8)
+	if err != nil || len(subnetId) != 2 {
+		return 0, fmt.Errorf("Malformed ipv6 subnet id %q, expecting a hex value from 00 to ff", subnetId)
+	}
+	return byte(id), nil
 }
@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package net
+
+import "testing"
+
+func TestNextCidrBlock(t *testing.T) {
+	for _, c := range []struct{ cidr, next string }{
+		{"10.0.1.0/24", "10.0.2.0/24"},
+		{"10.0.16.0/20", "10.0.32.0/20"},
+		{"10.0.0.64/26", "10.0.0.128/26"},
+		{"2600:1f14:e0c:6c00::/64", "2600:1f14:e0c:6c01::/64"},
+	} {
+		next, err := NextCidrBlock(c.cidr)
+		if err != nil {
+			t.Errorf("NextCidrBlock(%s): %s", c.cidr, err)
+			continue
+		}
+		if next != c.next {
+			t.Errorf("NextCidrBlock(%s) = %s, expected %s", c.cidr, next, c.next)
+		}
+	}
+	if _, err := NextCidrBlock("10.0.255.0/24"); err == nil {
+		t.Errorf("Expected NextCidrBlock(10.0.255.0/24) to overflow")
+	}
+}
+
+func TestIpv6SubnetBlock(t *testing.T) {
+	block, err := Ipv6SubnetBlock("2600:1f14:e0c:6c00::/56", "0a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block != "2600:1f14:e0c:6c0a::/64" {
+		t.Errorf("Ipv6SubnetBlock = %s, expected 2600:1f14:e0c:6c0a::/64", block)
+	}
+	if _, err := Ipv6SubnetBlock("2600:1f14:e0c:6c00::/64", "0a"); err == nil {
+		t.Errorf("Expected a /64 cidr block to be rejected")
+	}
+	if _, err := Ipv6SubnetBlock("10.0.0.0/16", "0a"); err == nil {
+		t.Errorf("Expected an ipv4 cidr block to be rejected")
+	}
+	if _, err := Ipv6SubnetBlock("2600:1f14:e0c:6c00::/56", "100"); err == nil {
+		t.Errorf("Expected subnet id 100 to be rejected")
+	}
+}
+
+func TestNextIpv6Subnet(t *testing.T) {
+	if next, err := NextIpv6Subnet("0f"); err != nil || next != "10" {
+		t.Errorf("NextIpv6Subnet(0f) = %s, %v, expected 10", next, err)
+	}
+	if _, err := NextIpv6Subnet("ff"); err == nil {
+		t.Errorf("Expected NextIpv6Subnet(ff) to overflow")
+	}
+}
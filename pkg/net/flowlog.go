@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package net
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// FlowRecord is a record of a vpc flow log in the default format, version,
+// account-id, interface-id, srcaddr, dstaddr, srcport, dstport, protocol,
+// packets, bytes, start, end, action and log-status.
+type FlowRecord struct {
+	InterfaceId string
+	SrcAddr     string
+	DstAddr     string
+	SrcPort     int
+	DstPort     int
+	Protocol    int
+	Packets     int64
+	Bytes       int64
+	Action      string
+}
+
+// ProtocolName returns the name of the record's iana protocol number.
+func (f *FlowRecord) ProtocolName() string {
+	switch f.Protocol {
+	case 1:
+		return "icmp"
+	case 6:
+		return "tcp"
+	case 17:
+		return "udp"
+	case 58:
+		return "icmpv6"
+	}
+	return strconv.Itoa(f.Protocol)
+}
+
+// ParseFlowLog reads the records of a flow log. The header line and the
+// records without data, NODATA and SKIPDATA, are skipped.
+func ParseFlowLog(r io.Reader) ([]*FlowRecord, error) {
+	records := []*FlowRecord{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] == "version" {
+			continue
+		}
+		if len(fields) != 14 {
+			return nil, fmt.Errorf("Flow log line %d: Expected 14 fields, found %d", line, len(fields))
+		}
+		if fields[13] != "OK" {
+			continue
+		}
+		f := &FlowRecord{
+			InterfaceId: fields[2],
+			SrcAddr:     fields[3],
+			DstAddr:     fields[4],
+			Action:      fields[12],
+		}
+		var err error
+		for _, v := range []struct {
+			s string
+			i *int
+		}{{fields[5], &f.SrcPort}, {fields[6], &f.DstPort}, {fields[7], &f.Protocol}} {
+			if *v.i, err = strconv.Atoi(v.s); err != nil {
+				return nil, fmt.Errorf("Flow log line %d: %s", line, err)
+			}
+		}
+		if f.Packets, err = strconv.ParseInt(fields[8], 10, 64); err != nil {
+			return nil, fmt.Errorf("Flow log line %d: %s", line, err)
+		}
+		if f.Bytes, err = strconv.ParseInt(fields[9], 10, 64); err != nil {
+			return nil, fmt.Errorf("Flow log line %d: %s", line, err)
+		}
+		records = append(records, f)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
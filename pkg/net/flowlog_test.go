@@ -0,0 +1,57 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package net
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFlowLog(t *testing.T) {
+	log := `version account-id interface-id srcaddr dstaddr srcport dstport protocol packets bytes start end action log-status
+2 123456789010 eni-1235b8ca 172.31.16.139 172.31.16.21 20641 22 6 20 4249 1418530010 1418530070 ACCEPT OK
+2 123456789010 eni-1235b8ca 172.31.9.69 172.31.9.12 49761 3389 6 20 4249 1418530010 1418530070 REJECT OK
+2 123456789010 eni-1a2b3c4d - - - - - - - 1431280876 1431280934 - NODATA
+`
+	records, err := ParseFlowLog(strings.NewReader(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, found %d", len(records))
+	}
+	r := records[1]
+	if r.SrcAddr != "172.31.9.69" || r.DstPort != 3389 || r.ProtocolName() != "tcp" || r.Action != "REJECT" || r.Bytes != 4249 {
+		t.Errorf("Unexpected record %+v", *r)
+	}
+	if _, err := ParseFlowLog(strings.NewReader("2 123 eni-1 1.2.3.4\n")); err == nil {
+		t.Errorf("Expected a truncated record to fail")
+	}
+	if _, err := ParseFlowLog(strings.NewReader("2 1 eni-1 1.2.3.4 5.6.7.8 x 22 6 1 1 1 1 ACCEPT OK\n")); err == nil {
+		t.Errorf("Expected a malformed port to fail")
+	}
+}
@@ -0,0 +1,230 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package net
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+)
+
+// Planner allocates non-overlapping ipv4 cidr blocks within a network's cidr block.
+type Planner struct {
+	first uint64
+	last  uint64
+	ones  int
+	used  []span
+}
+
+type span struct {
+	name  string
+	cidr  string
+	first uint64
+	last  uint64
+}
+
+// NewPlanner returns a planner for the given ipv4 cidr block.
+func NewPlanner(cidrBlock string) (*Planner, error) {
+	first, last, ones, err := parseIpv4Cidr(cidrBlock)
+	if err != nil {
+		return nil, err
+	}
+	return &Planner{first: first, last: last, ones: ones}, nil
+}
+
+// Reserve marks the cidr block as used by the named owner. It fails if the cidr
+// block isn't within the network or overlaps a block already in use.
+func (p *Planner) Reserve(name, cidrBlock string) error {
+	first, last, _, err := parseIpv4Cidr(cidrBlock)
+	if err != nil {
+		return err
+	}
+	if first < p.first || last > p.last {
+		return fmt.Errorf("%s, %s is outside of the network's cidr block", name, cidrBlock)
+	}
+	if s := p.overlap(first, last); s != nil {
+		return fmt.Errorf("%s, %s overlaps %s, %s", name, cidrBlock, s.name, s.cidr)
+	}
+	p.used = append(p.used, span{name: name, cidr: cidrBlock, first: first, last: last})
+	sort.Slice(p.used, func(i, j int) bool { return p.used[i].first < p.used[j].first })
+	return nil
+}
+
+// Allocate finds the first free run of count consecutive cidr blocks with the
+// given prefix length, reserves them for the named owner and returns them. The
+// blocks of the run follow each other as NextCidrBlock would produce them.
+func (p *Planner) Allocate(name string, prefix, count int) ([]string, error) {
+	if prefix < p.ones || prefix > 32 {
+		return nil, fmt.Errorf("%s, a /%d doesn't fit in a /%d network", name, prefix, p.ones)
+	}
+	if count < 1 {
+		count = 1
+	}
+	size := uint64(1) << uint(32-prefix)
+	for start := p.first; start+size-1 <= p.last; start += size {
+		cidrs, err := CidrBlocks(fmt.Sprintf("%s/%d", ipv4String(start), prefix), count)
+		if err != nil {
+			continue
+		}
+		free := true
+		for _, c := range cidrs {
+			first, last, _, _ := parseIpv4Cidr(c)
+			if last > p.last || p.overlap(first, last) != nil {
+				free = false
+				break
+			}
+		}
+		if !free {
+			continue
+		}
+		for _, c := range cidrs {
+			if err := p.Reserve(name, c); err != nil {
+				return nil, err
+			}
+		}
+		return cidrs, nil
+	}
+	return nil, fmt.Errorf("%s, no room for %d /%d cidr blocks", name, count, prefix)
+}
+
+// Free returns the unused space of the network as the largest possible cidr blocks.
+func (p *Planner) Free() []string {
+	free := []string{}
+	next := p.first
+	for _, s := range p.used {
+		if s.first > next {
+			free = append(free, rangeToCidrs(next, s.first-1)...)
+		}
+		if s.last+1 > next {
+			next = s.last + 1
+		}
+	}
+	if next <= p.last {
+		free = append(free, rangeToCidrs(next, p.last)...)
+	}
+	return free
+}
+
+func (p *Planner) overlap(first, last uint64) *span {
+	for i, s := range p.used {
+		if first <= s.last && s.first <= last {
+			return &p.used[i]
+		}
+	}
+	return nil
+}
+
+// CidrBlocks returns count consecutive cidr blocks starting with the given
+// cidr block, one for each availability zone of a subnet group.
+func CidrBlocks(cidrBlock string, count int) ([]string, error) {
+	cidrs := []string{cidrBlock}
+	for len(cidrs) < count {
+		next, err := NextCidrBlock(cidrs[len(cidrs)-1])
+		if err != nil {
+			return nil, err
+		}
+		cidrs = append(cidrs, next)
+	}
+	return cidrs, nil
+}
+
+// Overlaps returns true if the two cidr blocks share any addresses. Cidr blocks
+// of different address families never overlap.
+func Overlaps(a, b string) (bool, error) {
+	_, an, err := net.ParseCIDR(a)
+	if err != nil {
+		return false, err
+	}
+	_, bn, err := net.ParseCIDR(b)
+	if err != nil {
+		return false, err
+	}
+	if (an.IP.To4() == nil) != (bn.IP.To4() == nil) {
+		return false, nil
+	}
+	return an.Contains(bn.IP) || bn.Contains(an.IP), nil
+}
+
+func parseIpv4Cidr(cidrBlock string) (first, last uint64, ones int, err error) {
+	_, ipnet, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return
+	}
+	ip4 := ipnet.IP.To4()
+	if ip4 == nil {
+		err = fmt.Errorf("Cidr %s isn't an ipv4 cidr block", cidrBlock)
+		return
+	}
+	ones, _ = ipnet.Mask.Size()
+	first = uint64(binary.BigEndian.Uint32(ip4))
+	last = first + uint64(1)<<uint(32-ones) - 1
+	return
+}
+
+func ipv4String(addr uint64) string {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, uint32(addr))
+	return ip.String()
+}
+
+// rangeToCidrs covers the address range with the fewest cidr blocks.
+func rangeToCidrs(first, last uint64) []string {
+	cidrs := []string{}
+	for first <= last {
+		bits := uint(0)
+		for bits < 32 {
+			size := uint64(1) << (bits + 1)
+			if first%size != 0 || first+size-1 > last {
+				break
+			}
+			bits++
+		}
+		cidrs = append(cidrs, fmt.Sprintf("%s/%d", ipv4String(first), 32-bits))
+		first += uint64(1) << bits
+	}
+	return cidrs
+}
+
+// Contains returns true if every address of the inner cidr block is within the
+// outer cidr block.
+func Contains(outer, inner string) (bool, error) {
+	_, on, err := net.ParseCIDR(outer)
+	if err != nil {
+		return false, err
+	}
+	_, in, err := net.ParseCIDR(inner)
+	if err != nil {
+		return false, err
+	}
+	if (on.IP.To4() == nil) != (in.IP.To4() == nil) {
+		return false, nil
+	}
+	outerOnes, _ := on.Mask.Size()
+	innerOnes, _ := in.Mask.Size()
+	return outerOnes <= innerOnes && on.Contains(in.IP), nil
+}
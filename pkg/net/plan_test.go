@@ -0,0 +1,137 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package net
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlannerAllocate(t *testing.T) {
+	p, err := NewPlanner("10.0.0.0/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Reserve("bastion", "10.0.0.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	cidrs, err := p.Allocate("private", 20, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"10.0.16.0/20", "10.0.32.0/20", "10.0.48.0/20"}
+	if !reflect.DeepEqual(cidrs, expected) {
+		t.Errorf("Allocate = %v, expected %v", cidrs, expected)
+	}
+	cidrs, err = p.Allocate("public", 24, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"}
+	if !reflect.DeepEqual(cidrs, expected) {
+		t.Errorf("Allocate = %v, expected %v", cidrs, expected)
+	}
+	if err := p.Reserve("other", "10.0.2.128/25"); err == nil {
+		t.Errorf("Expected an overlapping reservation to fail")
+	}
+	if err := p.Reserve("other", "10.1.0.0/24"); err == nil {
+		t.Errorf("Expected a reservation outside of the network to fail")
+	}
+	if _, err := p.Allocate("huge", 17, 2); err == nil {
+		t.Errorf("Expected an allocation without room to fail")
+	}
+}
+
+func TestPlannerAllocateDoesNotWrap(t *testing.T) {
+	p, err := NewPlanner("10.0.0.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Reserve("first", "10.0.0.0/26"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Allocate("second", 26, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Allocate("third", 26, 2); err == nil {
+		t.Errorf("Expected an allocation that would overflow NextCidrBlock to fail")
+	}
+}
+
+func TestPlannerFree(t *testing.T) {
+	p, err := NewPlanner("10.0.0.0/22")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Reserve("a", "10.0.1.0/25"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"10.0.0.0/24", "10.0.1.128/25", "10.0.2.0/23"}
+	if free := p.Free(); !reflect.DeepEqual(free, expected) {
+		t.Errorf("Free = %v, expected %v", free, expected)
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	for _, c := range []struct {
+		a, b    string
+		overlap bool
+	}{
+		{"10.0.0.0/16", "10.0.5.0/24", true},
+		{"10.0.5.0/24", "10.0.0.0/16", true},
+		{"10.0.0.0/16", "10.1.0.0/16", false},
+		{"10.0.0.0/16", "2001:db8::/32", false},
+	} {
+		overlap, err := Overlaps(c.a, c.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if overlap != c.overlap {
+			t.Errorf("Overlaps(%s, %s) = %t, expected %t", c.a, c.b, overlap, c.overlap)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	for _, c := range []struct {
+		outer, inner string
+		contains     bool
+	}{
+		{"10.0.0.0/16", "10.0.5.0/24", true},
+		{"10.0.5.0/24", "10.0.0.0/16", false},
+		{"10.0.0.0/16", "10.0.0.0/16", true},
+		{"0.0.0.0/0", "2001:db8::/32", false},
+	} {
+		contains, err := Contains(c.outer, c.inner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contains != c.contains {
+			t.Errorf("Contains(%s, %s) = %t, expected %t", c.outer, c.inner, contains, c.contains)
+		}
+	}
+}
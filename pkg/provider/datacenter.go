@@ -32,13 +32,21 @@ import (
 )
 
 type DataCenter interface {
+	Target(string) (DataCenter, error)
+	AvailabilityZones([]string) []string
+	CidrBlock(string) string
+
 	NewNetwork(*config.Network) (resource.ProviderNetwork, error)
 	NewSubnet(resource.Network, *config.Subnet) (resource.ProviderSubnet, error)
 	NewSecurityGroup(resource.Network, *config.SecurityGroup) (resource.ProviderSecurityGroup, error)
 	NewNetworkPost(resource.Network, *config.Network) (resource.ProviderNetworkPost, error)
+	NewEndpoints(resource.Network, *config.Network) (resource.ProviderEndpoints, error)
 
 	NewCompute(*config.Compute) (resource.ProviderCompute, error)
 	NewKeyPair(*config.KeyPair) (resource.ProviderKeyPair, error)
+	NewAutoScalingGroup(resource.Pod, *config.Pod) (resource.ProviderAutoScalingGroup, error)
+	NewPlacementGroup(resource.Pod, *config.Pod) (resource.ProviderPlacementGroup, error)
+	NewLoadBalancer(resource.Pod, *config.Pod) (resource.ProviderLoadBalancer, error)
 	NewInstance(resource.Instance, *config.Instance) (resource.ProviderInstance, error)
 	NewVolume(resource.Compute, *config.Volume) (resource.ProviderVolume, error)
 	NewElasticIP(resource.ElasticIP, resource.Instance) (resource.ProviderElasticIP, error)
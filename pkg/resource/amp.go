@@ -34,6 +34,7 @@ import "github.com/cisco/arc/pkg/config"
 type StaticAmp interface {
 	Name() string
 	SecurityTags() config.SecurityTags
+	TagPolicy() *config.TagPolicy
 }
 
 // Amp provides the resource interface used for the common amp object
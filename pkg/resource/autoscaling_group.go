@@ -0,0 +1,56 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package resource
+
+// DynamicAutoScalingGroup provides access to the dynamic portion of the
+// autoscaling group managing the instances of a pod.
+type DynamicAutoScalingGroup interface {
+
+	// Id returns the id of the autoscaling group.
+	Id() string
+
+	// Available returns true if the group holds an instance that hasn't been
+	// claimed by one of the pod's instances.
+	Available() bool
+
+	// Release allows the instances of the group to be destroyed without
+	// the group replacing them.
+	Release() error
+
+	// SetUserData sets the user-data of the instances the group launches.
+	SetUserData([]byte)
+
+	Auditor
+}
+
+// ProviderAutoScalingGroup provides a resource interface for the provider
+// supplied autoscaling group. Pods backed by an autoscaling group route
+// load, info, create, destroy, start and stop requests to it.
+type ProviderAutoScalingGroup interface {
+	Resource
+	DynamicAutoScalingGroup
+}
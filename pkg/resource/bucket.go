@@ -71,4 +71,7 @@ type ProviderBucket interface {
 
 	// EnableEncryption error enables encryption on the buckets by default using a kms key created by amp.
 	EnableEncryption(EncryptionKey) error
+
+	// Tags returns the tags of the bucket.
+	Tags() (map[string]string, error)
 }
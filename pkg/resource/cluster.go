@@ -57,7 +57,7 @@ type Cluster interface {
 	FindPod(name string) Pod
 
 	// Find instance by name. This implies instances are named uniquely.
-	// The name takes the form "<pod name>-<instance number>".
+	// The name is expanded from the naming template, "<pod name>-<instance number>" by default.
 	FindInstance(name string) Instance
 
 	// Find the instance by ip address.
@@ -98,6 +98,11 @@ type Cluster interface {
 	Replace(req *route.Request) route.Response
 	PostReplace(req *route.Request) route.Response
 
+	// Resizer
+	PreResize(req *route.Request) route.Response
+	Resize(req *route.Request) route.Response
+	PostResize(req *route.Request) route.Response
+
 	// Auditor
 	AuditOverride
 }
@@ -26,6 +26,8 @@
 
 package resource
 
+import "github.com/cisco/arc/pkg/config"
+
 // StaticCompuite provides the interface to the static portion of the
 // compute object. This information is provided via config file and is implemented
 // by config.Compute.
@@ -35,6 +37,7 @@ type StaticCompute interface {
 	DeployVersion() int
 	SecretsVersion() int
 	AideVersion() int
+	Naming() string
 }
 
 // DyanmicCompute provides the interface to the dynamic portion of compute.
@@ -48,6 +51,13 @@ type DynamicCompute interface {
 
 	// AuditInstance identifies any instances that have been deployed but are not in the configuration.
 	AuditInstances(flags ...string) error
+
+	// AuditTags identifies any resources whose tags violate the tag policy.
+	AuditTags(policy *config.TagPolicy, flags ...string) error
+
+	// FixTags sets the tags of the resources whose tags violate the tag policy.
+	// Values that aren't inherited are taken from the defaults.
+	FixTags(policy *config.TagPolicy, defaults map[string]string) error
 }
 
 // Compute provides the resource interface used for the common compute
@@ -75,7 +85,7 @@ type Compute interface {
 	FindPod(name string) Pod
 
 	// Find instance by name. This implies instances are named uniquely.
-	// The name takes the form "<pod name>-<instance number>".
+	// The name is expanded from the naming template, "<pod name>-<instance number>" by default.
 	FindInstance(name string) Instance
 
 	// Find the instance by ip address.
@@ -88,4 +98,9 @@ type Compute interface {
 // ProviderCompute provides a resource interface for the provider supplied compute.
 type ProviderCompute interface {
 	DynamicCompute
+
+	// LoadCache populates the provider caches prior to loading the compute
+	// resources. If names are given the caches may be limited to the named
+	// instances. If readOnly is set a recent copy of the caches may be reused.
+	LoadCache(names []string, readOnly bool) error
 }
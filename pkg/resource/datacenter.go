@@ -30,6 +30,7 @@ import "github.com/cisco/arc/pkg/config"
 
 type StaticDataCenter interface {
 	SecurityTags() config.SecurityTags
+	TagPolicy() *config.TagPolicy
 }
 
 // DataCenter provides the resource interface used for the common datacenter
@@ -26,6 +26,8 @@
 
 package resource
 
+import "github.com/cisco/arc/pkg/config"
+
 // StaticDns provides the interface to the static portion of dns.
 // This information is provided via config file and is implemented
 // config.Dns.
@@ -45,6 +47,12 @@ type DynamicDns interface {
 
 	// AuditDnsRecords checks for any dns records that are unnamedo or deployed but not configured
 	AuditDnsRecords(flags ...string) error
+
+	// AuditTags checks the tags of the hosted zone against the tag policy.
+	AuditTags(policy *config.TagPolicy, flags ...string) error
+
+	// FixTags sets the tags of the hosted zone that violate the tag policy.
+	FixTags(policy *config.TagPolicy, defaults map[string]string) error
 }
 
 // Dns provides the resource interface used for the common dns object
@@ -65,9 +73,27 @@ type Dns interface {
 	// ARecords provides access to Dns' A records.
 	ARecords() DnsRecords
 
+	// AAAARecords provides access to Dns' AAAA records.
+	AAAARecords() DnsRecords
+
 	// CNameRecords provides access to Dns' CNAME records.
 	CNameRecords() DnsRecords
 
+	// TXTRecords provides access to Dns' TXT records.
+	TXTRecords() DnsRecords
+
+	// MXRecords provides access to Dns' MX records.
+	MXRecords() DnsRecords
+
+	// SRVRecords provides access to Dns' SRV records.
+	SRVRecords() DnsRecords
+
+	// CAARecords provides access to Dns' CAA records.
+	CAARecords() DnsRecords
+
+	// AliasRecords provides access to Dns' alias records.
+	AliasRecords() DnsRecords
+
 	// DataCenter provides access to the DataCenter resource.
 	DataCenter() DataCenter
 }
@@ -75,4 +101,9 @@ type Dns interface {
 // ProviderDns provides an interface for the provider supplied dns resource.
 type ProviderDns interface {
 	DynamicDns
+
+	// LoadCache populates the provider dns record cache prior to loading
+	// the dns records. If names are given only the records with those names are
+	// cached. If readOnly is set a recent copy of the cache may be reused.
+	LoadCache(names []string, readOnly bool) error
 }
@@ -26,7 +26,10 @@
 
 package resource
 
-import "github.com/cisco/arc/pkg/route"
+import (
+	"github.com/cisco/arc/pkg/config"
+	"github.com/cisco/arc/pkg/route"
+)
 
 // StaticInstance provides the interface to the static portion of the
 // instance. This information is provided via config file and is implemented
@@ -64,16 +67,55 @@ type DynamicInstance interface {
 	// Stopped returns true if the instance is currently stopped.
 	Stopped() bool
 
+	// Pending returns true if the instance is in the process of starting.
+	Pending() bool
+
+	// Stopping returns true if the instance is in the process of stopping.
+	Stopping() bool
+
+	// ShuttingDown returns true if the instance is in the process of being terminated.
+	ShuttingDown() bool
+
+	// Lifecycle returns how the instance was purchased, such as on-demand or spot.
+	Lifecycle() string
+
+	// AvailabilityZone returns the availability zone the instance is running in.
+	AvailabilityZone() string
+
+	// DeployedType returns the instance type the instance is running as.
+	DeployedType() string
+
+	// SetInstanceType changes the instance type of a stopped instance.
+	SetInstanceType(instanceType string) error
+
 	// PrivateIPAddress returns the private IP address associated with the instance.
 	PrivateIPAddress() string
 
 	// PublicIPAddress returns the public IP address associated with the instance.
 	PublicIPAddress() string
 
+	// Ipv6Address returns the IPv6 address of an instance on a dual stack subnet.
+	Ipv6Address() string
+
 	// SetTags sets the tags for the instance such as who created it and the last person
 	// that modified the instance.
 	SetTags(map[string]string) error
 
+	// FixTags sets the tags of the instance, its volumes and elastic ip that
+	// violate the tag policy. Values that aren't inherited are taken from the defaults.
+	FixTags(policy *config.TagPolicy, defaults map[string]string) error
+
+	// Baked returns true if the instance was launched from the image baked for its pod.
+	Baked() bool
+
+	// Adopted returns true if the instance was taken from the pod's autoscaling
+	// group when loaded and has yet to be claimed by creating it.
+	Adopted() bool
+
+	// CreateImage creates an image with the given name and tags from the instance.
+	// It returns the id of the image once it is available.
+	CreateImage(name string, tags map[string]string) (string, error)
+
 	Auditor
 }
 
@@ -174,6 +216,11 @@ type Instance interface {
 	Replace(req *route.Request) route.Response
 	PostReplace(req *route.Request) route.Response
 
+	// Resizer
+	PreResize(req *route.Request) route.Response
+	Resize(req *route.Request) route.Response
+	PostResize(req *route.Request) route.Response
+
 	AuditOverride
 }
 
@@ -183,4 +230,7 @@ type Instance interface {
 type ProviderInstance interface {
 	Resource
 	DynamicInstance
+
+	// SetUserData sets the user-data passed to the instance when it is launched.
+	SetUserData([]byte)
 }
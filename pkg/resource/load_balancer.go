@@ -0,0 +1,60 @@
+//
+// Copyright (c) 2018, Cisco Systems
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice, this
+//   list of conditions and the following disclaimer in the documentation and/or
+//   other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON
+// ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package resource
+
+// DynamicLoadBalancer provides access to the dynamic portion of the
+// load balancer the instances of a pod are registered with.
+type DynamicLoadBalancer interface {
+
+	// Id returns the id of the load balancer.
+	Id() string
+
+	// DNSName returns the dns name of the load balancer. Pod cname records
+	// with load_balancer access point at it.
+	DNSName() string
+
+	// HostedZoneId returns the id of the hosted zone of the load balancer's
+	// dns name. Pod alias records point at it.
+	HostedZoneId() string
+
+	// Register adds the instance to the targets of the load balancer.
+	Register(i Instance) error
+
+	// Deregister removes the instance from the targets of the load balancer,
+	// waiting for its in flight connections to drain.
+	Deregister(i Instance) error
+
+	Auditor
+}
+
+// ProviderLoadBalancer provides a resource interface for the provider
+// supplied load balancer. Pods with a load balancer route load, info,
+// create and destroy requests to it.
+type ProviderLoadBalancer interface {
+	Resource
+	DynamicLoadBalancer
+}
@@ -27,6 +27,7 @@
 package resource
 
 import (
+	"github.com/cisco/arc/pkg/config"
 	"github.com/cisco/arc/pkg/help"
 	"github.com/cisco/arc/pkg/route"
 )
@@ -37,10 +38,16 @@ import (
 type StaticNetwork interface {
 	Name() string
 	CidrBlock() string
+	Ipv6() bool
 	AvailabilityZones() []string
 	DnsNameServers() []string
 	CidrAliases() map[string]string
 	CidrGroups() map[string][]string
+	Peerings() config.Peerings
+	Endpoints() config.Endpoints
+	NetworkAcls() config.NetworkAcls
+	FlowLog() *config.FlowLog
+	NatGateways() string
 }
 
 // DyanmicNetwork provides the interface to the dynamic portion of the
@@ -59,6 +66,19 @@ type DynamicNetwork interface {
 
 	// AuditSecgroups indentifies any secgroups that have been deployed but are not configured.
 	AuditSecgroups(flags ...string) error
+
+	// ImportSecgroup converts a deployed secgroup, given by id or name, into a secgroup configuration.
+	ImportSecgroup(group string) (*config.SecurityGroup, error)
+
+	// AdoptSecgroup names a deployed secgroup so it is managed as the configured secgroup of that name.
+	AdoptSecgroup(req *route.Request, group, name string) error
+
+	// AuditTags identifies any resources whose tags violate the tag policy.
+	AuditTags(policy *config.TagPolicy, flags ...string) error
+
+	// FixTags sets the tags of the resources whose tags violate the tag policy.
+	// Values that aren't inherited are taken from the defaults.
+	FixTags(policy *config.TagPolicy, defaults map[string]string) error
 }
 
 // Network provides the resource interface used for the common network
@@ -118,3 +138,16 @@ type ProviderNetworkPost interface {
 	// Additional help commands for provider specific requests.
 	HelpCommands() []help.Command
 }
+
+// ProviderEndpoints provides a resource interface for the provider's endpoints.
+// Endpoints are created after the network's security groups, since endpoints
+// may use them.
+type ProviderEndpoints interface {
+	Resource
+
+	// Does the ProviderEndpoints support provider specific requests?
+	CanRoute(*route.Request) bool
+
+	// Additional help commands for provider specific requests.
+	HelpCommands() []help.Command
+}
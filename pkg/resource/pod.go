@@ -58,8 +58,16 @@ type Pod interface {
 	// Instances provides access to Pod's child instances.
 	Instances() Instances
 
+	// ProviderAutoScalingGroup provides access to the autoscaling group managing
+	// the pod's instances. It is nil unless the pod is backed by a group.
+	ProviderAutoScalingGroup() ProviderAutoScalingGroup
+
+	// ProviderLoadBalancer provides access to the load balancer the pod's
+	// instances are registered with. It is nil unless the pod has a load balancer.
+	ProviderLoadBalancer() ProviderLoadBalancer
+
 	// Find instance by name. This implies instances are named uniquely.
-	// The name takes the form "<pod name>-<instance number>".
+	// The name is expanded from the naming template, "<pod name>-<instance number>" by default.
 	FindInstance(name string) Instance
 
 	// Find the instance by ip address.
@@ -124,6 +132,11 @@ type Pod interface {
 	Replace(req *route.Request) route.Response
 	PostReplace(req *route.Request) route.Response
 
+	// Resizer
+	PreResize(req *route.Request) route.Response
+	Resize(req *route.Request) route.Response
+	PostResize(req *route.Request) route.Response
+
 	// Auditor
 	AuditOverride
 }
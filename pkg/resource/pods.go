@@ -35,7 +35,7 @@ type Pods interface {
 	Find(name string) Pod
 
 	// Find instance by name. This implies instances are named uniquely.
-	// The name takes the form "<pod name>-<instance number>".
+	// The name is expanded from the naming template, "<pod name>-<instance number>" by default.
 	FindInstance(name string) Instance
 
 	// Find the instance by ip address.
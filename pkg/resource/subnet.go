@@ -35,6 +35,7 @@ type StaticSubnet interface {
 	Access() string
 	AvailabilityZone() string
 	ManageRoutes() bool
+	Ipv6Subnet() string
 }
 
 // DyanmicSubnet provides the interface to the dynamic portion of the
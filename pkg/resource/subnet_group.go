@@ -26,6 +26,8 @@
 
 package resource
 
+import "github.com/cisco/arc/pkg/config"
+
 // StaticSubnetGroup provides the interface to the static portion of the
 // subnet group. This information is provided via config file and is implemented
 // by config.SubnetGroup.
@@ -34,6 +36,10 @@ type StaticSubnetGroup interface {
 	CidrBlock() string
 	Access() string
 	ManageRoutes() bool
+	NetworkAcl() string
+	Ipv6Subnet() string
+	FlowLog() *config.FlowLog
+	Routes() config.StaticRoutes
 }
 
 // SubnetGroup provides the resource interface used for the common subnet group
@@ -60,6 +60,24 @@ type DynamicVolume interface {
 	// SetTags sets the tags for the volume.
 	SetTags(map[string]string) error
 
+	// Snapshot creates a snapshot of the volume with the given tags and returns its id.
+	Snapshot(map[string]string) (string, error)
+
+	// Snapshots returns the snapshots of the volume that have all of the given tags.
+	Snapshots(map[string]string) ([]*Snapshot, error)
+
+	// DeleteSnapshot deletes the snapshot with the given id.
+	DeleteSnapshot(string) error
+
+	// Restore replaces the deployed volume with a new volume created from the
+	// snapshot with the given id. The replaced volume is detached but not destroyed.
+	// It returns the id of the replaced volume.
+	Restore(string) (string, error)
+
+	// UseSnapshot sets the snapshot with the given id as the source of the volume
+	// when it is created.
+	UseSnapshot(string)
+
 	// Info prints out volume information to the console.
 	Info()
 
@@ -42,6 +42,15 @@ const (
 	Replace
 	Destroy
 	Audit
+	Bake
+	Snapshot
+	Restore
+	Resize
+	Scale
+	Plan
+	Reach
+	Import
+	Flows
 )
 
 var c2s = map[Command][]string{
@@ -57,6 +66,15 @@ var c2s = map[Command][]string{
 	Replace:   {"replace", "upgrade"},
 	Destroy:   {"destroy", "delete", "nuke"},
 	Audit:     {"audit"},
+	Bake:      {"bake"},
+	Snapshot:  {"snapshot", "backup"},
+	Restore:   {"restore"},
+	Resize:    {"resize"},
+	Scale:     {"scale"},
+	Plan:      {"plan"},
+	Reach:     {"reach"},
+	Import:    {"import"},
+	Flows:     {"flows"},
 }
 
 var s2c = map[string]Command{
@@ -81,6 +99,16 @@ var s2c = map[string]Command{
 	"delete":    Destroy,
 	"nuke":      Destroy,
 	"audit":     Audit,
+	"bake":      Bake,
+	"snapshot":  Snapshot,
+	"backup":    Snapshot,
+	"restore":   Restore,
+	"resize":    Resize,
+	"scale":     Scale,
+	"plan":      Plan,
+	"reach":     Reach,
+	"import":    Import,
+	"flows":     Flows,
 }
 
 func (c Command) String() string {
@@ -90,3 +118,12 @@ func (c Command) String() string {
 	}
 	return cmdList[0]
 }
+
+// ReadOnly returns true for commands that do not modify any resources.
+func (c Command) ReadOnly() bool {
+	switch c {
+	case Help, Config, Info, Audit, Plan, Reach, Flows:
+		return true
+	}
+	return false
+}
@@ -38,3 +38,16 @@ func TestCommand2String(t *testing.T) {
 		t.Errorf("Expected %q, got %q\n", "info", s)
 	}
 }
+
+func TestCommandReadOnly(t *testing.T) {
+	for _, c := range []Command{Help, Config, Info, Audit, Plan, Reach, Flows} {
+		if !c.ReadOnly() {
+			t.Errorf("Expected %q to be read only\n", c.String())
+		}
+	}
+	for _, c := range []Command{None, Load, Create, Provision, Start, Stop, Restart, Replace, Destroy, Bake, Snapshot, Restore, Resize, Scale, Import} {
+		if c.ReadOnly() {
+			t.Errorf("Expected %q not to be read only\n", c.String())
+		}
+	}
+}
@@ -26,6 +26,8 @@
 
 package route
 
+import "strings"
+
 type Flags struct {
 	flags []string
 }
@@ -49,6 +51,15 @@ func (f *Flags) isSet(s string) bool {
 	return false
 }
 
+func (f *Flags) value(key string) string {
+	for _, t := range f.flags {
+		if strings.HasPrefix(t, key+"=") {
+			return strings.TrimPrefix(t, key+"=")
+		}
+	}
+	return ""
+}
+
 func (f *Flags) Set(s []string) {
 	f.flags = s
 }
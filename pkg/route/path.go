@@ -60,3 +60,9 @@ func (p *Path) Append(s string) *Path {
 	p.path = append(p.path, s)
 	return p
 }
+
+func (p *Path) Clone() *Path {
+	return &Path{
+		path: append([]string{}, p.path...),
+	}
+}
@@ -118,3 +118,22 @@ func TestPathAppend(t *testing.T) {
 		t.Errorf("Expected top to be %q, got %q\n", "one", s)
 	}
 }
+
+func TestPathClone(t *testing.T) {
+	p := NewPath()
+	p.Append("one").Append("two")
+
+	c := p.Clone()
+	if len(c.path) != 2 {
+		t.Errorf("Expected path of length 2, got %q\n", c.path)
+	}
+
+	c.Pop()
+	if len(p.path) != 2 {
+		t.Errorf("Expected original path of length 2, got %q\n", p.path)
+	}
+	s := c.Top()
+	if s != "two" {
+		t.Errorf("Expected top to be %q, got %q\n", "two", s)
+	}
+}
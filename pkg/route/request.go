@@ -60,6 +60,13 @@ func (r *Request) Clone(c Command) *Request {
 	}
 }
 
+// CloneWithPath is like Clone except the path of the original request is kept.
+func (r *Request) CloneWithPath(c Command) *Request {
+	req := r.Clone(c)
+	req.path = r.path.Clone()
+	return req
+}
+
 func (r *Request) Parse(params []string) {
 	for i, s := range params {
 		if s == "" {
@@ -109,6 +116,12 @@ func (r *Request) Flag(s string) bool {
 	return r.flags.isSet(s)
 }
 
+// Value returns the value of a flag of the form "key=value", or an empty
+// string if the flag isn't set.
+func (r *Request) Value(key string) string {
+	return r.flags.value(key)
+}
+
 func (r *Request) TestFlag() bool {
 	return r.flags.isSet("test")
 }
@@ -173,3 +173,18 @@ func TestRequestParse(t *testing.T) {
 	req.Parse(strings.Split("secgroup common "+Create.String()+" norules", " "))
 	check(t, req, 2, Create, 1)
 }
+
+func TestRequestValue(t *testing.T) {
+	req := NewRequest("dc", "user", "time")
+	if req == nil {
+		t.Fatalf("Expected req, got nil\n")
+	}
+	req.Parse(strings.Split("pod web "+Restore.String()+" snapshot=nightly podonly", " "))
+	check(t, req, 2, Restore, 2)
+	if v := req.Value("snapshot"); v != "nightly" {
+		t.Errorf("Expected %q, got %q\n", "nightly", v)
+	}
+	if v := req.Value("podonly"); v != "" {
+		t.Errorf("Expected empty value, got %q\n", v)
+	}
+}